@@ -0,0 +1,114 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// AppendOptions ... struct
+type AppendOptions struct {
+	// Lock serializes writers, in-process and (where supported) across processes.
+	Lock bool
+	// Sync calls fsync after every line.
+	Sync bool
+	// Perm is used when the file is created.
+	Perm os.FileMode
+}
+
+var appendMutexes = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{
+	m: map[string]*sync.Mutex{},
+}
+
+func appendMutex(argPath string) *sync.Mutex {
+
+	appendMutexes.Lock()
+	defer appendMutexes.Unlock()
+
+	mu, ok := appendMutexes.m[argPath]
+	if !ok {
+		mu = &sync.Mutex{}
+		appendMutexes.m[argPath] = mu
+	}
+
+	return mu
+}
+
+// MarshalLine ... func
+// returns the element as one JSON line (terminated by "\n").
+func (me *JSONElement) MarshalLine() ([]byte, error) {
+
+	buf := &bytes.Buffer{}
+
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(me.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("Encode: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// AppendToFile ... func
+func (me *JSONElement) AppendToFile(argPath string) error {
+
+	return me.AppendToFileWithOptions(argPath, AppendOptions{
+		Lock: true,
+		Perm: 0644,
+	})
+}
+
+// AppendToFileWithOptions ... func
+func (me *JSONElement) AppendToFileWithOptions(argPath string, opts AppendOptions) error {
+
+	line, err := me.MarshalLine()
+	if err != nil {
+		return me.Errorf("AppendToFile: %s: %w", argPath, err)
+	}
+
+	perm := opts.Perm
+	if perm == 0 {
+		perm = 0644
+	}
+
+	if opts.Lock {
+		mu := appendMutex(argPath)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	f, err := os.OpenFile(argPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perm)
+	if err != nil {
+		return me.Errorf("AppendToFile: OpenFile: %s: %w", argPath, err)
+	}
+	defer f.Close()
+
+	if opts.Lock {
+		err = lockFile(f)
+		if err != nil {
+			return me.Errorf("AppendToFile: lockFile: %s: %w", argPath, err)
+		}
+		defer unlockFile(f)
+	}
+
+	_, err = f.Write(line)
+	if err != nil {
+		return me.Errorf("AppendToFile: Write: %s: %w", argPath, err)
+	}
+
+	if opts.Sync {
+		err = f.Sync()
+		if err != nil {
+			return me.Errorf("AppendToFile: Sync: %s: %w", argPath, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build windows || plan9 || js
+// +build windows plan9 js
+
+package dynajson
+
+import (
+	"os"
+)
+
+// no advisory lock here, only the in-process mutex applies.
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package dynajson
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendToFile(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "dynajson")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	logPath := filepath.Join(dir, "audit.ndjson")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			elm := NewAsMap()
+			elm.Put("i", i)
+			elm.Put("msg", "line\nbreak <&>")
+
+			assert.Nil(elm.AppendToFile(logPath))
+		}(i)
+	}
+	wg.Wait()
+
+	elm := NewAsArray()
+	elm.Append(1, "a")
+	assert.Nil(elm.AppendToFileWithOptions(logPath, AppendOptions{Sync: true}))
+
+	f, err := os.Open(logPath)
+	assert.Nil(err)
+	defer f.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		root, err := NewByBytes(scanner.Bytes())
+		assert.Nil(err)
+
+		if root.IsMap() {
+			assert.Equal("line\nbreak <&>", root.Select("msg").AsString())
+		}
+
+		lines++
+	}
+
+	assert.Equal(21, lines)
+}
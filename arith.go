@@ -0,0 +1,139 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"strconv"
+	"sync"
+)
+
+// SetLocker ... func
+// elements derived from me afterwards share l; only Increment, Decrement and
+// MultiplyBy hold it while they run. Put, Append, Delete and the other mutators
+// do not take it, callers mixing them with the helpers must lock l themselves.
+func (me *JSONElement) SetLocker(l sync.Locker) {
+	me.locker = l
+}
+
+func (me *JSONElement) lock() func() {
+
+	if me.locker == nil {
+		return func() {}
+	}
+
+	me.locker.Lock()
+
+	return me.locker.Unlock
+}
+
+// arithOp ... struct
+// exact is applied to json.Number integers, nil when the operand is not whole.
+type arithOp struct {
+	float func(float64) float64
+	exact func(*big.Int) *big.Int
+}
+
+// exactOperand ... func
+// fn bound to arg as a big.Int, nil when arg is not a whole number.
+func exactOperand(arg float64, fn func(v, arg *big.Int) *big.Int) func(*big.Int) *big.Int {
+
+	if math.IsInf(arg, 0) || arg != math.Trunc(arg) {
+		return nil
+	}
+
+	bigArg, _ := big.NewFloat(arg).Int(nil)
+
+	return func(v *big.Int) *big.Int {
+		return fn(v, bigArg)
+	}
+}
+
+func (me *JSONElement) arith(name, path string, missingOK bool, op arithOp) (float64, error) {
+
+	defer me.lock()()
+
+	keys := me.SlashPath2Keys(path)
+
+	parent, key, err := me.selectParent(keys)
+	if err != nil {
+		return 0, me.Errorf("%s: %s: %w", name, path, err)
+	}
+
+	cur := parent.Select(key).Raw()
+	if cur == nil {
+		if !missingOK {
+			return 0, me.Errorf("%s: %s: Null Object", name, path)
+		}
+
+		cur = 0
+	}
+
+	var val interface{}
+	var ret float64
+
+	switch typed := cur.(type) {
+	case int:
+		ret = op.float(float64(typed))
+		val = ret
+		if ret == math.Trunc(ret) && math.Abs(ret) < math.MaxInt32 {
+			val = int(ret)
+		}
+	case float64:
+		ret = op.float(typed)
+		val = ret
+	case json.Number:
+		// UseNumber integers are not rounded through float64
+		if i, ok := new(big.Int).SetString(typed.String(), 10); ok && op.exact != nil {
+			i = op.exact(i)
+			ret, _ = new(big.Float).SetInt(i).Float64()
+			val = json.Number(i.String())
+			break
+		}
+
+		ret = op.float(numberFloat(typed))
+		val = ret
+		if !math.IsInf(ret, 0) && !math.IsNaN(ret) {
+			val = json.Number(strconv.FormatFloat(ret, 'g', -1, 64))
+		}
+	default:
+		return 0, me.Errorf("%s: %s: Not Number: %T", name, path, cur)
+	}
+
+	err = parent.setChild(key, val)
+	if err != nil {
+		return 0, me.Errorf("%s: %s: %w", name, path, err)
+	}
+
+	return ret, nil
+}
+
+// Increment ... func
+// adds delta to the number at path ("/" separated), a missing map entry counts as 0.
+// int values stay int while the result is whole, json.Number integers are
+// computed exactly and stay json.Number. Returns the new value.
+func (me *JSONElement) Increment(path string, delta float64) (float64, error) {
+
+	return me.arith("Increment", path, true, arithOp{
+		float: func(v float64) float64 { return v + delta },
+		exact: exactOperand(delta, func(v, arg *big.Int) *big.Int { return v.Add(v, arg) }),
+	})
+}
+
+// Decrement ... func
+func (me *JSONElement) Decrement(path string, delta float64) (float64, error) {
+
+	return me.arith("Decrement", path, true, arithOp{
+		float: func(v float64) float64 { return v - delta },
+		exact: exactOperand(delta, func(v, arg *big.Int) *big.Int { return v.Sub(v, arg) }),
+	})
+}
+
+// MultiplyBy ... func
+func (me *JSONElement) MultiplyBy(path string, factor float64) (float64, error) {
+
+	return me.arith("MultiplyBy", path, false, arithOp{
+		float: func(v float64) float64 { return v * factor },
+		exact: exactOperand(factor, func(v, arg *big.Int) *big.Int { return v.Mul(v, arg) }),
+	})
+}
@@ -0,0 +1,92 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArith(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"quota": {"used": 10, "ratio": 0.5}, "list": [1, 2]}`)
+	assert.Nil(err)
+
+	v, err := root.Increment("quota/used", 5)
+	assert.Nil(err)
+	assert.Equal(15.0, v)
+	assert.Equal(15, root.Select("quota", "used").AsInt())
+
+	v, err = root.Decrement("list/1", 3)
+	assert.Nil(err)
+	assert.Equal(-1.0, v)
+
+	v, err = root.MultiplyBy("quota/ratio", 3)
+	assert.Nil(err)
+	assert.Equal(1.5, v)
+
+	v, err = root.Increment("quota/new", 1)
+	assert.Nil(err)
+	assert.Equal(1, root.Select("quota", "new").Raw())
+
+	_, err = root.Increment("quota/new", 0.5)
+	assert.Nil(err)
+	assert.Equal(1.5, root.Select("quota", "new").Raw())
+
+	_, err = root.MultiplyBy("quota/none", 2)
+	assert.NotNil(err)
+
+	root.Put("str", "x")
+	_, err = root.Increment("str", 1)
+	assert.NotNil(err)
+
+	root.SetLocker(&sync.Mutex{})
+	counter := root.Select("quota")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter.Increment("hits", 1)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(100, root.Select("quota", "hits").AsInt())
+}
+
+func TestArithUseNumber(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesUseNumber([]byte(`{"n": 9007199254740993, "big": 123456789012345678901234567890, "f": 1.5}`))
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	_, err = root.Increment("n", 1)
+	assert.Nil(err)
+	assert.Equal(json.Number("9007199254740994"), root.Select("n").Raw())
+
+	_, err = root.Decrement("n", 3)
+	assert.Nil(err)
+	assert.Equal(json.Number("9007199254740991"), root.Select("n").Raw())
+
+	_, err = root.MultiplyBy("big", 10)
+	assert.Nil(err)
+	assert.Equal(json.Number("1234567890123456789012345678900"), root.Select("big").Raw())
+
+	// not whole, still json.Number
+	v, err := root.Increment("f", 0.25)
+	assert.Nil(err)
+	assert.Equal(1.75, v)
+	assert.Equal(json.Number("1.75"), root.Select("f").Raw())
+
+	_, err = root.MultiplyBy("n", 2)
+	assert.Nil(err)
+
+	assert.Equal(`{"big": 1234567890123456789012345678900, "f": 1.75, "n": 18014398509481982}`, root.String())
+}
@@ -0,0 +1,259 @@
+package dynajson
+
+import "sort"
+
+// InsertByPos ... func
+// inserts val before pos, pos == Count() appends and -1 inserts before the last
+// element. A parsed array becomes editable.
+func (me *JSONElement) InsertByPos(pos int, val interface{}) error {
+
+	if me.IsNil() {
+		return me.fail("InsertByPos", ErrorNilObject, "pos=[%d]", pos)
+	}
+
+	if me.isReadonly() {
+		return me.fail("InsertByPos", ErrorReadonly, "pos=[%d]", pos)
+	}
+
+	arr := asSlice(me.raw)
+	if arr == nil {
+		return me.fail("InsertByPos", ErrorNotArray, "pos=[%d]: %T", pos, me.raw)
+	}
+
+	idx := pos
+	if idx < 0 {
+		idx += len(arr)
+	}
+
+	if idx < 0 || idx > len(arr) {
+		return me.fail("InsertByPos", ErrorOverflow, "pos=[%d]: length %d", pos, len(arr))
+	}
+
+	newRaw := elm2Raw(val)
+	if err := me.checkAppendLimits(len(arr), []interface{}{newRaw}); err != nil {
+		return me.Errorf("pos=[%d]: %w", pos, err)
+	}
+
+	me.unshare()
+	me.makeEditable()
+
+	refArr := me.raw.(*[]interface{})
+
+	*refArr = append(*refArr, nil)
+	copy((*refArr)[idx+1:], (*refArr)[idx:])
+	(*refArr)[idx] = newRaw
+
+	me.order.adopt(newRaw)
+	me.notify("add", idx, newRaw)
+
+	return nil
+}
+
+// SetByPos ... func
+// replaces the element at pos, which must exist (-1 is the last).
+func (me *JSONElement) SetByPos(pos int, val interface{}) error {
+
+	return me.setChild(pos, val)
+}
+
+// editableArray ... func
+// the array of me ready to be rearranged in place.
+func (me *JSONElement) editableArray(op string) (*[]interface{}, error) {
+
+	if me.IsNil() {
+		return nil, me.fail(op, ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return nil, me.fail(op, ErrorReadonly, "")
+	}
+
+	if asSlice(me.raw) == nil {
+		return nil, me.fail(op, ErrorNotArray, "%T", me.raw)
+	}
+
+	me.unshare()
+	me.makeEditable()
+
+	return me.raw.(*[]interface{}), nil
+}
+
+// SortArray ... func
+// sorts the array of me in place by less (stable).
+func (me *JSONElement) SortArray(less func(a, b *JSONElement) bool) error {
+
+	refArr, err := me.editableArray("SortArray")
+	if err != nil {
+		return err
+	}
+
+	elms := make([]*JSONElement, len(*refArr))
+	for i, v := range *refArr {
+		elms[i] = me.child(i, v)
+	}
+
+	sort.SliceStable(elms, func(i, j int) bool {
+		return less(elms[i], elms[j])
+	})
+
+	for i, v := range elms {
+		(*refArr)[i] = v.raw
+	}
+
+	me.notifyReplaced()
+
+	return nil
+}
+
+// kindRank ... func
+// null < bool < number < string < array < map, for ordering mixed values.
+func kindRank(raw interface{}) int {
+
+	switch kindOf(raw) {
+	case KindNull:
+		return 0
+	case KindBool:
+		return 1
+	case KindNumber:
+		return 2
+	case KindString:
+		return 3
+	case KindArray:
+		return 4
+	}
+
+	return 5
+}
+
+// rawLess ... func
+// numbers by value, strings lexically, false before true, other kinds by kindRank.
+func rawLess(a, b interface{}) bool {
+
+	ra, rb := kindRank(a), kindRank(b)
+	if ra != rb {
+		return ra < rb
+	}
+
+	switch ra {
+	case 1:
+		return !a.(bool) && b.(bool)
+	case 2:
+		fa, _ := jpNumber(a)
+		fb, _ := jpNumber(b)
+		return fa < fb
+	case 3:
+		return a.(string) < b.(string)
+	}
+
+	return false
+}
+
+// SortArrayByKey ... func
+// sorts an array of maps by the value of key (see SortArray), elements without
+// key or not maps count as null and come first in ascending order.
+func (me *JSONElement) SortArrayByKey(key string, asc bool) error {
+
+	value := func(elm *JSONElement) interface{} {
+		obj, _ := elm.raw.(map[string]interface{})
+		return obj[key]
+	}
+
+	return me.SortArray(func(a, b *JSONElement) bool {
+		if asc {
+			return rawLess(value(a), value(b))
+		}
+		return rawLess(value(b), value(a))
+	})
+}
+
+// Reverse ... func
+// reverses the array of me in place.
+func (me *JSONElement) Reverse() error {
+
+	refArr, err := me.editableArray("Reverse")
+	if err != nil {
+		return err
+	}
+
+	arr := *refArr
+	for i, j := 0, len(arr)-1; i < j; i, j = i+1, j-1 {
+		arr[i], arr[j] = arr[j], arr[i]
+	}
+
+	me.notifyReplaced()
+
+	return nil
+}
+
+// sliceIndex ... func
+// a Python style index: negative counts from the end, clamped to [0, n].
+func sliceIndex(i, n int) int {
+
+	if i < 0 {
+		i += n
+	}
+
+	if i < 0 {
+		return 0
+	}
+
+	if i > n {
+		return n
+	}
+
+	return i
+}
+
+// Slice ... func
+// a new array of the elements from..to (to excluded, copied) like a[from:to]
+// of Python: negative indexes count from the end, out of range ones are
+// clamped and from >= to gives an empty array.
+func (me *JSONElement) Slice(from, to int) *JSONElement {
+
+	elms := me.arrayChildren("Slice")
+	if elms == nil {
+		return me.derive(nil)
+	}
+
+	from = sliceIndex(from, len(elms))
+	to = sliceIndex(to, len(elms))
+
+	arr := []interface{}{}
+	for i := from; i < to; i++ {
+		arr = append(arr, copyRaw(elms[i].raw))
+	}
+
+	return me.derive(&arr)
+}
+
+// DeleteRange ... func
+// removes the elements from..to (to excluded) in one operation, the indexes
+// follow Slice. A parsed array becomes editable.
+func (me *JSONElement) DeleteRange(from, to int) error {
+
+	refArr, err := me.editableArray("DeleteRange")
+	if err != nil {
+		return err
+	}
+
+	from = sliceIndex(from, len(*refArr))
+	to = sliceIndex(to, len(*refArr))
+
+	if from >= to {
+		return nil
+	}
+
+	for _, v := range (*refArr)[from:to] {
+		me.order.forget(v)
+	}
+
+	*refArr = append((*refArr)[:from], (*refArr)[to:]...)
+
+	end := me.batch()
+	for i := from; i < to; i++ {
+		me.notify("remove", from, nil)
+	}
+	end()
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertSetByPos(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [1, 2, 3]}`)
+	assert.Nil(err)
+
+	arr := root.Select("a")
+
+	assert.Nil(arr.InsertByPos(0, "first"))
+	assert.Nil(arr.InsertByPos(2, "mid"))
+	assert.Nil(arr.InsertByPos(arr.Count(), "last"))
+	assert.Equal(`{"a": ["first", 1, "mid", 2, 3, "last"]}`, root.String())
+
+	assert.Nil(arr.SetByPos(1, map[string]interface{}{"k": true}))
+	assert.Equal(`{"a": ["first", {"k": true}, "mid", 2, 3, "last"]}`, root.String())
+
+	// the array became editable
+	assert.Nil(arr.Append(4))
+	assert.Equal(7, root.Select("a").Count())
+
+	assert.NotNil(arr.InsertByPos(8, 0))
+	assert.NotNil(arr.InsertByPos(-8, 0))
+	assert.NotNil(arr.SetByPos(7, 0))
+	assert.NotNil(arr.SetByPos(-8, 0))
+
+	// negative positions count from the end as in Select
+	assert.Nil(arr.SetByPos(-1, 5))
+	assert.Nil(arr.InsertByPos(-1, "before"))
+	assert.Equal(`{"a": ["first", {"k": true}, "mid", 2, 3, "last", "before", 5]}`, root.String())
+	assert.Nil(arr.DeleteByPos(-1))
+	assert.Equal("before", arr.Select(-1).AsString())
+	assert.NotNil(root.InsertByPos(0, 0))
+	assert.NotNil(root.SetByPos(0, 0))
+
+	// a parsed root array
+	top, err := NewByString(`[1]`)
+	assert.Nil(err)
+	assert.Nil(top.InsertByPos(0, 0))
+	assert.Equal(`[0, 1]`, top.String())
+
+	arr.Readonly = true
+	assert.NotNil(arr.InsertByPos(0, 0))
+	assert.NotNil(arr.SetByPos(0, 0))
+}
+
+func TestSortArray(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"users": [{"name": "b", "age": 3}, {"name": "c", "age": 1}, {"age": 2}, {"name": "a", "age": 3}]}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	users := root.Select("users")
+
+	assert.Nil(users.SortArrayByKey("name", true))
+	assert.Equal(`[{"age": 2}, {"age": 3, "name": "a"}, {"age": 3, "name": "b"}, {"age": 1, "name": "c"}]`, root.Select("users").String())
+
+	// stable
+	assert.Nil(users.SortArrayByKey("age", false))
+	assert.Equal(`[{"age": 3, "name": "a"}, {"age": 3, "name": "b"}, {"age": 2}, {"age": 1, "name": "c"}]`, root.Select("users").String())
+
+	assert.Nil(users.Reverse())
+	assert.Equal(`[{"age": 1, "name": "c"}, {"age": 2}, {"age": 3, "name": "b"}, {"age": 3, "name": "a"}]`, root.Select("users").String())
+
+	nums, err := NewByString(`[3, "x", 1, null, true, 2.5]`)
+	assert.Nil(err)
+
+	assert.Nil(nums.SortArray(func(a, b *JSONElement) bool {
+		return rawLess(a.Raw(), b.Raw())
+	}))
+	assert.Equal(`[null, true, 1, 2.5, 3, "x"]`, nums.String())
+
+	assert.Nil(nums.Reverse())
+	assert.Equal(`["x", 3, 2.5, 1, true, null]`, nums.String())
+
+	assert.NotNil(root.Reverse())
+	assert.NotNil(root.SortArrayByKey("a", true))
+
+	users.Readonly = true
+	assert.NotNil(users.Reverse())
+}
+
+func TestNegativePos(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [1, 2, 3]}`)
+	assert.Nil(err)
+
+	assert.Equal(3, root.Select("a", -1).AsInt())
+	assert.Equal(1, root.Select("a").SelectByPos(-3).AsInt())
+	assert.Equal("/a/1", root.Select("a", -2).PathString())
+
+	warned := 0
+	root.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		warned++
+	}
+
+	assert.True(root.Select("a", -4).IsNil())
+	assert.Equal(1, warned)
+}
+
+func TestSlice(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`[0, 1, 2, 3, {"k": 4}]`)
+	assert.Nil(err)
+
+	assert.Equal("[1, 2]", root.Slice(1, 3).String())
+	assert.Equal(`[3, {"k": 4}]`, root.Slice(-2, 5).String())
+	assert.Equal("[0, 1, 2, 3]", root.Slice(0, -1).String())
+	assert.Equal(`[0, 1, 2, 3, {"k": 4}]`, root.Slice(-100, 100).String())
+	assert.Equal("[]", root.Slice(3, 1).String())
+
+	// copied
+	sub := root.Slice(4, 5)
+	assert.Nil(sub.Select(0).Put("k", 5))
+	assert.Equal(4, root.Select(4, "k").AsInt())
+
+	assert.Nil(sub.Append(6))
+	assert.Equal(5, root.Count())
+
+	assert.True(root.Select(4).Slice(0, 1).IsNil())
+}
+
+func TestDeleteByPosNegative(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := New(&[]interface{}{0, 1, 2, 3})
+
+	assert.Nil(root.DeleteByPos(-1))
+	assert.Equal("[0, 1, 2]", root.String())
+
+	assert.Nil(root.DeleteByPos(-3))
+	assert.Equal("[1, 2]", root.String())
+
+	// only a warning by default
+	assert.Nil(root.DeleteByPos(-3))
+	assert.Nil(root.DeleteByPos(2))
+
+	root.Config().StrictDelete = true
+
+	err := root.DeleteByPos(-3)
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.True(errors.Is(root.DeleteByPos(2), ErrNotFound))
+	assert.Equal("[1, 2]", root.String())
+
+	// a parsed array becomes editable
+	parsed, err := NewByString(`{"a": [1, 2]}`)
+	assert.Nil(err)
+	assert.Nil(parsed.Select("a").DeleteByPos(-1))
+	assert.Equal(`{"a": [1]}`, parsed.String())
+
+	obj := New(map[string]interface{}{"a": 1})
+	assert.Nil(obj.DeleteByKey("x"))
+
+	obj.Config().StrictDelete = true
+	assert.True(errors.Is(obj.DeleteByKey("x"), ErrNotFound))
+	assert.Nil(obj.DeleteByKey("a"))
+}
+
+func TestDeleteRange(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [0, 1, 2, 3, 4, 5]}`)
+	assert.Nil(err)
+
+	arr := root.Select("a")
+
+	assert.Nil(arr.DeleteRange(1, 3))
+	assert.Equal("[0, 3, 4, 5]", arr.String())
+
+	assert.Nil(arr.DeleteRange(-2, 100))
+	assert.Equal("[0, 3]", arr.String())
+
+	assert.Nil(arr.DeleteRange(1, 1))
+	assert.Equal(`{"a": [0, 3]}`, root.String())
+
+	assert.True(errors.Is(root.DeleteRange(0, 1), ErrNotArray))
+
+	root.Readonly = true
+	assert.True(errors.Is(root.Select("a").DeleteRange(0, 1), ErrReadonly))
+}
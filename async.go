@@ -0,0 +1,70 @@
+package dynajson
+
+import (
+	"context"
+)
+
+// Future ... struct
+// handle of a document being loaded in the background.
+type Future struct {
+	done chan struct{}
+	elm  *JSONElement
+	err  error
+}
+
+// LoadAsync ... func
+// starts NewByPath in a goroutine, ctx cancels the fetch.
+func LoadAsync(ctx context.Context, argPath string) *Future {
+
+	me := &Future{
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(me.done)
+
+		me.elm, me.err = loadByPath(ctx, argPath, loadConfig{})
+	}()
+
+	return me
+}
+
+// Done ... func
+func (me *Future) Done() <-chan struct{} {
+	return me.done
+}
+
+// Result ... func
+// blocks until loading has finished.
+func (me *Future) Result() (*JSONElement, error) {
+
+	<-me.done
+
+	return me.elm, me.err
+}
+
+// Err ... func
+// blocks until loading has finished.
+func (me *Future) Err() error {
+
+	<-me.done
+
+	return me.err
+}
+
+// WaitAll ... func
+// waits for every future and returns the first error in argument order.
+func WaitAll(futures ...*Future) error {
+
+	var first error
+
+	for _, v := range futures {
+
+		err := v.Err()
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,42 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAsync(t *testing.T) {
+
+	assert := assert.New(t)
+
+	jsonPath := filepath.Join(currentDir(), "testdata", "read2.json")
+
+	futures := []*Future{}
+	for i := 0; i < 5; i++ {
+		futures = append(futures, LoadAsync(context.Background(), jsonPath))
+	}
+
+	assert.Nil(WaitAll(futures...))
+
+	for _, v := range futures {
+		<-v.Done()
+
+		root, err := v.Result()
+		assert.Nil(err)
+		assert.Equal("example glossary", root.Select("glossary", "title").AsString())
+	}
+
+	bad := LoadAsync(context.Background(), filepath.Join(currentDir(), "testdata", "none.json"))
+	assert.NotNil(bad.Err())
+	assert.NotNil(WaitAll(futures[0], bad))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	canceled := LoadAsync(ctx, jsonPath)
+	assert.True(errors.Is(canceled.Err(), context.Canceled))
+}
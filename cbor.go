@@ -0,0 +1,474 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"unicode/utf8"
+)
+
+// MediaTypeCBOR ... const
+const MediaTypeCBOR = "application/cbor"
+
+func init() {
+	RegisterDecoder(MediaTypeCBOR, decodeCBOR, ".cbor")
+}
+
+func decodeCBOR(data []byte) (interface{}, error) {
+	return cborDecode(data, nil)
+}
+
+// binaryMaxDepth ... const
+// nesting the CBOR and MessagePack decoders reject instead of exhausting the stack.
+const binaryMaxDepth = 1000
+
+type cborDecoder struct {
+	data  []byte
+	pos   int
+	order *keyOrderState
+}
+
+func cborDecode(data []byte, order *keyOrderState) (interface{}, error) {
+
+	dec := &cborDecoder{data: data, order: order}
+
+	obj, err := dec.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("CBOR: offset %d: %w", dec.pos, err)
+	}
+
+	if dec.pos != len(data) {
+		return nil, fmt.Errorf("CBOR: offset %d: Trailing Data", dec.pos)
+	}
+
+	return obj, nil
+}
+
+func (me *cborDecoder) read(n uint64) ([]byte, error) {
+
+	if n > uint64(len(me.data)-me.pos) {
+		return nil, fmt.Errorf("Unexpected End")
+	}
+
+	b := me.data[me.pos : me.pos+int(n)]
+	me.pos += int(n)
+
+	return b, nil
+}
+
+// head ... func
+// major type, additional info and its argument; indefinite is info 31.
+func (me *cborDecoder) head() (byte, byte, uint64, error) {
+
+	b, err := me.read(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	major, info := b[0]>>5, b[0]&0x1f
+
+	var arg uint64
+
+	switch {
+	case info < 24:
+		arg = uint64(info)
+	case info <= 27:
+		p, err := me.read(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		for _, v := range p {
+			arg = arg<<8 | uint64(v)
+		}
+	case info == 31:
+		if major == 0 || major == 1 || major == 6 {
+			return 0, 0, 0, fmt.Errorf("Bad Indefinite Length: major %d", major)
+		}
+	default:
+		return 0, 0, 0, fmt.Errorf("Reserved Info: %d", info)
+	}
+
+	return major, info, arg, nil
+}
+
+func (me *cborDecoder) isBreak() bool {
+
+	if me.pos < len(me.data) && me.data[me.pos] == 0xff {
+		me.pos++
+		return true
+	}
+
+	return false
+}
+
+func cborInt(neg bool, arg uint64) interface{} {
+
+	if arg <= math.MaxInt64 {
+		if neg {
+			return int(-1 - int64(arg))
+		}
+		if uint64(int(arg)) == arg {
+			return int(arg)
+		}
+	}
+
+	f := float64(arg)
+	if neg {
+		return -1 - f
+	}
+
+	return f
+}
+
+// chunks ... func
+// the bytes of a (possibly indefinite length) byte or text string.
+func (me *cborDecoder) chunks(major, info byte, arg uint64) ([]byte, error) {
+
+	if info != 31 {
+		return me.read(arg)
+	}
+
+	buf := []byte{}
+
+	for !me.isBreak() {
+
+		m, i, a, err := me.head()
+		if err != nil {
+			return nil, err
+		}
+
+		if m != major || i == 31 {
+			return nil, fmt.Errorf("Bad Chunk: major %d", m)
+		}
+
+		p, err := me.read(a)
+		if err != nil {
+			return nil, err
+		}
+
+		buf = append(buf, p...)
+	}
+
+	return buf, nil
+}
+
+func (me *cborDecoder) value(depth int) (interface{}, error) {
+
+	if depth > binaryMaxDepth {
+		return nil, fmt.Errorf("Too Deep")
+	}
+
+	major, info, arg, err := me.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0, 1:
+		return cborInt(major == 1, arg), nil
+
+	case 2:
+		// RFC 8949 6.1: byte strings become base64url
+		p, err := me.chunks(major, info, arg)
+		if err != nil {
+			return nil, err
+		}
+		return base64.RawURLEncoding.EncodeToString(p), nil
+
+	case 3:
+		p, err := me.chunks(major, info, arg)
+		if err != nil {
+			return nil, err
+		}
+		if !utf8.Valid(p) {
+			return nil, fmt.Errorf("Invalid UTF-8")
+		}
+		return string(p), nil
+
+	case 4:
+		arr := []interface{}{}
+		for i := uint64(0); info == 31 || i < arg; i++ {
+			if info == 31 && me.isBreak() {
+				break
+			}
+			v, err := me.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+
+	case 5:
+		obj := map[string]interface{}{}
+		keys := []string{}
+		for i := uint64(0); info == 31 || i < arg; i++ {
+			if info == 31 && me.isBreak() {
+				break
+			}
+			k, err := me.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			v, err := me.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				key = fmt.Sprintf("%v", k)
+			}
+			if _, ok := obj[key]; !ok {
+				keys = append(keys, key)
+			}
+			obj[key] = v
+		}
+		if me.order != nil {
+			me.order.record(obj, keys)
+		}
+		return obj, nil
+
+	case 6:
+		v, err := me.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		// bignums, other tags are dropped
+		if arg == 2 || arg == 3 {
+			if s, ok := v.(string); ok {
+				p, _ := base64.RawURLEncoding.DecodeString(s)
+				f, _ := new(big.Float).SetInt(new(big.Int).SetBytes(p)).Float64()
+				if arg == 3 {
+					f = -1 - f
+				}
+				return f, nil
+			}
+		}
+		return v, nil
+	}
+
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		return halfFloat(uint16(arg)), nil
+	case 26:
+		return float64(math.Float32frombits(uint32(arg))), nil
+	case 27:
+		return math.Float64frombits(arg), nil
+	}
+
+	return nil, fmt.Errorf("Unsupported Simple Value: %d", arg)
+}
+
+func halfFloat(h uint16) float64 {
+
+	exp := int(h>>10) & 0x1f
+	mant := float64(h & 0x3ff)
+
+	var f float64
+
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+
+	if h&0x8000 != 0 {
+		return -f
+	}
+
+	return f
+}
+
+// NewByCBOR ... func
+// decodes RFC 8949 CBOR, map keys keep the order of data. Byte strings become
+// base64url strings and tags are dropped (bignums become numbers), as in RFC 8949 6.1.
+func NewByCBOR(data []byte) (*JSONElement, error) {
+
+	order := newKeyOrderState()
+
+	obj, err := cborDecode(data, order)
+	if err != nil {
+		return nil, fmt.Errorf("NewByCBOR: %w", err)
+	}
+
+	elm := New(obj)
+	elm.order = order
+
+	return elm, nil
+}
+
+// plainRaw ... func
+// values that were Put as other Go types (int64, structs, ...), as JSON would
+// read them with UseNumber.
+func plainRaw(raw interface{}) (interface{}, error) {
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNumbers(data)
+}
+
+type cborEncoder struct {
+	buf  *bytes.Buffer
+	elm  *JSONElement
+	less func(string, string) bool
+}
+
+func (me *cborEncoder) head(major byte, arg uint64) {
+
+	switch {
+	case arg < 24:
+		me.buf.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		me.buf.Write([]byte{major<<5 | 24, byte(arg)})
+	case arg <= math.MaxUint16:
+		me.buf.WriteByte(major<<5 | 25)
+		binary.Write(me.buf, binary.BigEndian, uint16(arg))
+	case arg <= math.MaxUint32:
+		me.buf.WriteByte(major<<5 | 26)
+		binary.Write(me.buf, binary.BigEndian, uint32(arg))
+	default:
+		me.buf.WriteByte(major<<5 | 27)
+		binary.Write(me.buf, binary.BigEndian, arg)
+	}
+}
+
+func (me *cborEncoder) int(v int64) {
+
+	if v < 0 {
+		me.head(1, uint64(-1-v))
+		return
+	}
+
+	me.head(0, uint64(v))
+}
+
+// float ... func
+// integral values are written as integers, others in the shortest exact float.
+func (me *cborEncoder) float(f float64) {
+
+	switch {
+	case math.IsNaN(f):
+		me.buf.Write([]byte{0xf9, 0x7e, 0x00})
+	case math.IsInf(f, 1):
+		me.buf.Write([]byte{0xf9, 0x7c, 0x00})
+	case math.IsInf(f, -1):
+		me.buf.Write([]byte{0xf9, 0xfc, 0x00})
+	case f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64:
+		me.int(int64(f))
+	case float64(float32(f)) == f:
+		me.buf.WriteByte(0xfa)
+		binary.Write(me.buf, binary.BigEndian, math.Float32bits(float32(f)))
+	default:
+		me.buf.WriteByte(0xfb)
+		binary.Write(me.buf, binary.BigEndian, math.Float64bits(f))
+	}
+}
+
+func (me *cborEncoder) encode(raw interface{}) error {
+
+	switch typed := raw.(type) {
+	case nil:
+		me.buf.WriteByte(0xf6)
+	case bool:
+		if typed {
+			me.buf.WriteByte(0xf5)
+		} else {
+			me.buf.WriteByte(0xf4)
+		}
+	case string:
+		me.head(3, uint64(len(typed)))
+		me.buf.WriteString(typed)
+	case int:
+		me.int(int64(typed))
+	case float64:
+		me.float(typed)
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			me.int(i)
+		} else if u, err := strconv.ParseUint(string(typed), 10, 64); err == nil {
+			me.head(0, u)
+		} else {
+			f, err := typed.Float64()
+			if err != nil {
+				return err
+			}
+			me.float(f)
+		}
+
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return err
+		}
+		return me.encode(obj)
+
+	case []interface{}, *[]interface{}:
+		arr := asSlice(typed)
+		me.head(4, uint64(len(arr)))
+		for _, v := range arr {
+			if err := me.encode(v); err != nil {
+				return err
+			}
+		}
+
+	case map[string]interface{}:
+		me.head(5, uint64(len(typed)))
+		for _, k := range me.elm.order.keys(typed, me.less) {
+			me.head(3, uint64(len(k)))
+			me.buf.WriteString(k)
+			if err := me.encode(typed[k]); err != nil {
+				return err
+			}
+		}
+
+	default:
+		obj, err := plainRaw(typed)
+		if err != nil {
+			return err
+		}
+		return me.encode(obj)
+	}
+
+	return nil
+}
+
+// ToCBOR ... func
+// encodes me as CBOR, integral numbers as integers. Map keys are in insertion
+// order for ordered elements, otherwise by KeyLess (lexical when nil).
+func (me *JSONElement) ToCBOR() ([]byte, error) {
+
+	less := me.KeyLess
+	if less == nil {
+		less = LexicalLess
+	}
+
+	enc := &cborEncoder{buf: &bytes.Buffer{}, elm: me, less: less}
+
+	err := enc.encode(me.raw)
+	if err != nil {
+		return nil, me.Errorf("ToCBOR: %w", err)
+	}
+
+	return enc.buf.Bytes(), nil
+}
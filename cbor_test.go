@@ -0,0 +1,91 @@
+package dynajson
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByCBOR(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// RFC 8949 Appendix A
+	vectors := map[string]string{
+		"00":                         "0",
+		"1903e8":                     "1000",
+		"3903e7":                     "-1000",
+		"20":                         "-1",
+		"f93c00":                     "1",
+		"f9c400":                     "-4",
+		"fa47c35000":                 "100000",
+		"fb3ff199999999999a":         "1.1",
+		"f4":                         "false",
+		"f5":                         "true",
+		"f6":                         "null",
+		"f7":                         "null",
+		"6449455446":                 `"IETF"`,
+		"62c3bc":                     `"ü"`,
+		"4401020304":                 `"AQIDBA"`,
+		"83010203":                   "[1, 2, 3]",
+		"8301820203820405":           "[1, [2, 3], [4, 5]]",
+		"a201020304":                 `{"1": 2, "3": 4}`,
+		"a26161016162820203":         `{"a": 1, "b": [2, 3]}`,
+		"7f657374726561646d696e67ff": `"streaming"`,
+		"9f018202039f0405ffff":       "[1, [2, 3], [4, 5]]",
+		"bf61610161629f0203ffff":     `{"a": 1, "b": [2, 3]}`,
+		"c074323031332d30332d32315432303a30343a30305a": `"2013-03-21T20:04:00Z"`,
+		"c249010000000000000000":                       "1.8446744073709552e+19",
+	}
+
+	for in, want := range vectors {
+
+		data, _ := hex.DecodeString(in)
+
+		elm, err := NewByCBOR(data)
+		assert.Nil(err, in)
+
+		got, _ := elm.MarshalJSON()
+		assert.Equal(want, string(got), in)
+	}
+
+	elm, _ := NewByCBOR([]byte{0xf9, 0x7c, 0x00})
+	assert.True(math.IsInf(elm.AsFloat(), 1))
+
+	elm, _ = NewByCBOR([]byte{0xa2, 0x61, 0x7a, 0x01, 0x61, 0x61, 0x02})
+	assert.Equal([]string{"z", "a"}, elm.Keys())
+
+	for _, bad := range []string{"", "18", "62c3", "0000", "9f01", "1c", "7f4100ff", "f8ff", "62c328"} {
+		data, _ := hex.DecodeString(bad)
+		_, err := NewByCBOR(data)
+		assert.NotNil(err, bad)
+	}
+}
+
+func TestToCBOR(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, _ := NewByString(`{"b": [1, -1000, 1.5, 1.1, null, true], "a": "IETF"}`)
+
+	data, err := elm.ToCBOR()
+	assert.Nil(err)
+	assert.Equal("a26161644945544661628601"+"3903e7"+"fa3fc00000"+"fb3ff199999999999a"+"f6f5", hex.EncodeToString(data))
+
+	back, err := NewByCBOR(data)
+	assert.Nil(err)
+	assert.True(rawEqual(elm.Raw(), back.Raw()))
+
+	ordered, _ := NewByBytesOrdered([]byte(`{"z": 1, "a": {"y": 2, "b": 3}}`))
+	data, err = ordered.ToCBOR()
+	assert.Nil(err)
+
+	back, _ = NewByCBOR(data)
+	assert.Equal(`{"z": 1, "a": {"y": 2, "b": 3}}`, back.String())
+
+	data, err = New(int64(-5)).ToCBOR()
+	assert.Nil(err)
+	assert.Equal("24", hex.EncodeToString(data))
+}
@@ -0,0 +1,86 @@
+package dynajson
+
+// copyRaw ... func
+// deep copy of maps and slices, *[]interface{} stays a pointer (editable) array.
+// For values that may hold spilled ones use copyRawChecked, here they become null.
+func copyRaw(raw interface{}) interface{} {
+
+	cp, _ := copyRawChecked(raw)
+
+	return cp
+}
+
+// copyRawChecked ... func
+// copyRaw failing when a spilled value can not be read.
+func copyRawChecked(raw interface{}) (interface{}, error) {
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			cp, err := copyRawChecked(v)
+			if err != nil {
+				return nil, err
+			}
+			obj[k] = cp
+		}
+		return obj, nil
+	case []interface{}:
+		arr := make([]interface{}, len(typed))
+		for i, v := range typed {
+			cp, err := copyRawChecked(v)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = cp
+		}
+		return arr, nil
+	case *[]interface{}:
+		cp, err := copyRawChecked(*typed)
+		if err != nil {
+			return nil, err
+		}
+		arr := cp.([]interface{})
+		return &arr, nil
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return nil, err
+		}
+		return copyRawChecked(obj)
+	}
+
+	return raw, nil
+}
+
+// Clone ... func
+// returns a deep copy of me as a new root, mutations on either side are not shared.
+// Handlers, KeyLess, Limits and the key order are carried over, subscriptions, coverage and the locker are not.
+// When a spilled value can not be read, the error goes to Warn and the clone has no value.
+func (me *JSONElement) Clone() *JSONElement {
+
+	raw, err := copyRawChecked(me.raw)
+	if err != nil {
+		me.Warn("Clone: %v", err)
+		return New(nil)
+	}
+
+	clone := New(raw)
+
+	clone.WarnHandler = me.WarnHandler
+	clone.FatalHandler = me.FatalHandler
+	clone.KeyLess = me.KeyLess
+
+	if me.limits != nil {
+		clone.SetLimits(me.limits.Limits)
+	}
+
+	if me.order != nil {
+		clone.order = newKeyOrderState()
+		if err := me.order.copyTo(clone.order, me.raw, clone.raw); err != nil {
+			me.Warn("Clone: %v", err)
+		}
+	}
+
+	return clone
+}
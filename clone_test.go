@@ -0,0 +1,36 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClone(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [1, {"c": 2}]}, "s": "x"}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	list, err := root.PutEmptyArray("list")
+	assert.Nil(err)
+	assert.Nil(list.Append(1))
+
+	clone := root.Select("a").Clone()
+	assert.Equal(0, len(clone.FullPath()))
+	assert.Nil(clone.Put("new", true))
+	assert.Nil(clone.Select("b", 1).Put("c", 3))
+	assert.Equal(2, root.Select("a", "b", 1, "c").AsInt())
+	assert.True(root.Select("a", "new").IsNil())
+
+	whole := root.Clone()
+	assert.Equal(root.String(), whole.String())
+	assert.Nil(whole.Select("list").Append(2))
+	assert.Equal(1, root.Select("list").Count())
+
+	root.SetLimits(Limits{MaxNodes: 8})
+	limited := root.Clone()
+	assert.NotNil(limited.Put("x", 1))
+}
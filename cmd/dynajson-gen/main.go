@@ -0,0 +1,58 @@
+// Command dynajson-gen ... generates typed accessors from a sample document or JSON Schema.
+//
+// Usage with go generate:
+//
+//	//go:generate go run github.com/cbh34680/dynajson/cmd/dynajson-gen -in config.json -type Config -pkg main -out config_gen.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/cbh34680/dynajson"
+	"github.com/cbh34680/dynajson/codegen"
+)
+
+func main() {
+
+	in := flag.String("in", "", "sample document or schema (path or URL)")
+	out := flag.String("out", "", "output file, stdout when empty")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name of the generated file")
+	typeName := flag.String("type", "Document", "type name of the root")
+	schema := flag.Bool("schema", false, "treat -in as a JSON Schema")
+	flag.Parse()
+
+	if *in == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	root, err := dynajson.NewByPath(*in)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	src, err := codegen.Generate(root, codegen.Options{
+		Package:  *pkg,
+		TypeName: *typeName,
+		Schema:   *schema,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+
+	err = ioutil.WriteFile(*out, src, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,345 @@
+// Package codegen ... generates typed accessors backed by dynajson.JSONElement
+// from a sample document or a JSON Schema, see cmd/dynajson-gen for the go:generate entry point.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cbh34680/dynajson"
+)
+
+// Options ... struct
+type Options struct {
+	// Package "" means "main".
+	Package string
+	// TypeName is the type of the root, "" means "Document".
+	TypeName string
+	// Schema treats the input as a JSON Schema (properties, items, type) instead of a sample.
+	Schema bool
+}
+
+type shape struct {
+	kind    dynajson.Kind
+	integer bool
+	fields  map[string]*shape
+	elem    *shape
+}
+
+func mergeShape(a, b *shape) *shape {
+
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.kind == dynajson.KindNull:
+		return b
+	case b.kind == dynajson.KindNull:
+		return a
+	case a.kind != b.kind:
+		return &shape{kind: dynajson.KindUnknown}
+	}
+
+	ret := &shape{
+		kind:    a.kind,
+		integer: a.integer && b.integer,
+		elem:    mergeShape(a.elem, b.elem),
+	}
+
+	if a.kind == dynajson.KindMap {
+
+		ret.fields = map[string]*shape{}
+		for k, v := range a.fields {
+			ret.fields[k] = v
+		}
+		for k, v := range b.fields {
+			ret.fields[k] = mergeShape(ret.fields[k], v)
+		}
+	}
+
+	return ret
+}
+
+func sampleShape(elm *dynajson.JSONElement) *shape {
+
+	ret := &shape{kind: elm.Kind()}
+
+	switch ret.kind {
+	case dynajson.KindNumber:
+		f := elm.AsFloat()
+		ret.integer = f == float64(int64(f))
+
+	case dynajson.KindMap:
+		ret.fields = map[string]*shape{}
+		elm.EachMap(func(k string, v *dynajson.JSONElement) (bool, error) {
+			ret.fields[k] = sampleShape(v)
+			return true, nil
+		})
+
+	case dynajson.KindArray:
+		for _, v := range elm.AsArray() {
+			ret.elem = mergeShape(ret.elem, sampleShape(v))
+		}
+	}
+
+	return ret
+}
+
+func schemaType(schema *dynajson.JSONElement) string {
+
+	t := schema.Select("type")
+
+	if t.IsArray() {
+		for _, v := range t.AsArray() {
+			if v.AsString() != "null" {
+				return v.AsString()
+			}
+		}
+	}
+
+	if str, ok := t.Raw().(string); ok {
+		return str
+	}
+
+	if schema.Select("properties").IsMap() {
+		return "object"
+	}
+
+	if schema.Select("items").IsMap() {
+		return "array"
+	}
+
+	return ""
+}
+
+func schemaShape(schema *dynajson.JSONElement) *shape {
+
+	switch schemaType(schema) {
+	case "object":
+		ret := &shape{kind: dynajson.KindMap, fields: map[string]*shape{}}
+
+		props := schema.Select("properties")
+		if props.IsMap() {
+			for _, k := range props.Keys() {
+				ret.fields[k] = schemaShape(props.Select(k))
+			}
+		}
+
+		return ret
+
+	case "array":
+		ret := &shape{kind: dynajson.KindArray}
+
+		if items := schema.Select("items"); items.IsMap() {
+			ret.elem = schemaShape(items)
+		}
+
+		return ret
+
+	case "string":
+		return &shape{kind: dynajson.KindString}
+	case "integer":
+		return &shape{kind: dynajson.KindNumber, integer: true}
+	case "number":
+		return &shape{kind: dynajson.KindNumber}
+	case "boolean":
+		return &shape{kind: dynajson.KindBool}
+	}
+
+	return &shape{kind: dynajson.KindUnknown}
+}
+
+// GoName ... func
+// converts a key to an exported Go identifier.
+func GoName(key string) string {
+
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, dynajson.ConvertKeyStyle(key, dynajson.PascalCase))
+
+	if name == "" {
+		return "Field"
+	}
+
+	if r := []rune(name)[0]; !unicode.IsLetter(r) || !unicode.IsUpper(r) {
+		name = "F" + name
+	}
+
+	return name
+}
+
+type generator struct {
+	buf   bytes.Buffer
+	types map[string]bool
+	queue []namedShape
+}
+
+type namedShape struct {
+	name string
+	s    *shape
+}
+
+func (me *generator) typeName(name string) string {
+
+	ret := name
+	for i := 2; me.types[ret]; i++ {
+		ret = fmt.Sprintf("%s%d", name, i)
+	}
+
+	me.types[ret] = true
+
+	return ret
+}
+
+func (me *generator) enqueue(name string, s *shape) string {
+
+	name = me.typeName(name)
+	me.queue = append(me.queue, namedShape{name, s})
+
+	return name
+}
+
+func scalarAccessor(s *shape) (string, string, bool) {
+
+	if s == nil {
+		return "", "", false
+	}
+
+	switch s.kind {
+	case dynajson.KindString:
+		return "string", "AsString()", true
+	case dynajson.KindBool:
+		return "bool", "AsBool()", true
+	case dynajson.KindNumber:
+		if s.integer {
+			return "int", "AsInt()", true
+		}
+		return "float64", "AsFloat()", true
+	}
+
+	return "", "", false
+}
+
+func (me *generator) field(typeName, method, key string, s *shape) {
+
+	sel := fmt.Sprintf("me.elm.Select(%s)", strconv.Quote(key))
+
+	fmt.Fprintf(&me.buf, "// %s ... func\n// %s", method, strconv.Quote(key))
+
+	if goType, conv, ok := scalarAccessor(s); ok {
+		fmt.Fprintf(&me.buf, " as %s.\nfunc (me %s) %s() %s {\n\treturn %s.%s\n}\n\n", goType, typeName, method, goType, sel, conv)
+		return
+	}
+
+	switch s.kind {
+	case dynajson.KindMap:
+		sub := me.enqueue(typeName+method, s)
+		fmt.Fprintf(&me.buf, ".\nfunc (me %s) %s() %s {\n\treturn New%[3]s(%s)\n}\n\n", typeName, method, sub, sel)
+		return
+
+	case dynajson.KindArray:
+		elemType, conv, ok := scalarAccessor(s.elem)
+
+		if !ok && s.elem != nil && s.elem.kind == dynajson.KindMap {
+			elemType = me.enqueue(typeName+method+"Item", s.elem)
+			conv, ok = "", true
+		}
+
+		if ok {
+			item := "v." + conv
+			if conv == "" {
+				item = "New" + elemType + "(v)"
+			}
+
+			fmt.Fprintf(&me.buf, " as []%s.\nfunc (me %s) %s() []%[1]s {\n", elemType, typeName, method)
+			fmt.Fprintf(&me.buf, "\tarr := %s.AsArray()\n\tret := make([]%s, len(arr))\n\n", sel, elemType)
+			fmt.Fprintf(&me.buf, "\tfor i, v := range arr {\n\t\tret[i] = %s\n\t}\n\n\treturn ret\n}\n\n", item)
+			return
+		}
+	}
+
+	fmt.Fprintf(&me.buf, ".\nfunc (me %s) %s() *dynajson.JSONElement {\n\treturn %s\n}\n\n", typeName, method, sel)
+}
+
+func (me *generator) object(name string, s *shape) {
+
+	fmt.Fprintf(&me.buf, "// %s ... struct\n// typed accessors of a dynajson.JSONElement.\ntype %[1]s struct {\n\telm *dynajson.JSONElement\n}\n\n", name)
+	fmt.Fprintf(&me.buf, "// New%s ... func\nfunc New%[1]s(elm *dynajson.JSONElement) %[1]s {\n\treturn %[1]s{elm: elm}\n}\n\n", name)
+	fmt.Fprintf(&me.buf, "// Element ... func\nfunc (me %s) Element() *dynajson.JSONElement {\n\treturn me.elm\n}\n\n", name)
+
+	keys := make([]string, 0, len(s.fields))
+	for k := range s.fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	methods := map[string]bool{"Element": true}
+
+	for _, k := range keys {
+
+		method := GoName(k)
+		for i := 2; methods[method]; i++ {
+			method = fmt.Sprintf("%s%d", GoName(k), i)
+		}
+		methods[method] = true
+
+		me.field(name, method, k, s.fields[k])
+	}
+}
+
+// Generate ... func
+// returns gofmt-ed Go source declaring TypeName (and one type per nested object)
+// with a method per key that selects and converts the value.
+func Generate(root *dynajson.JSONElement, opts Options) ([]byte, error) {
+
+	if opts.Package == "" {
+		opts.Package = "main"
+	}
+
+	if opts.TypeName == "" {
+		opts.TypeName = "Document"
+	}
+
+	var s *shape
+	if opts.Schema {
+		s = schemaShape(root)
+	} else {
+		s = sampleShape(root)
+	}
+
+	if s.kind != dynajson.KindMap {
+		return nil, fmt.Errorf("Generate: Root Not Object: %v", s.kind)
+	}
+
+	g := &generator{
+		types: map[string]bool{},
+	}
+
+	fmt.Fprintf(&g.buf, "// Code generated by dynajson-gen. DO NOT EDIT.\n\npackage %s\n\nimport \"github.com/cbh34680/dynajson\"\n\n", opts.Package)
+
+	g.enqueue(opts.TypeName, s)
+
+	for len(g.queue) > 0 {
+
+		v := g.queue[0]
+		g.queue = g.queue[1:]
+
+		g.object(v.name, v.s)
+	}
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Generate: format.Source: %w", err)
+	}
+
+	return src, nil
+}
@@ -0,0 +1,70 @@
+package codegen
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/cbh34680/dynajson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+
+	assert := assert.New(t)
+
+	sample, err := dynajson.NewByString(`{
+		"server": {"host": "localhost", "port": 8080, "ratio": 0.5},
+		"tags": ["a", "b"],
+		"workers": [{"name": "w1"}, {"name": "w2", "retries": 3}],
+		"debug": false,
+		"2fa-enabled": true,
+		"element": null,
+		"mixed": [1, "x"]
+	}`)
+	assert.Nil(err)
+
+	src, err := Generate(sample, Options{Package: "config", TypeName: "Config"})
+	assert.Nil(err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "config_gen.go", src, 0)
+	assert.Nil(err)
+
+	code := string(src)
+	assert.True(strings.HasPrefix(code, "// Code generated by dynajson-gen. DO NOT EDIT."))
+	assert.Contains(code, "package config")
+	assert.Contains(code, "func (me Config) Server() ConfigServer {")
+	assert.Contains(code, `return me.elm.Select("port").AsInt()`)
+	assert.Contains(code, `return me.elm.Select("ratio").AsFloat()`)
+	assert.Contains(code, "func (me Config) Tags() []string {")
+	assert.Contains(code, "func (me Config) Workers() []ConfigWorkersItem {")
+	assert.Contains(code, "func (me ConfigWorkersItem) Retries() int {")
+	assert.Contains(code, "func (me Config) F2faEnabled() bool {")
+	assert.Contains(code, "func (me Config) Element2() *dynajson.JSONElement {")
+	assert.Contains(code, "func (me Config) Mixed() *dynajson.JSONElement {")
+
+	schema, err := dynajson.NewByString(`{
+		"type": "object",
+		"properties": {
+			"id": {"type": "integer"},
+			"score": {"type": ["number", "null"]},
+			"owner": {"properties": {"name": {"type": "string"}}},
+			"ids": {"type": "array", "items": {"type": "integer"}}
+		}
+	}`)
+	assert.Nil(err)
+
+	src, err = Generate(schema, Options{Schema: true})
+	assert.Nil(err)
+
+	code = string(src)
+	assert.Contains(code, "package main")
+	assert.Contains(code, "func (me Document) Id() int {")
+	assert.Contains(code, "func (me Document) Score() float64 {")
+	assert.Contains(code, "func (me DocumentOwner) Name() string {")
+	assert.Contains(code, "func (me Document) Ids() []int {")
+
+	_, err = Generate(dynajson.New([]interface{}{}), Options{})
+	assert.NotNil(err)
+}
@@ -0,0 +1,175 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CoerceFailure ... struct
+type CoerceFailure struct {
+	Path  []interface{}
+	To    Kind
+	Value interface{}
+}
+
+func (me CoerceFailure) String() string {
+	return fmt.Sprintf("%s: %v -> %s", FullPath2Str(me.Path, "/"), me.Value, me.To)
+}
+
+// CoerceReport ... struct
+type CoerceReport struct {
+	Converted [][]interface{}
+	Failed    []CoerceFailure
+}
+
+// plainNumberText ... func
+// a number as a string without exponent (100000000, not 1e+08).
+func plainNumberText(raw interface{}) string {
+
+	switch typed := raw.(type) {
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(typed), 'f', -1, 32)
+	case json.Number:
+		return typed.String()
+	}
+
+	return fmt.Sprintf("%v", raw)
+}
+
+// decimal digits only, ParseFloat also takes "Inf", "0x1p4" and "1_000"
+var coerceDecimal = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+// leadingZeros ... func
+// "0012" would lose its zeros as a number (an id, a zip code...).
+func leadingZeros(str string) bool {
+
+	str = strings.TrimLeft(str, "+-")
+
+	return len(str) > 1 && str[0] == '0' && str[1] >= '0' && str[1] <= '9'
+}
+
+func coerceValue(raw interface{}, to Kind) (interface{}, bool) {
+
+	from := kindOf(raw)
+	if from == to {
+		return raw, true
+	}
+
+	switch to {
+	case KindString:
+		switch from {
+		case KindNumber:
+			return plainNumberText(raw), true
+		case KindBool:
+			return strconv.FormatBool(raw.(bool)), true
+		case KindNull:
+			return "", true
+		}
+
+	case KindNumber:
+		switch from {
+		case KindString:
+			str := strings.TrimSpace(raw.(string))
+			if !coerceDecimal.MatchString(str) || leadingZeros(str) {
+				return nil, false
+			}
+			f, err := strconv.ParseFloat(str, 64)
+			if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+				return nil, false
+			}
+			return f, true
+		case KindBool:
+			if raw.(bool) {
+				return float64(1), true
+			}
+			return float64(0), true
+		}
+
+	case KindBool:
+		switch from {
+		case KindString:
+			switch strings.ToLower(strings.TrimSpace(raw.(string))) {
+			case "true", "yes", "y", "on", "1":
+				return true, true
+			case "false", "no", "n", "off", "0", "":
+				return false, true
+			}
+		case KindNumber:
+			f, _ := coerceValue(raw, KindString)
+			n, err := strconv.ParseFloat(f.(string), 64)
+			if err == nil && (n == 0 || n == 1) {
+				return n == 1, true
+			}
+		}
+
+	case KindNull:
+		return nil, true
+	}
+
+	return nil, false
+}
+
+// CoerceTypes ... func
+// converts the values whose path (relative to me) matches a pattern (see MatchPath)
+// to the kind of the rule: numbers/bools to strings, numeric strings to numbers,
+// "yes"/"no", "on"/"off", "1"/"0" ... to bools. Containers are never converted.
+// When several patterns match, the first in sorted order wins.
+func (me *JSONElement) CoerceTypes(rules map[string]Kind) (*CoerceReport, error) {
+
+	patterns := make([]string, 0, len(rules))
+	for k := range rules {
+		patterns = append(patterns, k)
+	}
+	sort.Strings(patterns)
+
+	report := &CoerceReport{
+		Converted: [][]interface{}{},
+		Failed:    []CoerceFailure{},
+	}
+
+	changed, err := me.rewrite("CoerceTypes", func(elm *JSONElement, rel []interface{}) (interface{}, bool) {
+
+		if elm.IsMap() || elm.IsArray() {
+			return nil, false
+		}
+
+		for _, pattern := range patterns {
+
+			if !MatchPath(pattern, rel) {
+				continue
+			}
+
+			to := rules[pattern]
+			if elm.Kind() == to {
+				return nil, false
+			}
+
+			val, ok := coerceValue(elm.Raw(), to)
+			if !ok {
+				report.Failed = append(report.Failed, CoerceFailure{
+					Path:  elm.FullPath(),
+					To:    to,
+					Value: elm.Raw(),
+				})
+			}
+
+			return val, ok
+		}
+
+		return nil, false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.Converted = changed
+
+	return report, nil
+}
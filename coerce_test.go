@@ -0,0 +1,84 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoerceTypes(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{
+		"rows": [
+			{"id": 1, "price": "12.50", "active": "yes", "zip": 1234},
+			{"id": 2, "price": "n/a", "active": "off", "zip": "0987"}
+		]
+	}`)
+	assert.Nil(err)
+
+	report, err := root.CoerceTypes(map[string]Kind{
+		"rows/*/price":  KindNumber,
+		"rows/*/active": KindBool,
+		"rows/*/zip":    KindString,
+		"rows/*/id":     KindString,
+	})
+	assert.Nil(err)
+
+	assert.Equal(12.5, root.Select("rows", 0, "price").AsFloat())
+	assert.Equal("n/a", root.Select("rows", 1, "price").AsString())
+	assert.True(root.Select("rows", 0, "active").AsBool())
+	assert.False(root.Select("rows", 1, "active").AsBool())
+	assert.Equal(KindBool, root.Select("rows", 1, "active").Kind())
+	assert.Equal("1234", root.Select("rows", 0, "zip").AsString())
+	assert.Equal("0987", root.Select("rows", 1, "zip").AsString())
+	assert.Equal("2", root.Select("rows", 1, "id").AsString())
+
+	assert.Equal(6, len(report.Converted))
+	assert.Equal(1, len(report.Failed))
+	assert.Equal("/rows/1/price: n/a -> number", report.Failed[0].String())
+}
+
+func TestCoerceTypesNumbers(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": 100000000, "b": 1234567, "c": 0.000001, "d": -2.5, "zip": "0012", "neg": "-007", "ok": ["0", "0.5", "-0.25", "1e3"]}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	report, err := root.CoerceTypes(map[string]Kind{
+		"a": KindString, "b": KindString, "c": KindString, "d": KindString,
+		"zip": KindNumber, "neg": KindNumber, "ok/*": KindNumber,
+	})
+	assert.Nil(err)
+
+	assert.Equal(`{"a": "100000000", "b": "1234567", "c": "0.000001", "d": "-2.5", "neg": "-007", `+
+		`"ok": [0, 0.5, -0.25, 1000], "zip": "0012"}`, root.String())
+
+	failed := []string{}
+	for _, v := range report.Failed {
+		failed = append(failed, v.String())
+	}
+	assert.ElementsMatch([]string{"/neg: -007 -> number", "/zip: 0012 -> number"}, failed)
+
+	root, err = NewByBytesUseNumber([]byte(`[12345678901234567890]`))
+	assert.Nil(err)
+
+	_, err = root.CoerceTypes(map[string]Kind{"*": KindString})
+	assert.Nil(err)
+	assert.Equal(`["12345678901234567890"]`, root.String())
+
+	// only finite decimal numbers
+	root, err = NewByString(`["NaN", "Inf", "-infinity", "0x1p4", "1_000", "1e999", "", "+1.5", ".5"]`)
+	assert.Nil(err)
+
+	report, err = root.CoerceTypes(map[string]Kind{"*": KindNumber})
+	assert.Nil(err)
+	assert.Len(report.Failed, 7)
+	assert.Equal(`["NaN", "Inf", "-infinity", "0x1p4", "1_000", "1e999", "", 1.5, 0.5]`, root.String())
+
+	_, err = root.Marshal(DumpOptions{})
+	assert.Nil(err)
+}
@@ -0,0 +1,70 @@
+package dynajson
+
+// Config ... struct
+// settings shared by a document and every element selected from it, also those
+// selected before a change. The fields of an element (WarnHandler, FatalHandler,
+// Readonly) take precedence, Readonly holds when either is set.
+// With StrictDelete, DeleteByKey and DeleteByPos return an ErrNotFound error
+// for a missing key or index instead of a warning.
+type Config struct {
+	WarnHandler  func(*JSONElement, string, string, int)
+	FatalHandler func(*JSONElement, string, string, int)
+	Readonly     bool
+	StrictDelete bool
+}
+
+// Config ... func
+// the Config of the document of me, kept by its root (created on first use).
+// Set it up before the document is shared between goroutines.
+func (me *JSONElement) Config() *Config {
+
+	root := me.Root()
+	if root.config == nil {
+		root.config = &Config{}
+	}
+
+	return root.config
+}
+
+// sharedConfig ... func
+// nil until Config was called.
+func (me *JSONElement) sharedConfig() *Config {
+
+	if me == nil {
+		return nil
+	}
+
+	return me.Root().config
+}
+
+func (me *JSONElement) strictDelete() bool {
+
+	conf := me.sharedConfig()
+	return conf != nil && conf.StrictDelete
+}
+
+func (me *JSONElement) warnHandler() func(*JSONElement, string, string, int) {
+
+	if me.WarnHandler != nil {
+		return me.WarnHandler
+	}
+
+	if conf := me.sharedConfig(); conf != nil {
+		return conf.WarnHandler
+	}
+
+	return nil
+}
+
+func (me *JSONElement) fatalHandler() func(*JSONElement, string, string, int) {
+
+	if me.FatalHandler != nil {
+		return me.FatalHandler
+	}
+
+	if conf := me.sharedConfig(); conf != nil {
+		return conf.FatalHandler
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFatalHandlerPropagates(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": 1}}`)
+	assert.Nil(err)
+
+	fatal := []string{}
+	root.FatalHandler = func(elm *JSONElement, msg, where string, line int) {
+		fatal = append(fatal, elm.PathString())
+	}
+
+	assert.NotNil(root.Select("a", "b").Put("x", 1))
+	assert.Equal([]string{"/a/b"}, fatal)
+}
+
+func TestConfig(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [1]}}`)
+	assert.Nil(err)
+
+	// selected before the configuration
+	b := root.Select("a", "b")
+
+	warned, fatal := 0, 0
+
+	conf := root.Select("a").Config()
+	assert.Equal(conf, root.Config())
+
+	conf.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		warned++
+	}
+	conf.FatalHandler = func(elm *JSONElement, msg, where string, line int) {
+		fatal++
+	}
+
+	b.SelectByPos(5)
+	assert.Equal(1, warned)
+
+	assert.NotNil(b.Put("x", 1))
+	assert.Equal(1, fatal)
+
+	// element handlers win
+	own := 0
+	b.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		own++
+	}
+	b.SelectByPos(5)
+	assert.Equal(1, warned)
+	assert.Equal(1, own)
+
+	conf.Readonly = true
+	assert.NotNil(b.Append(2))
+	assert.NotNil(root.Put("c", 1))
+
+	conf.Readonly = false
+	assert.Nil(root.Put("c", 1))
+}
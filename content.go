@@ -0,0 +1,166 @@
+package dynajson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"sync"
+)
+
+// MediaTypeJSON ... const
+const MediaTypeJSON = "application/json"
+
+// Decoder ... type
+// converts a fetched document into the raw value of a JSONElement.
+type Decoder func(data []byte) (interface{}, error)
+
+type decoderEntry struct {
+	mediaType string
+	decode    Decoder
+}
+
+var decoders = struct {
+	sync.RWMutex
+	entries []decoderEntry
+	exts    map[string]string
+}{
+	entries: []decoderEntry{
+		{mediaType: MediaTypeJSON, decode: decodeJSON},
+	},
+	exts: map[string]string{
+		".json": MediaTypeJSON,
+	},
+}
+
+func decodeJSON(data []byte) (interface{}, error) {
+
+	var obj interface{}
+
+	err := json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal: %w", err)
+	}
+
+	return obj, nil
+}
+
+// RegisterDecoder ... func
+// makes the loader accept mediaType, exts (e.g. ".yaml") map local files to it.
+// Registering an existing mediaType replaces its decoder.
+func RegisterDecoder(mediaType string, dec Decoder, exts ...string) {
+
+	mediaType = strings.ToLower(mediaType)
+
+	decoders.Lock()
+	defer decoders.Unlock()
+
+	for _, ext := range exts {
+		decoders.exts[strings.ToLower(ext)] = mediaType
+	}
+
+	for i, v := range decoders.entries {
+		if v.mediaType == mediaType {
+			decoders.entries[i].decode = dec
+			return
+		}
+	}
+
+	decoders.entries = append(decoders.entries, decoderEntry{mediaType: mediaType, decode: dec})
+}
+
+// AcceptHeader ... func
+// lists the registered media types, JSON first, for the Accept request header.
+func AcceptHeader() string {
+
+	decoders.RLock()
+	defer decoders.RUnlock()
+
+	parts := []string{MediaTypeJSON, "application/*+json;q=0.9"}
+
+	for _, v := range decoders.entries {
+		if v.mediaType != MediaTypeJSON {
+			parts = append(parts, v.mediaType+";q=0.8")
+		}
+	}
+
+	return strings.Join(append(parts, "*/*;q=0.1"), ", ")
+}
+
+// ContentTypeByExt ... func
+// returns the media type registered for the extension of argPath, "" when unknown.
+func ContentTypeByExt(argPath string) string {
+
+	if i := strings.IndexAny(argPath, "?#"); i >= 0 && strings.Contains(argPath, "://") {
+		argPath = argPath[:i]
+	}
+
+	decoders.RLock()
+	defer decoders.RUnlock()
+
+	return decoders.exts[strings.ToLower(path.Ext(argPath))]
+}
+
+// lookupDecoder ... func
+// "+json" suffixes are JSON, any other "+xxx" suffix is tried as application/xxx.
+func lookupDecoder(contentType string) (Decoder, bool) {
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	candidates := []string{mediaType}
+	if i := strings.LastIndex(mediaType, "+"); i >= 0 {
+		candidates = append(candidates, "application/"+mediaType[i+1:])
+	}
+
+	decoders.RLock()
+	defer decoders.RUnlock()
+
+	for _, c := range candidates {
+		for _, v := range decoders.entries {
+			if v.mediaType == c {
+				return v.decode, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// decodeFetched ... func
+// override wins over the Content-Type of the response, then the file extension.
+// Unknown types sent by a server fall back to JSON, an unknown override is an error.
+func decodeFetched(argPath string, data []byte, contentType, override string) (interface{}, error) {
+
+	if override != "" {
+
+		dec, ok := lookupDecoder(override)
+		if !ok {
+			return nil, fmt.Errorf("%s: Unsupported Content-Type: %s", argPath, override)
+		}
+
+		return dec(data)
+	}
+
+	if contentType == "" {
+		contentType = ContentTypeByExt(argPath)
+	}
+
+	dec, ok := lookupDecoder(contentType)
+	if !ok {
+		dec = decodeJSON
+	}
+
+	return dec(data)
+}
+
+// NewByPathAs ... func
+// loads argPath decoding it as contentType whatever the server says.
+func NewByPathAs(argPath string, contentType string) (*JSONElement, error) {
+
+	return loadByPath(context.Background(), argPath, loadConfig{contentType: contentType})
+}
@@ -0,0 +1,94 @@
+package dynajson
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentType(t *testing.T) {
+
+	assert := assert.New(t)
+
+	accepts := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		accepts = append(accepts, r.Header.Get("Accept"))
+
+		switch r.URL.Path {
+		case "/problem":
+			w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+			w.Write([]byte(`{"title": "x"}`))
+		case "/csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte(`a,b`))
+		default:
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(`{"name": "plain"}`))
+		}
+	}))
+	defer srv.Close()
+
+	fetcher := &PathFetcher{}
+
+	root, err := NewByPathWithFetcher(srv.URL+"/problem", fetcher)
+	assert.Nil(err)
+	assert.Equal("x", root.Select("title").AsString())
+	assert.True(strings.HasPrefix(accepts[0], "application/json"))
+
+	root, err = NewByPathWithFetcher(srv.URL+"/plain", fetcher)
+	assert.Nil(err)
+	assert.Equal("plain", root.Select("name").AsString())
+
+	_, err = NewByPathWithFetcher(srv.URL+"/csv", fetcher)
+	assert.NotNil(err)
+
+	fetcher.Accept = "application/vnd.test+json"
+	_, err = NewByPathWithFetcher(srv.URL+"/problem", fetcher)
+	assert.Nil(err)
+	assert.Equal("application/vnd.test+json", accepts[len(accepts)-1])
+
+	decoders.RLock()
+	saved := len(decoders.entries)
+	decoders.RUnlock()
+
+	RegisterDecoder("text/csv", func(data []byte) (interface{}, error) {
+		arr := []interface{}{}
+		for _, v := range strings.Split(string(data), ",") {
+			arr = append(arr, v)
+		}
+		return arr, nil
+	}, ".csv")
+	defer func() {
+		decoders.Lock()
+		decoders.entries = decoders.entries[:saved]
+		delete(decoders.exts, ".csv")
+		decoders.Unlock()
+	}()
+
+	assert.Contains(AcceptHeader(), "text/csv;q=0.8")
+	assert.Equal("text/csv", ContentTypeByExt("https://example.com/a.CSV?x=1"))
+
+	root, err = NewByPathWithFetcher(srv.URL+"/csv", fetcher)
+	assert.Nil(err)
+	assert.Equal([]interface{}{"a", "b"}, root.Raw())
+
+	canned := MapFetcher{"a.csv": []byte(`1,2`)}
+	root, err = loadByPath(context.Background(), "a.csv", loadConfig{fetcher: canned})
+	assert.Nil(err)
+	assert.Equal([]interface{}{"1", "2"}, root.Raw())
+
+	_, err = loadByPath(context.Background(), "a.csv", loadConfig{fetcher: canned, contentType: MediaTypeJSON})
+	assert.NotNil(err)
+
+	_, err = NewByPathAs("testdata/petstore.json", "application/unknown")
+	assert.NotNil(err)
+
+	root, err = NewByPathAs("testdata/petstore.json", "application/json")
+	assert.Nil(err)
+	assert.True(root.IsMap())
+}
@@ -0,0 +1,71 @@
+package dynajson
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// ctxReadChunk ... const
+// reads are cut to this size, so that data already in memory is not handed
+// over (and decoded) at once.
+const ctxReadChunk = 32 << 10
+
+// Read ... func
+// fails with ctx.Err() once the context is done, so decoders stop at the next chunk.
+func (me *ctxReader) Read(p []byte) (int, error) {
+
+	if err := me.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(p) > ctxReadChunk {
+		p = p[:ctxReadChunk]
+	}
+
+	return me.r.Read(p)
+}
+
+// NewByPathContext ... func
+func NewByPathContext(ctx context.Context, argPath string) (*JSONElement, error) {
+
+	return loadByPath(ctx, argPath, loadConfig{})
+}
+
+// NewByBytesContext ... func
+func NewByBytesContext(ctx context.Context, data []byte) (*JSONElement, error) {
+
+	return NewByReader(&ctxReader{ctx: ctx, r: bytes.NewReader(data)})
+}
+
+// EachArrayFromReaderContext ... func
+func EachArrayFromReaderContext(ctx context.Context, r io.Reader, path string, callback func(int, *JSONElement) (bool, error)) error {
+
+	return EachArrayFromReader(&ctxReader{ctx: ctx, r: r}, path, func(i int, elm *JSONElement) (bool, error) {
+
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		return callback(i, elm)
+	})
+}
+
+// WalkContext ... func
+// same as Walk, ctx is checked before every callback.
+func (me *JSONElement) WalkContext(ctx context.Context, callback walkCallbackType) error {
+
+	return me.Walk(func(parents []interface{}, key, val interface{}) (bool, error) {
+
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		return callback(parents, key, val)
+	})
+}
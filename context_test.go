@@ -0,0 +1,180 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContext(t *testing.T) {
+
+	assert := assert.New(t)
+
+	jsonPath := filepath.Join(currentDir(), "testdata", "read2.json")
+
+	root, err := NewByPathContext(context.Background(), jsonPath)
+	assert.Nil(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = NewByPathContext(ctx, jsonPath)
+	assert.True(errors.Is(err, context.Canceled))
+
+	elm, err := NewByBytesContext(context.Background(), []byte(`{"a": [1, 2]}`))
+	assert.Nil(err)
+	assert.Equal(2, elm.Select("a", 1).AsInt())
+
+	_, err = NewByBytesContext(context.Background(), []byte(`{"a": 1} {}`))
+	assert.NotNil(err)
+
+	_, err = NewByBytesContext(ctx, []byte(`{"a": 1}`))
+	assert.True(errors.Is(err, context.Canceled))
+
+	err = EachArrayFromReaderContext(ctx, strings.NewReader(`[1, 2]`), "", func(i int, elm *JSONElement) (bool, error) {
+		return true, nil
+	})
+	assert.True(errors.Is(err, context.Canceled))
+
+	cnt := 0
+	err = root.WalkContext(context.Background(), func(parents []interface{}, key, val interface{}) (bool, error) {
+		cnt++
+		return true, nil
+	})
+	assert.Nil(err)
+	assert.True(cnt > 0)
+
+	err = root.WalkContext(ctx, func(parents []interface{}, key, val interface{}) (bool, error) {
+		return true, nil
+	})
+	assert.True(errors.Is(err, context.Canceled))
+}
+
+type cancelingReader struct {
+	cancel context.CancelFunc
+	done   bool
+}
+
+func (me *cancelingReader) Read(p []byte) (int, error) {
+
+	if me.done {
+		return copy(p, `}`), io.EOF
+	}
+
+	me.done = true
+	me.cancel()
+
+	return copy(p, `{"a": 1`), nil
+}
+
+func TestLoadCanceledWhileReading(t *testing.T) {
+
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fetcher := FetcherFunc(func(ctx context.Context, argPath string) (*FetchResult, error) {
+		return &FetchResult{Body: ioutil.NopCloser(&cancelingReader{cancel: cancel}), Size: -1}, nil
+	})
+
+	_, err := loadByPath(ctx, "slow.json", loadConfig{fetcher: fetcher})
+	assert.True(errors.Is(err, context.Canceled))
+}
+
+func TestNewByURLContextDeadline(t *testing.T) {
+
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewByURLContext(ctx, srv.URL)
+	assert.True(errors.Is(err, context.DeadlineExceeded))
+	assert.True(time.Since(start) < 5*time.Second)
+}
+
+// countdownCtx ... struct
+// done after its Err has been asked n times.
+type countdownCtx struct {
+	context.Context
+	n int
+}
+
+func (me *countdownCtx) Err() error {
+
+	me.n--
+	if me.n < 0 {
+		return context.Canceled
+	}
+
+	return nil
+}
+
+func TestValidateDiffContext(t *testing.T) {
+
+	assert := assert.New(t)
+
+	schema, _ := NewByString(`{"type": "object", "properties": {"a": {"type": "array", "items": {"type": "integer"}}}}`)
+	doc, _ := NewByString(`{"a": [1, 2, "x"]}`)
+	other, _ := NewByString(`{"a": [1, 3, "x"], "b": true}`)
+
+	errs, err := doc.ValidateContext(context.Background(), schema)
+	assert.Nil(err)
+	assert.Equal(1, len(errs))
+
+	diff, err := doc.DiffContext(context.Background(), other)
+	assert.Nil(err)
+	assert.Equal(doc.Diff(other).String(), diff.String())
+	assert.Equal(2, len(diff.Entries))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = doc.ValidateContext(ctx, schema)
+	assert.True(errors.Is(err, context.Canceled))
+
+	_, err = doc.DiffContext(ctx, other)
+	assert.True(errors.Is(err, context.Canceled))
+
+	// stopped on the way
+	_, err = doc.ValidateContext(&countdownCtx{Context: context.Background(), n: 2}, schema)
+	assert.True(errors.Is(err, context.Canceled))
+
+	_, err = doc.DiffContext(&countdownCtx{Context: context.Background(), n: 2}, other)
+	assert.True(errors.Is(err, context.Canceled))
+}
+
+func TestNewByBytesContextChunks(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data := []byte("[" + strings.Repeat(`"0123456789abcdef", `, 1<<16) + "0]")
+
+	ctx := &countdownCtx{Context: context.Background(), n: 4}
+
+	_, err := NewByBytesContext(ctx, data)
+	assert.True(errors.Is(err, context.Canceled))
+
+	elm, err := NewByBytesContext(context.Background(), data)
+	assert.Nil(err)
+	assert.Equal(1<<16+1, elm.Count())
+}
@@ -0,0 +1,105 @@
+package dynajson
+
+import "fmt"
+
+// elements ... func
+// the children of an array me, ErrNotFound / ErrTypeMismatch otherwise.
+func (me *JSONElement) elements(op string) ([]*JSONElement, error) {
+
+	raw, err := resolveSpill(me.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	arr := asSlice(raw)
+	if arr == nil {
+		return nil, me.getError(op, "array")
+	}
+
+	elms := make([]*JSONElement, len(arr))
+	for i, v := range arr {
+		elms[i] = me.child(i, v)
+	}
+
+	return elms, nil
+}
+
+// AsStringSlice ... func
+// converts an array of strings, any other element is an error.
+func (me *JSONElement) AsStringSlice() ([]string, error) {
+
+	elms, err := me.elements("AsStringSlice")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]string, len(elms))
+	for i, v := range elms {
+		if ret[i], err = v.GetString(); err != nil {
+			return nil, fmt.Errorf("AsStringSlice: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+// AsIntSlice ... func
+// converts an array of integral numbers.
+func (me *JSONElement) AsIntSlice() ([]int, error) {
+
+	elms, err := me.elements("AsIntSlice")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]int, len(elms))
+	for i, v := range elms {
+		if ret[i], err = v.GetInt(); err != nil {
+			return nil, fmt.Errorf("AsIntSlice: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+// AsFloatSlice ... func
+func (me *JSONElement) AsFloatSlice() ([]float64, error) {
+
+	elms, err := me.elements("AsFloatSlice")
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]float64, len(elms))
+	for i, v := range elms {
+		if ret[i], err = v.GetFloat(); err != nil {
+			return nil, fmt.Errorf("AsFloatSlice: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+// AsStringMap ... func
+// converts a map of strings.
+func (me *JSONElement) AsStringMap() (map[string]string, error) {
+
+	raw, err := resolveSpill(me.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("AsStringMap: %w", err)
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, me.getError("AsStringMap", "map")
+	}
+
+	ret := make(map[string]string, len(obj))
+	for k, v := range obj {
+		if ret[k], err = me.child(k, v).GetString(); err != nil {
+			return nil, fmt.Errorf("AsStringMap: %w", err)
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,58 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceConverters(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"s": ["a", "b"], "i": [1, 2, 3], "f": [1, 2.5], "m": {"k": "v"}, "mix": [1, "x"], "e": []}`)
+	assert.Nil(err)
+
+	ss, err := root.Select("s").AsStringSlice()
+	assert.Nil(err)
+	assert.Equal([]string{"a", "b"}, ss)
+
+	is, err := root.Select("i").AsIntSlice()
+	assert.Nil(err)
+	assert.Equal([]int{1, 2, 3}, is)
+
+	fs, err := root.Select("f").AsFloatSlice()
+	assert.Nil(err)
+	assert.Equal([]float64{1, 2.5}, fs)
+
+	sm, err := root.Select("m").AsStringMap()
+	assert.Nil(err)
+	assert.Equal(map[string]string{"k": "v"}, sm)
+
+	ss, err = root.Select("e").AsStringSlice()
+	assert.Nil(err)
+	assert.Equal([]string{}, ss)
+
+	// editable arrays too
+	assert.Nil(root.Put("p", "x", "y"))
+	ss, err = root.Select("p").AsStringSlice()
+	assert.Nil(err)
+	assert.Equal([]string{"x", "y"}, ss)
+
+	_, err = root.Select("mix").AsIntSlice()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+	assert.Equal("AsIntSlice: GetInt: /mix/1: type mismatch: string is not int", err.Error())
+
+	_, err = root.Select("f").AsIntSlice()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+
+	_, err = root.Select("m").AsStringSlice()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+
+	_, err = root.Select("s").AsStringMap()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+
+	_, err = root.Select("none").AsFloatSlice()
+	assert.True(errors.Is(err, ErrNotFound))
+}
@@ -0,0 +1,99 @@
+package dynajson
+
+import (
+	"sort"
+	"sync"
+)
+
+// Coverage ... struct
+// records the paths reached through Select, Each*, As* and friends.
+type Coverage struct {
+	mu   sync.Mutex
+	base []interface{}
+	root interface{}
+	read map[string]bool
+}
+
+func (me *Coverage) record(path []interface{}) {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.read[Path2Pointer(path)] = true
+}
+
+// IsRead ... func
+// path is absolute, as FullPath returns.
+func (me *Coverage) IsRead(path []interface{}) bool {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	return me.read[Path2Pointer(path)]
+}
+
+// ReadPaths ... func
+// returns the recorded paths as JSON Pointers, sorted.
+func (me *Coverage) ReadPaths() []string {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	ret := []string{}
+	for k := range me.read {
+		ret = append(ret, k)
+	}
+
+	sort.Strings(ret)
+
+	return ret
+}
+
+func (me *Coverage) untouched(path []interface{}, raw interface{}, ret [][]interface{}) [][]interface{} {
+
+	entries, err := walkEntries(path, raw)
+	if err != nil {
+		return ret
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return Path2Pointer([]interface{}{entries[i].key}) < Path2Pointer([]interface{}{entries[j].key})
+	})
+
+	for _, v := range entries {
+
+		subPath := appendParents(path, v.key)
+
+		if !me.read[Path2Pointer(subPath)] {
+			ret = append(ret, subPath)
+			continue
+		}
+
+		ret = me.untouched(subPath, v.val, ret)
+	}
+
+	return ret
+}
+
+// Untouched ... func
+// returns the topmost subtrees nobody has read, their descendants are not listed.
+func (me *Coverage) Untouched() [][]interface{} {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	return me.untouched(me.base, me.root, [][]interface{}{})
+}
+
+// TrackCoverage ... func
+// starts recording reads on elements derived from me from now on.
+func (me *JSONElement) TrackCoverage() *Coverage {
+
+	me.coverage = &Coverage{
+		base: me.FullPath(),
+		root: me.raw,
+		read: map[string]bool{},
+	}
+
+	return me.coverage
+}
@@ -0,0 +1,38 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoverage(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": 1, "c": 2}, "d": [1, {"e": 3}], "f/g": true, "h": {"i": 4}}`)
+	assert.Nil(err)
+
+	cov := root.TrackCoverage()
+
+	root.Select("a", "b").AsInt()
+	root.Select("d").EachArray(func(i int, elm *JSONElement) (bool, error) {
+		return i < 0, nil
+	})
+	root.Select("f/g").AsBool()
+	root.Select("x", "y")
+
+	assert.True(cov.IsRead([]interface{}{"a", "b"}))
+	assert.False(cov.IsRead([]interface{}{"a", "c"}))
+	assert.Equal([]string{"/a", "/a/b", "/d", "/d/0", "/f~1g", "/x", "/x/y"}, cov.ReadPaths())
+
+	assert.Equal([][]interface{}{
+		{"a", "c"},
+		{"d", 1},
+		{"h"},
+	}, cov.Untouched())
+
+	sub := NewAsMap()
+	sub.Put("k", 1)
+	assert.Nil(sub.coverage)
+}
@@ -0,0 +1,310 @@
+package dynajson
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Timestamp ... struct
+// a Lamport clock value, Node breaks ties so every replica orders writes the same way.
+type Timestamp struct {
+	Counter uint64
+	Node    string
+}
+
+// Less ... func
+func (me Timestamp) Less(other Timestamp) bool {
+
+	if me.Counter != other.Counter {
+		return me.Counter < other.Counter
+	}
+
+	return me.Node < other.Node
+}
+
+type crdtEntry struct {
+	ts      Timestamp
+	val     interface{}
+	deleted bool
+}
+
+// CRDT ... struct
+// a document replicated as last-writer-wins registers keyed by JSON Pointer.
+// Replicas edited independently converge to the same document once they have
+// merged each other's state, in any order. A write at a path wins over older
+// writes at, above or below it. Arrays are registers as a whole, write the
+// array again rather than its elements.
+type CRDT struct {
+	mu      sync.Mutex
+	node    string
+	clock   uint64
+	entries map[string]crdtEntry
+}
+
+// NewCRDT ... func
+// node must be unique among the replicas.
+func NewCRDT(node string) *CRDT {
+
+	return &CRDT{
+		node:    node,
+		entries: map[string]crdtEntry{},
+	}
+}
+
+// NewCRDTFrom ... func
+// starts a replica whose document is a copy of root.
+func NewCRDTFrom(node string, root *JSONElement) (*CRDT, error) {
+
+	me := NewCRDT(node)
+
+	err := me.Set("", root.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	return me, nil
+}
+
+func (me *CRDT) write(name, pointer string, val interface{}, deleted bool) error {
+
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return fmt.Errorf("CRDT.%s: %w", name, err)
+	}
+
+	raw, err := resolveSpill(elm2Raw(val))
+	if err != nil {
+		return fmt.Errorf("CRDT.%s: %s: %w", name, pointer, err)
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.clock++
+
+	me.entries[Path2Pointer(tokens2Path(tokens))] = crdtEntry{
+		ts:      Timestamp{Counter: me.clock, Node: me.node},
+		val:     copyRaw(raw),
+		deleted: deleted,
+	}
+
+	return nil
+}
+
+func tokens2Path(tokens []string) []interface{} {
+
+	path := make([]interface{}, len(tokens))
+	for i, v := range tokens {
+		path[i] = v
+	}
+
+	return path
+}
+
+// Set ... func
+// writes val at the JSON Pointer path, "" is the whole document.
+func (me *CRDT) Set(pointer string, val interface{}) error {
+	return me.write("Set", pointer, val, false)
+}
+
+// Delete ... func
+func (me *CRDT) Delete(pointer string) error {
+	return me.write("Delete", pointer, nil, true)
+}
+
+func (me *CRDT) mergeEntry(pointer string, entry crdtEntry) {
+
+	if entry.ts.Counter > me.clock {
+		me.clock = entry.ts.Counter
+	}
+
+	if cur, ok := me.entries[pointer]; ok && !cur.ts.Less(entry.ts) {
+		return
+	}
+
+	me.entries[pointer] = entry
+}
+
+// Merge ... func
+// takes in the writes of other, Merge is commutative, associative and idempotent.
+func (me *CRDT) Merge(other *CRDT) {
+
+	if other == me {
+		return
+	}
+
+	other.mu.Lock()
+	entries := make(map[string]crdtEntry, len(other.entries))
+	for k, v := range other.entries {
+		entries[k] = v
+	}
+	other.mu.Unlock()
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	for k, v := range entries {
+		me.mergeEntry(k, v)
+	}
+}
+
+// State ... func
+// returns the replica state for transport to other nodes, see MergeState.
+func (me *CRDT) State() *JSONElement {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	entries := map[string]interface{}{}
+
+	for k, v := range me.entries {
+
+		entry := map[string]interface{}{
+			"c": strconv.FormatUint(v.ts.Counter, 10),
+			"n": v.ts.Node,
+		}
+
+		if v.deleted {
+			entry["d"] = true
+		} else {
+			entry["v"] = copyRaw(v.val)
+		}
+
+		entries[k] = entry
+	}
+
+	return New(map[string]interface{}{
+		"node":    me.node,
+		"entries": entries,
+	})
+}
+
+// MergeState ... func
+// same as Merge with the State of a remote replica.
+func (me *CRDT) MergeState(state *JSONElement) error {
+
+	entries, ok := state.Select("entries").Raw().(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("CRDT.MergeState: Not Map: entries")
+	}
+
+	parsed := map[string]crdtEntry{}
+
+	for k, v := range entries {
+
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("CRDT.MergeState: %s: Not Map: %T", k, v)
+		}
+
+		counterStr, _ := obj["c"].(string)
+		counter, err := strconv.ParseUint(counterStr, 10, 64)
+		if err != nil {
+			return fmt.Errorf("CRDT.MergeState: %s: Bad Counter: %w", k, err)
+		}
+
+		node, _ := obj["n"].(string)
+		deleted, _ := obj["d"].(bool)
+
+		parsed[k] = crdtEntry{
+			ts:      Timestamp{Counter: counter, Node: node},
+			val:     copyRaw(obj["v"]),
+			deleted: deleted,
+		}
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	for k, v := range parsed {
+		me.mergeEntry(k, v)
+	}
+
+	return nil
+}
+
+// Element ... func
+// materializes the current document, the result is a copy.
+func (me *CRDT) Element() *JSONElement {
+
+	me.mu.Lock()
+
+	pointers := make([]string, 0, len(me.entries))
+	for k := range me.entries {
+		pointers = append(pointers, k)
+	}
+
+	sort.Slice(pointers, func(i, j int) bool {
+		return me.entries[pointers[i]].ts.Less(me.entries[pointers[j]].ts)
+	})
+
+	entries := make([]crdtEntry, len(pointers))
+	for i, k := range pointers {
+		entries[i] = me.entries[k]
+	}
+
+	me.mu.Unlock()
+
+	var root interface{} = map[string]interface{}{}
+
+	for i, k := range pointers {
+
+		tokens, _ := ParsePointer(k)
+
+		if len(tokens) == 0 {
+			root = map[string]interface{}{}
+			if !entries[i].deleted {
+				root = copyRaw(entries[i].val)
+			}
+			continue
+		}
+
+		root = crdtApply(root, tokens, entries[i])
+	}
+
+	return New(root)
+}
+
+// crdtApply ... func
+// containers missing (or scalars) on the way become maps, later writes win.
+func crdtApply(raw interface{}, tokens []string, entry crdtEntry) interface{} {
+
+	if len(tokens) == 0 {
+		return copyRaw(entry.val)
+	}
+
+	if arr := asSlice(raw); arr != nil {
+
+		pos, err := strconv.Atoi(tokens[0])
+		if err != nil || pos < 0 || pos >= len(arr) {
+			return raw
+		}
+
+		if len(tokens) == 1 && entry.deleted {
+			return append(arr[:pos:pos], arr[pos+1:]...)
+		}
+
+		arr[pos] = crdtApply(arr[pos], tokens[1:], entry)
+		return raw
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		if entry.deleted {
+			return raw
+		}
+
+		obj = map[string]interface{}{}
+	}
+
+	if len(tokens) == 1 && entry.deleted {
+		delete(obj, tokens[0])
+		return obj
+	}
+
+	obj[tokens[0]] = crdtApply(obj[tokens[0]], tokens[1:], entry)
+
+	return obj
+}
@@ -0,0 +1,52 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRDT(t *testing.T) {
+
+	assert := assert.New(t)
+
+	base, err := NewByString(`{"title": "doc", "settings": {"theme": "light", "size": 10}}`)
+	assert.Nil(err)
+
+	a, err := NewCRDTFrom("a", base)
+	assert.Nil(err)
+
+	b := NewCRDT("b")
+	b.Merge(a)
+
+	assert.Nil(a.Set("/settings/theme", "dark"))
+	assert.Nil(b.Set("/settings/theme", "blue"))
+	assert.Nil(b.Set("/settings/font", "mono"))
+	assert.Nil(a.Delete("/settings/size"))
+	assert.Nil(a.Set("/tags", []interface{}{"x"}))
+
+	state := b.State()
+	assert.Nil(a.MergeState(state))
+	b.Merge(a)
+	b.Merge(a)
+
+	docA := a.Element()
+	docB := b.Element()
+	docA.KeyLess = LexicalLess
+	docB.KeyLess = LexicalLess
+
+	assert.Equal(docA.String(), docB.String())
+	assert.Equal(`{"settings": {"font": "mono", "theme": "blue"}, "tags": ["x"], "title": "doc"}`, docA.String())
+
+	assert.Nil(b.Set("/settings", "reset"))
+	a.Merge(b)
+	assert.Equal("reset", a.Element().Select("settings").AsString())
+
+	assert.Nil(a.Set("/settings/theme", "green"))
+	b.Merge(a)
+	assert.Equal("green", b.Element().Select("settings", "theme").AsString())
+	assert.Equal(Timestamp{Counter: 3, Node: "a"}.Less(Timestamp{Counter: 3, Node: "b"}), true)
+
+	assert.NotNil(a.Set("bad", 1))
+	assert.NotNil(a.MergeState(NewAsMap()))
+}
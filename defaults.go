@@ -0,0 +1,133 @@
+package dynajson
+
+import (
+	"strings"
+)
+
+type defaultsApplier struct {
+	schemaRoot *JSONElement
+	order      *keyOrderState
+	count      int
+}
+
+// resolve ... func
+// follows local "#/..." $ref of schema.
+func (me *defaultsApplier) resolve(schema *JSONElement) *JSONElement {
+
+	for i := 0; i < 32; i++ {
+
+		ref, ok := schema.Select("$ref").Raw().(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return schema
+		}
+
+		tokens, err := ParsePointer(ref)
+		if err != nil {
+			return schema
+		}
+
+		schema = me.schemaRoot.selectTokens(tokens)
+	}
+
+	return schema
+}
+
+func (me *defaultsApplier) apply(raw interface{}, schema *JSONElement, depth int) {
+
+	if depth > 64 || !schema.IsMap() {
+		return
+	}
+
+	schema = me.resolve(schema)
+
+	for _, sub := range schema.Select("allOf").AsArray() {
+		me.apply(raw, sub, depth+1)
+	}
+
+	if typedObj, ok := raw.(map[string]interface{}); ok {
+
+		props := schema.Select("properties")
+		for _, k := range props.Keys() {
+
+			prop := me.resolve(props.Select(k))
+
+			if _, exists := typedObj[k]; !exists {
+				if def, ok := prop.Raw().(map[string]interface{}); ok {
+					if v, ok := def["default"]; ok {
+						me.order.add(typedObj, k)
+						typedObj[k] = copyRaw(v)
+						me.count++
+					}
+				}
+			}
+
+			if v, exists := typedObj[k]; exists {
+				me.apply(v, prop, depth+1)
+			}
+		}
+	}
+
+	if arr := asSlice(raw); arr != nil {
+
+		items := schema.Select("items")
+		if items.IsMap() {
+			for _, v := range arr {
+				me.apply(v, items, depth+1)
+			}
+		}
+	}
+}
+
+// ApplyDefaults ... func
+// fills keys missing in me with the "default" of their JSON Schema / OpenAPI
+// schema, recursively through properties, items, allOf and local "#/..." $ref.
+// Returns the number of values added.
+func (me *JSONElement) ApplyDefaults(schema *JSONElement) (int, error) {
+
+	if me.IsNil() {
+		return 0, me.fail("ApplyDefaults", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return 0, me.fail("ApplyDefaults", ErrorReadonly, "")
+	}
+
+	if !schema.IsMap() {
+		return 0, me.Errorf("ApplyDefaults: Schema Not Map: %T", schema.Raw())
+	}
+
+	applier := &defaultsApplier{
+		schemaRoot: schema,
+		order:      me.order,
+	}
+
+	// applied to a copy, which is checked against the limits as a whole
+	cp, err := editableCopy(me.raw)
+	if err != nil {
+		return 0, me.Errorf("ApplyDefaults: %w", err)
+	}
+
+	if me.order != nil {
+		if err := me.order.copyTo(me.order, me.raw, cp); err != nil {
+			me.order.forget(cp)
+			return 0, me.Errorf("ApplyDefaults: %w", err)
+		}
+	}
+
+	applier.apply(cp, schema, 0)
+
+	if applier.count == 0 {
+		me.order.forget(cp)
+		return 0, nil
+	}
+
+	old := me.raw
+	if err := me.replaceRaw(cp); err != nil {
+		me.order.forget(cp)
+		return 0, me.Errorf("ApplyDefaults: %w", err)
+	}
+
+	me.order.forget(old)
+
+	return applier.count, nil
+}
@@ -0,0 +1,60 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDefaults(t *testing.T) {
+
+	assert := assert.New(t)
+
+	schema, err := NewByString(`{
+		"type": "object",
+		"properties": {
+			"host": {"type": "string", "default": "localhost"},
+			"port": {"type": "integer", "default": 8080},
+			"tls": {"$ref": "#/definitions/TLS"},
+			"workers": {
+				"type": "array",
+				"items": {"type": "object", "properties": {"retries": {"default": 3}}}
+			},
+			"tags": {"type": "array", "default": ["a"]}
+		},
+		"allOf": [{"properties": {"debug": {"default": false}}}],
+		"definitions": {
+			"TLS": {"type": "object", "properties": {"enabled": {"default": true}, "cert": {"type": "string"}}}
+		}
+	}`)
+	assert.Nil(err)
+
+	config, err := NewByString(`{"port": 9090, "tls": {}, "workers": [{"name": "a"}, {"name": "b", "retries": 1}]}`)
+	assert.Nil(err)
+
+	count, err := config.ApplyDefaults(schema)
+	assert.Nil(err)
+	assert.Equal(5, count)
+
+	assert.Equal("localhost", config.Select("host").AsString())
+	assert.Equal(9090, config.Select("port").AsInt())
+	assert.True(config.Select("tls", "enabled").AsBool())
+	assert.True(config.Select("tls", "cert").IsNil())
+	assert.Equal(3, config.Select("workers", 0, "retries").AsInt())
+	assert.Equal(1, config.Select("workers", 1, "retries").AsInt())
+	assert.False(config.Select("debug").AsBool())
+	assert.Equal(KindBool, config.Select("debug").Kind())
+
+	config.Select("tags").Raw().([]interface{})[0] = "changed"
+	assert.Equal("a", schema.Select("properties", "tags", "default", 0).AsString())
+
+	// added keys are appended to the key order
+	ordered, err := NewByBytesWithOptions([]byte(`{"z": 1, "port": 1}`), ParseOptions{PreserveOrder: true})
+	assert.Nil(err)
+
+	_, err = ordered.ApplyDefaults(schema)
+	assert.Nil(err)
+	keys := ordered.Keys()
+	assert.Equal([]string{"z", "port", "debug"}, keys[:3])
+	assert.ElementsMatch([]string{"host", "tags"}, keys[3:])
+}
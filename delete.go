@@ -0,0 +1,94 @@
+package dynajson
+
+// DeleteOptions ... struct
+type DeleteOptions struct {
+	// PruneEmptyParents removes the maps and arrays left empty by the deletion,
+	// up to (not including) the element the path starts from.
+	PruneEmptyParents bool
+}
+
+// deleteChild ... func
+// Delete that first turns a parsed array into an editable one.
+func (me *JSONElement) deleteChild(key interface{}) error {
+
+	if _, ok := key.(int); ok && me.IsArray() {
+
+		if _, err := me.editableArray("Delete"); err != nil {
+			return err
+		}
+	}
+
+	return me.Delete(key)
+}
+
+// DeletePath ... func
+// removes the node at keys (string for maps, int for arrays) in one call, the
+// parents must exist (see SelectE).
+func (me *JSONElement) DeletePath(keys ...interface{}) error {
+	return me.DeletePathWith(DeleteOptions{}, keys...)
+}
+
+// DeletePathWith ... func
+func (me *JSONElement) DeletePathWith(opts DeleteOptions, keys ...interface{}) error {
+
+	keys = selectKeys(keys)
+	if len(keys) == 0 {
+		return me.fail("DeletePath", ErrorBadArgument, "no key")
+	}
+
+	if me.isReadonly() {
+		return me.fail("DeletePath", ErrorReadonly, "")
+	}
+
+	parent := me
+
+	if len(keys) > 1 {
+
+		var err error
+
+		parent, err = me.SelectE(keys[:len(keys)-1]...)
+		if err != nil {
+			return me.Errorf("DeletePath: %w", err)
+		}
+	}
+
+	if err := parent.deleteChild(keys[len(keys)-1]); err != nil {
+		return me.Errorf("DeletePath: %w", err)
+	}
+
+	if !opts.PruneEmptyParents {
+		return nil
+	}
+
+	empty := PruneOptions{RemoveEmptyContainers: true}
+
+	for cur := parent; cur != me && empty.removable(cur.raw); cur = cur.parent {
+
+		if err := cur.parent.deleteChild(cur.key); err != nil {
+			return me.Errorf("DeletePath: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteByPointer ... func
+// DeletePath with an RFC 6901 JSON Pointer, "" (me itself) can not be deleted.
+func (me *JSONElement) DeleteByPointer(pointer string) error {
+	return me.DeleteByPointerWith(DeleteOptions{}, pointer)
+}
+
+// DeleteByPointerWith ... func
+func (me *JSONElement) DeleteByPointerWith(opts DeleteOptions, pointer string) error {
+
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return me.Errorf("DeleteByPointer: %w", err)
+	}
+
+	if len(tokens) == 0 {
+		return me.fail("DeleteByPointer", ErrorBadArgument, "%s: Root Can Not Be Deleted", pointer)
+	}
+
+	return me.DeletePathWith(opts, me.pointerKeys(tokens)...)
+}
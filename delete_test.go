@@ -0,0 +1,57 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletePath(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [{"c": 1, "d": 2}, 3]}, "x": {"y": {"z": 0}}}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	assert.Nil(root.DeletePath("a", "b", 0, "c"))
+	assert.Equal(`{"a": {"b": [{"d": 2}, 3]}, "x": {"y": {"z": 0}}}`, root.String())
+
+	assert.Nil(root.DeletePath("a", "b", -1))
+	assert.Equal(`{"a": {"b": [{"d": 2}]}, "x": {"y": {"z": 0}}}`, root.String())
+
+	assert.Nil(root.DeleteByPointer("/a/b/0/d"))
+	assert.Equal(`{"a": {"b": [{}]}, "x": {"y": {"z": 0}}}`, root.String())
+
+	err = root.DeletePath("a", "q", "r")
+	assert.True(errors.Is(err, ErrNotFound))
+
+	err = root.DeleteByPointer("")
+	assert.True(errors.Is(err, ErrBadArgument))
+
+	err = root.DeleteByPointer("a")
+	assert.NotNil(err)
+
+	root.Readonly = true
+	assert.True(errors.Is(root.DeletePath("x"), ErrReadonly))
+}
+
+func TestDeletePathPrune(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [{"c": 1}], "k": 1}, "x": {"y": {"z": 0}}}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	opts := DeleteOptions{PruneEmptyParents: true}
+
+	assert.Nil(root.DeletePathWith(opts, "a", "b", 0, "c"))
+	assert.Equal(`{"a": {"k": 1}, "x": {"y": {"z": 0}}}`, root.String())
+
+	// stops at the element the path starts from
+	x := root.Select("x")
+	assert.Nil(x.DeleteByPointerWith(opts, "/y/z"))
+	assert.Equal(`{"a": {"k": 1}, "x": {}}`, root.String())
+}
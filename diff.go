@@ -0,0 +1,246 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiffKind ... type
+type DiffKind string
+
+const (
+	// DiffAdded ... only in other
+	DiffAdded DiffKind = "added"
+	// DiffRemoved ... only in me
+	DiffRemoved DiffKind = "removed"
+	// DiffChanged ... in both with different values (or kinds)
+	DiffChanged DiffKind = "changed"
+)
+
+// DiffEntry ... struct
+// Old is unset for DiffAdded, New for DiffRemoved.
+type DiffEntry struct {
+	Kind DiffKind
+	Path []interface{}
+	Old  interface{}
+	New  interface{}
+}
+
+// DiffResult ... struct
+// the differences between two documents, map keys in lexical order, array
+// elements compared by position.
+type DiffResult struct {
+	Entries []DiffEntry
+}
+
+// Empty ... func
+func (me *DiffResult) Empty() bool {
+	return len(me.Entries) == 0
+}
+
+func diffText(raw interface{}) string {
+
+	data, err := New(raw).Marshal(DumpOptions{SortKeys: true})
+	if err != nil {
+		return "?"
+	}
+
+	return string(data)
+}
+
+// String ... func
+// one line per entry: "+ /path: new", "- /path: old" or "~ /path: old -> new".
+func (me *DiffResult) String() string {
+
+	b := strings.Builder{}
+
+	for _, v := range me.Entries {
+
+		path := Path2Pointer(v.Path)
+		if path == "" {
+			path = "/"
+		}
+
+		switch v.Kind {
+		case DiffAdded:
+			b.WriteString("+ " + path + ": " + diffText(v.New))
+		case DiffRemoved:
+			b.WriteString("- " + path + ": " + diffText(v.Old))
+		default:
+			b.WriteString("~ " + path + ": " + diffText(v.Old) + " -> " + diffText(v.New))
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// MarshalJSON ... func
+// [{"kind": "changed", "path": "/a", "old": 1, "new": 2}, ...], paths as JSON Pointers.
+func (me *DiffResult) MarshalJSON() ([]byte, error) {
+
+	arr := make([]interface{}, len(me.Entries))
+
+	for i, v := range me.Entries {
+
+		entry := map[string]interface{}{
+			"kind": string(v.Kind),
+			"path": Path2Pointer(v.Path),
+		}
+
+		if v.Kind != DiffAdded {
+			entry["old"] = v.Old
+		}
+		if v.Kind != DiffRemoved {
+			entry["new"] = v.New
+		}
+
+		arr[i] = entry
+	}
+
+	return New(arr).Marshal(DumpOptions{SortKeys: true})
+}
+
+func diffEntries(ctx context.Context, path []interface{}, a, b interface{}, entries []DiffEntry) ([]DiffEntry, error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a, err := resolveSpill(a)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	b, err = resolveSpill(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	eq, err := equalRawBy(a, b, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	if eq {
+		return entries, nil
+	}
+
+	objA, okA := a.(map[string]interface{})
+	objB, okB := b.(map[string]interface{})
+
+	if okA && okB {
+
+		keys := mapKeys(objA, nil)
+		for k := range objB {
+			if _, ok := objA[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+
+			va, inA := objA[k]
+			vb, inB := objB[k]
+			sub := appendParents(path, k)
+
+			switch {
+			case !inB:
+				if entries, err = appendDiffEntry(entries, DiffRemoved, sub, va, nil); err != nil {
+					return nil, err
+				}
+			case !inA:
+				if entries, err = appendDiffEntry(entries, DiffAdded, sub, nil, vb); err != nil {
+					return nil, err
+				}
+			default:
+				if entries, err = diffEntries(ctx, sub, va, vb, entries); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return entries, nil
+	}
+
+	arrA, arrB := asSlice(a), asSlice(b)
+
+	if arrA != nil && arrB != nil {
+
+		for i := 0; i < len(arrA) || i < len(arrB); i++ {
+
+			sub := appendParents(path, i)
+
+			switch {
+			case i >= len(arrB):
+				if entries, err = appendDiffEntry(entries, DiffRemoved, sub, arrA[i], nil); err != nil {
+					return nil, err
+				}
+			case i >= len(arrA):
+				if entries, err = appendDiffEntry(entries, DiffAdded, sub, nil, arrB[i]); err != nil {
+					return nil, err
+				}
+			default:
+				if entries, err = diffEntries(ctx, sub, arrA[i], arrB[i], entries); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return entries, nil
+	}
+
+	return appendDiffEntry(entries, DiffChanged, path, a, b)
+}
+
+// appendDiffEntry ... func
+// with copies of oldRaw and newRaw, which may hold spilled values.
+func appendDiffEntry(entries []DiffEntry, kind DiffKind, path []interface{}, oldRaw, newRaw interface{}) ([]DiffEntry, error) {
+
+	oldCp, err := copyRawChecked(oldRaw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	newCp, err := copyRawChecked(newRaw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	return append(entries, DiffEntry{Kind: kind, Path: path, Old: oldCp, New: newCp}), nil
+}
+
+// Diff ... func
+// reports what differs from me in other, paths relative to me. Unlike
+// DiffAsPatch the result is meant for people (String) and tools (MarshalJSON).
+// nil when a spilled value can not be read, the error goes to Warn.
+func (me *JSONElement) Diff(other *JSONElement) *DiffResult {
+
+	ret, err := me.DiffContext(context.Background(), other)
+	if err != nil {
+		me.Warn("Diff: %v", err)
+	}
+
+	return ret
+}
+
+// DiffContext ... func
+// same as Diff, ctx is checked at every value compared.
+func (me *JSONElement) DiffContext(ctx context.Context, other *JSONElement) (*DiffResult, error) {
+
+	var raw interface{}
+	if other != nil {
+		raw = other.Raw()
+	}
+
+	entries, err := diffEntries(ctx, []interface{}{}, me.Raw(), raw, []DiffEntry{})
+	if err != nil {
+		return nil, fmt.Errorf("DiffContext: %w", err)
+	}
+
+	return &DiffResult{Entries: entries}, nil
+}
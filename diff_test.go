@@ -0,0 +1,52 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+
+	assert := assert.New(t)
+
+	a, err := NewByString(`{"name": "app", "port": 80, "tags": ["a", "b"], "db": {"host": "x"}, "old": true}`)
+	assert.Nil(err)
+
+	b, err := NewByString(`{"name": "app", "port": 8080, "tags": ["a"], "db": {"host": "x", "user": "u"}, "new": [1]}`)
+	assert.Nil(err)
+
+	diff := a.Diff(b)
+	assert.False(diff.Empty())
+	assert.Equal(5, len(diff.Entries))
+
+	assert.Equal(DiffEntry{Kind: DiffAdded, Path: []interface{}{"db", "user"}, New: "u"}, diff.Entries[0])
+	assert.Equal(DiffEntry{Kind: DiffChanged, Path: []interface{}{"port"}, Old: 80.0, New: 8080.0}, diff.Entries[3])
+
+	assert.Equal(`+ /db/user: "u"
++ /new: [1]
+- /old: true
+~ /port: 80 -> 8080
+- /tags/1: "b"
+`, diff.String())
+
+	data, err := json.Marshal(diff)
+	assert.Nil(err)
+
+	report, err := NewByBytes(data)
+	assert.Nil(err)
+	assert.Equal(5, report.Count())
+	assert.Equal("changed", report.Select(3, "kind").AsString())
+	assert.Equal("/port", report.Select(3, "path").AsString())
+	assert.Equal(8080, report.Select(3, "new").AsInt())
+	assert.True(report.Select(0, "old").IsNil())
+
+	assert.True(a.Diff(a).Empty())
+	assert.Equal("", a.Diff(a).String())
+
+	// kinds differ at the root
+	c, err := NewByString(`[1]`)
+	assert.Nil(err)
+	assert.Equal("~ /: [1] -> \"x\"\n", c.Diff(New("x")).String())
+}
@@ -2,14 +2,14 @@ package dynajson
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"net/http"
 	"runtime"
 	"sort"
-	"strings"
+	"sync"
 )
 
 func escapeJSONString(arg string) string {
@@ -29,17 +29,55 @@ func escapeJSONString(arg string) string {
 
 // Dump ...https://pod.hatenablog.com/entry/2016/05/15/232710
 func Dump(d *interface{}, buf *bytes.Buffer) {
+	dump(d, buf, nil, nil, nil)
+}
+
+// DumpSorted ... func
+// same as Dump with map keys ordered by less.
+func DumpSorted(d *interface{}, buf *bytes.Buffer, less func(string, string) bool) {
+
+	if less == nil {
+		less = LexicalLess
+	}
+
+	dump(d, buf, less, nil, nil)
+}
+
+// LegacyDump ... var
+// restores the output of older versions in Dump and String(): control characters
+// are not escaped and null is printed as <nil> (both invalid JSON).
+var LegacyDump = false
+
+func dump(d *interface{}, buf *bytes.Buffer, less func(string, string) bool, order *keyOrderState, warn func(string, ...interface{})) {
+
+	if LegacyDump {
+		dumpLegacy(d, buf, less)
+		return
+	}
+
+	enc := &encoder{
+		buf:     buf,
+		less:    less,
+		order:   order,
+		lenient: true,
+		warn:    warn,
+	}
+
+	enc.encode(nil, *d, 0)
+}
+
+func dumpLegacy(d *interface{}, buf *bytes.Buffer, less func(string, string) bool) {
 	switch v := (*d).(type) {
 	// * add [pointer of array] -->
 	case *[]interface{}:
 		var i interface{} = *v
 		//i = *v
-		Dump(&i, buf)
+		dumpLegacy(&i, buf, less)
 		// * add [pointer of array] <--
 	case []interface{}:
 		buf.WriteString("[")
 		for _, sub := range v {
-			Dump(&sub, buf)
+			dumpLegacy(&sub, buf, less)
 			buf.WriteString(", ")
 		}
 		if len(v) > 0 {
@@ -48,19 +86,26 @@ func Dump(d *interface{}, buf *bytes.Buffer) {
 		buf.WriteString("]")
 	case map[string]interface{}:
 		buf.WriteString("{")
-		for k, sub := range v {
+		for _, k := range mapKeys(v, less) {
+			sub := v[k]
 			// * add escape -->
 			//buf.WriteString(fmt.Sprintf(`"%s"`, k))
 			buf.WriteString(fmt.Sprintf(`"%s"`, escapeJSONString(k)))
 			// * add escape <--
 			buf.WriteString(": ")
-			Dump(&sub, buf)
+			dumpLegacy(&sub, buf, less)
 			buf.WriteString(", ")
 		}
 		if len(v) > 0 {
 			buf.Truncate(buf.Len() - 2)
 		}
 		buf.WriteString("}")
+	case *spillRef:
+		obj, err := resolveSpill(v)
+		if err != nil {
+			obj = nil
+		}
+		dumpLegacy(&obj, buf, less)
 	case string:
 		// * add escape -->
 		//buf.WriteString(fmt.Sprintf(`"%s"`, v))
@@ -80,6 +125,19 @@ type JSONElement struct {
 	FatalHandler func(*JSONElement, string, string, int)
 	level        int
 	Readonly     bool
+	coverage     *Coverage
+	// KeyLess orders map keys in EachMap, Keys and String, nil means
+	// lexical order for EachMap and map order for Keys and String.
+	KeyLess  func(string, string) bool
+	locker   sync.Locker
+	limits   *limitState
+	feed     *changeFeed
+	lazyRefs *lazyRefState
+	cow      *cowState
+	order    *keyOrderState
+	// frozen and config are kept by the root only, see Freeze and Config.
+	frozen *frozenState
+	config *Config
 }
 
 // ---------------------------------------------------------------------------
@@ -125,6 +183,93 @@ func NewByBytes(data []byte) (*JSONElement, error) {
 	return New(obj), nil
 }
 
+// ParseOptions ... struct
+type ParseOptions struct {
+	UTF8 UTF8Policy
+	// OnUTF8Issue is called for every invalid sequence found.
+	OnUTF8Issue func(UTF8Issue)
+	// PreserveOrder keeps map keys in the order of the source (see NewAsOrderedMap).
+	PreserveOrder bool
+	// UseNumber keeps numbers as json.Number, so 64-bit integers are not rounded.
+	UseNumber bool
+	// Lenient accepts comments, trailing commas and unquoted keys (see LenientToJSON).
+	Lenient bool
+	// Limits rejects hostile input before it is decoded.
+	Limits ParseLimits
+}
+
+// NewByBytesWithOptions ... func
+func NewByBytesWithOptions(data []byte, opts ParseOptions) (*JSONElement, error) {
+
+	limits := opts.Limits
+
+	if opts.Lenient {
+
+		var err error
+
+		if err = checkLimit("MaxBytes", limits.MaxBytes, len(data)); err != nil {
+			return nil, fmt.Errorf("NewByBytesWithOptions: %w", err)
+		}
+
+		if data, err = LenientToJSON(data); err != nil {
+			return nil, fmt.Errorf("NewByBytesWithOptions: %w", err)
+		}
+
+		// quoting keys makes it longer
+		limits.MaxBytes = 0
+	}
+
+	if !limits.isZero() {
+		if err := checkParseLimits(data, limits); err != nil {
+			return nil, fmt.Errorf("NewByBytesWithOptions: %w", err)
+		}
+	}
+
+	marked, issues := scanUTF8(data, opts.UTF8 == UTF8PassThrough)
+
+	if opts.OnUTF8Issue != nil {
+		for _, v := range issues {
+			opts.OnUTF8Issue(v)
+		}
+	}
+
+	if len(issues) > 0 {
+		switch opts.UTF8 {
+		case UTF8Reject:
+			return nil, fmt.Errorf("NewByBytesWithOptions: %s", issues[0])
+		case UTF8PassThrough:
+			data = marked
+		}
+	}
+
+	var obj interface{}
+	var order *keyOrderState
+	var err error
+
+	if opts.PreserveOrder {
+		order = newKeyOrderState()
+		obj, err = decodeOrdered(data, order, opts.UseNumber)
+	} else if opts.UseNumber {
+		obj, err = decodeNumbers(data)
+	} else {
+		err = json.Unmarshal(data, &obj)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal: %w", err)
+	}
+
+	if opts.UTF8 == UTF8PassThrough && len(issues) > 0 {
+		obj = unmarkRaw(obj)
+		order.unmark()
+	}
+
+	elm := New(obj)
+	elm.order = order
+
+	return elm, nil
+}
+
 // NewByString ... func
 func NewByString(data string) (*JSONElement, error) {
 
@@ -134,49 +279,52 @@ func NewByString(data string) (*JSONElement, error) {
 // NewByPath ... func
 func NewByPath(argPath string) (*JSONElement, error) {
 
-	var data []byte
+	return loadByPath(context.Background(), argPath, loadConfig{})
+}
 
-	if strings.HasPrefix(argPath, "http://") || strings.HasPrefix(argPath, "https://") {
+// loadConfig ... struct
+// per call settings of loadByPath.
+type loadConfig struct {
+	progress ProgressFunc
+	fetcher  Fetcher
+	// contentType overrides the Content-Type of the fetched document.
+	contentType string
+}
 
-		// https://golang.hateblo.jp/entry/golang-http-request
-		// https://qiita.com/ono_matope/items/60e96c01b43c64ed1d18
-		// https://qiita.com/stk0724/items/dc400dccd29a4b3d6471
+func (me loadConfig) reader(r io.Reader, total int64) io.Reader {
 
-		req, err := http.NewRequest(http.MethodGet, argPath, nil)
-		if err != nil {
-			return nil, fmt.Errorf("http.NewRequest: %s: %w", argPath, err)
-		}
+	if me.progress == nil {
+		return r
+	}
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("http.DefaultClient.Do: %s: %w", argPath, err)
-		}
-		defer func() {
-			io.Copy(ioutil.Discard, resp.Body)
-			resp.Body.Close()
-		}()
+	return NewProgressReader(r, total, me.progress)
+}
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("StatusCode != 200: %s: %d", argPath, resp.StatusCode)
-		}
+func loadByPath(ctx context.Context, argPath string, lc loadConfig) (*JSONElement, error) {
 
-		bytes, err := ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("ReadAll: %s: %w", argPath, err)
-		}
+	fetcher := lc.fetcher
+	if fetcher == nil {
+		fetcher = GetDefaultFetcher()
+	}
 
-		data = bytes
-	} else {
+	res, err := fetcher.Fetch(ctx, argPath)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
 
-		bytes, err := ioutil.ReadFile(argPath)
-		if err != nil {
-			return nil, fmt.Errorf("ReadFile: %s: %w", argPath, err)
-		}
+	// a canceled ctx also stops reading a slow file or body between chunks
+	data, err := ioutil.ReadAll(lc.reader(&ctxReader{ctx: ctx, r: res.Body}, res.Size))
+	if err != nil {
+		return nil, fmt.Errorf("ReadAll: %s: %w", argPath, err)
+	}
 
-		data = bytes
+	obj, err := decodeFetched(argPath, data, res.ContentType, lc.contentType)
+	if err != nil {
+		return nil, err
 	}
 
-	return NewByBytes(data)
+	return New(obj), nil
 }
 
 // ---------------------------------------------------------------------------
@@ -184,13 +332,14 @@ func NewByPath(argPath string) (*JSONElement, error) {
 // Warn ... func
 func (me *JSONElement) Warn(format string, a ...interface{}) {
 
-	if me.WarnHandler == nil {
+	handler := me.warnHandler()
+	if handler == nil {
 		return
 	}
 
 	_, where, line, _ := runtime.Caller(2)
 
-	me.WarnHandler(me, fmt.Sprintf(format, a...), where, line)
+	handler(me, fmt.Sprintf(format, a...), where, line)
 }
 
 // Fatal ... func
@@ -198,17 +347,15 @@ func (me *JSONElement) Fatal(format string, a ...interface{}) {
 
 	_, where, line, _ := runtime.Caller(3)
 
-	if me.FatalHandler == nil {
+	handler := me.fatalHandler()
+	if handler == nil {
 
-		if me.WarnHandler == nil {
+		if handler = me.warnHandler(); handler == nil {
 			return
 		}
-
-		me.WarnHandler(me, fmt.Sprintf(format, a...), where, line)
-		return
 	}
 
-	me.FatalHandler(me, fmt.Sprintf(format, a...), where, line)
+	handler(me, fmt.Sprintf(format, a...), where, line)
 }
 
 // Errorf ... func
@@ -273,31 +420,54 @@ func updateElms2Raws(arg []interface{}) {
 func (me *JSONElement) Put(key string, val1 interface{}, vals ...interface{}) error {
 
 	if me.IsNil() {
-		return me.Errorf("key=[%s]: me.raw is null", key)
+		return me.fail("Put", ErrorNilObject, "key=[%s]", key)
 	}
 
-	if me.Readonly {
-		return me.Errorf("key=[%s]: me.Readonly is true", key)
+	if me.isReadonly() {
+		return me.fail("Put", ErrorReadonly, "key=[%s]", key)
 	}
 
+	me.unshare()
+
 	typedObj, ok := me.raw.(map[string]interface{})
 	if !ok {
-		return me.Errorf("key=[%s]: Not Map Type: %T", key, me.raw)
+		return me.fail("Put", ErrorNotMap, "key=[%s]: %T", key, me.raw)
 	}
 
+	var newRaw interface{}
+
 	switch len(vals) {
 	case 0:
 		// 一つの時は "key": val
-		typedObj[key] = elm2Raw(val1)
+		newRaw = elm2Raw(val1)
 	default:
 		// 複数の時は "key": [val, val, ...]
 		arr := []interface{}{val1}
 		arr = append(arr, vals...)
 		updateElms2Raws(arr)
 
-		typedObj[key] = &arr
+		newRaw = &arr
+	}
+
+	if err := me.checkKeyLimits(key); err != nil {
+		return me.Errorf("key=[%s]: %w", key, err)
+	}
+
+	if err := me.checkLimits(typedObj[key], newRaw); err != nil {
+		return me.Errorf("key=[%s]: %w", key, err)
 	}
 
+	op := "add"
+	if _, ok := typedObj[key]; ok {
+		op = "replace"
+	}
+
+	me.order.add(typedObj, key)
+	me.order.replace(typedObj[key], newRaw)
+
+	typedObj[key] = newRaw
+	me.notify(op, key, newRaw)
+
 	return nil
 }
 
@@ -305,26 +475,37 @@ func (me *JSONElement) Put(key string, val1 interface{}, vals ...interface{}) er
 func (me *JSONElement) Append(val1 interface{}, vals ...interface{}) error {
 
 	if me.IsNil() {
-		return me.Errorf("me.raw is null")
+		return me.fail("Append", ErrorNilObject, "")
 	}
 
-	if me.Readonly {
-		return me.Errorf("me.Readonly is true")
+	if me.isReadonly() {
+		return me.fail("Append", ErrorReadonly, "")
 	}
 
+	me.unshare()
+
 	refArr, ok := me.raw.(*[]interface{})
 	if !ok {
-		return me.Errorf("Not Editable-Array Type: %T", me.raw)
+		return me.fail("Append", ErrorNotArray, "%T", me.raw)
 	}
 
-	(*refArr) = append((*refArr), val1)
+	added := append([]interface{}{val1}, vals...)
+	updateElms2Raws(added)
 
-	if len(vals) != 0 {
-		(*refArr) = append((*refArr), vals...)
+	if err := me.checkAppendLimits(len(*refArr), added); err != nil {
+		return me.Errorf("%w", err)
 	}
 
+	(*refArr) = append((*refArr), added...)
+
 	updateElms2Raws(*refArr)
 
+	end := me.batch()
+	for i, v := range added {
+		me.notify("add", len(*refArr)-len(added)+i, v)
+	}
+	end()
+
 	return nil
 }
 
@@ -332,11 +513,11 @@ func (me *JSONElement) Append(val1 interface{}, vals ...interface{}) error {
 func (me *JSONElement) PutEmptyMap(key string) (*JSONElement, error) {
 
 	if me.IsNil() {
-		return nil, me.Errorf("key=[%s]: me.raw is null", key)
+		return nil, me.fail("PutEmptyMap", ErrorNilObject, "key=[%s]", key)
 	}
 
-	if me.Readonly {
-		return nil, me.Errorf("key=[%s]: me.Readonly is true", key)
+	if me.isReadonly() {
+		return nil, me.fail("PutEmptyMap", ErrorReadonly, "key=[%s]", key)
 	}
 
 	err := me.Put(key, map[string]interface{}{})
@@ -351,11 +532,11 @@ func (me *JSONElement) PutEmptyMap(key string) (*JSONElement, error) {
 func (me *JSONElement) PutEmptyArray(key string) (*JSONElement, error) {
 
 	if me.IsNil() {
-		return nil, me.Errorf("key=[%s]: me.raw is null", key)
+		return nil, me.fail("PutEmptyArray", ErrorNilObject, "key=[%s]", key)
 	}
 
-	if me.Readonly {
-		return nil, me.Errorf("key=[%s]: me.Readonly is true", key)
+	if me.isReadonly() {
+		return nil, me.fail("PutEmptyArray", ErrorReadonly, "key=[%s]", key)
 	}
 
 	err := me.Put(key, &[]interface{}{})
@@ -370,24 +551,34 @@ func (me *JSONElement) PutEmptyArray(key string) (*JSONElement, error) {
 func (me *JSONElement) DeleteByKey(key string) error {
 
 	if me.IsNil() {
-		return me.Errorf("key=[%s]: me.raw is null", key)
+		return me.fail("DeleteByKey", ErrorNilObject, "key=[%s]", key)
 	}
 
-	if me.Readonly {
-		return me.Errorf("key=[%s]: me.Readonly is true", key)
+	if me.isReadonly() {
+		return me.fail("DeleteByKey", ErrorReadonly, "key=[%s]", key)
 	}
 
 	typedObj, ok := me.raw.(map[string]interface{})
 	if !ok {
-		return me.Errorf("key=[%s]: Not Map Type: %T", key, me.raw)
+		return me.fail("DeleteByKey", ErrorNotMap, "key=[%s]: %T", key, me.raw)
 	}
 
 	if _, ok := typedObj[key]; !ok {
+		if me.strictDelete() {
+			return me.fail("DeleteByKey", ErrorNotFound, "key=[%s]", key)
+		}
+
 		me.Warn("DeleteByKey(%s): No Key", key)
 		return nil
 	}
 
+	me.unshare()
+	typedObj = me.raw.(map[string]interface{})
+
+	me.order.remove(typedObj, key)
+
 	delete(typedObj, key)
+	me.notify("remove", key, nil)
 
 	return nil
 }
@@ -398,29 +589,48 @@ func remove(slice []interface{}, s int) []interface{} {
 }
 
 // DeleteByPos ... func
+// a negative pos counts from the end, -1 is the last element.
 func (me *JSONElement) DeleteByPos(pos int) error {
 
 	if me.IsNil() {
-		return me.Errorf("pos=[%d]: me.raw is null", pos)
+		return me.fail("DeleteByPos", ErrorNilObject, "pos=[%d]", pos)
 	}
 
-	if me.Readonly {
-		return me.Errorf("pos=[%d]: me.Readonly is true", pos)
+	if me.isReadonly() {
+		return me.fail("DeleteByPos", ErrorReadonly, "pos=[%d]", pos)
 	}
 
-	refArr, ok := me.raw.(*[]interface{})
-	if !ok {
-		return me.Errorf("pos=[%d]: Not Editable-Array Type: %T", pos, me.raw)
+	arr := asSlice(me.raw)
+	if arr == nil {
+		return me.fail("DeleteByPos", ErrorNotArray, "pos=[%d]: %T", pos, me.raw)
 	}
 
-	containerLen := len(*refArr)
+	containerLen := len(arr)
+
+	idx := pos
+	if idx < 0 {
+		// -1 is the last element
+		idx += containerLen
+	}
+
+	if idx < 0 || idx >= containerLen {
+		if me.strictDelete() {
+			return me.fail("DeleteByPos", ErrorNotFound, "pos=[%d]: length %d", pos, containerLen)
+		}
 
-	if pos >= containerLen {
 		me.Warn("DeleteByPos(%d): Overflow: Container(%d)", pos, containerLen)
 		return nil
 	}
 
-	(*refArr) = remove(*refArr, pos)
+	// a parsed array becomes editable
+	refArr, err := me.editableArray("DeleteByPos")
+	if err != nil {
+		return err
+	}
+
+	me.order.forget((*refArr)[idx])
+	(*refArr) = remove(*refArr, idx)
+	me.notify("remove", idx, nil)
 
 	return nil
 }
@@ -429,7 +639,7 @@ func (me *JSONElement) DeleteByPos(pos int) error {
 func (me *JSONElement) Delete(arg interface{}) error {
 
 	if me.IsNil() {
-		return me.Errorf("me.raw is null")
+		return me.fail("Delete", ErrorNilObject, "")
 	}
 
 	switch v := arg.(type) {
@@ -439,21 +649,54 @@ func (me *JSONElement) Delete(arg interface{}) error {
 		return me.DeleteByKey(v)
 	}
 
-	return me.Errorf("Bad Argument Type: %T", arg)
+	return me.fail("Delete", ErrorBadArgument, "%T", arg)
 }
 
 // ---------------------------------------------------------------------------
 
 func (me *JSONElement) child(key, raw interface{}) *JSONElement {
 
-	return &JSONElement{
-		parent:      me,
-		key:         key,
-		raw:         raw,
-		WarnHandler: me.WarnHandler,
-		level:       me.level + 1,
-		Readonly:    me.Readonly,
+	readonly := me.Readonly
+
+	if _, ok := raw.(*spillRef); ok {
+
+		obj, err := resolveSpill(raw)
+		if err != nil {
+			me.Warn("child(%v): Spill: %v", key, err)
+		}
+
+		// not written back to the file (see NewByPathSpill)
+		raw = obj
+		readonly = true
+	}
+
+	if me.lazyRefs != nil {
+		raw = me.resolveLazyRef(key, raw)
+	}
+
+	elm := &JSONElement{
+		parent:       me,
+		key:          key,
+		raw:          raw,
+		WarnHandler:  me.WarnHandler,
+		FatalHandler: me.FatalHandler,
+		level:        me.level + 1,
+		Readonly:     readonly,
+		coverage:     me.coverage,
+		KeyLess:      me.KeyLess,
+		locker:       me.locker,
+		limits:       me.limits,
+		feed:         me.feed,
+		lazyRefs:     me.lazyRefs,
+		cow:          me.cow,
+		order:        me.order,
+	}
+
+	if elm.coverage != nil {
+		elm.coverage.record(elm.FullPath())
 	}
+
+	return elm
 }
 
 func (me *JSONElement) String() string {
@@ -461,7 +704,13 @@ func (me *JSONElement) String() string {
 	buf := &bytes.Buffer{}
 
 	if me.raw != nil {
-		Dump(&me.raw, buf)
+		dump(&me.raw, buf, me.KeyLess, me.order, me.Warn)
+	}
+
+	if OutputCheckHandler != nil {
+		if issues := me.SelfCheck(); len(issues) > 0 {
+			OutputCheckHandler(me, issues)
+		}
 	}
 
 	return buf.String()
@@ -506,6 +755,7 @@ func (me *JSONElement) SelectByKey(key string) *JSONElement {
 }
 
 // SelectByPos ... func
+// a negative pos counts from the end, -1 is the last element.
 func (me *JSONElement) SelectByPos(pos int) *JSONElement {
 
 	if me.IsNil() {
@@ -527,7 +777,12 @@ func (me *JSONElement) SelectByPos(pos int) *JSONElement {
 
 	containerLen := len(*refArr)
 
-	if pos >= containerLen {
+	if pos < 0 {
+		// -1 is the last element
+		pos += containerLen
+	}
+
+	if pos < 0 || pos >= containerLen {
 		me.Warn("pos=[%d]: SelectByPos: Overflow: %d", pos, containerLen)
 
 		return me.child(pos, nil)
@@ -605,6 +860,10 @@ func (me *JSONElement) Keys() []string {
 		return []string{}
 	}
 
+	if me.order != nil {
+		return me.order.keys(typedObj, me.KeyLess)
+	}
+
 	keys := make([]string, len(typedObj))
 
 	i := 0
@@ -613,6 +872,12 @@ func (me *JSONElement) Keys() []string {
 		i++
 	}
 
+	if me.KeyLess != nil {
+		sort.Slice(keys, func(i, j int) bool {
+			return me.KeyLess(keys[i], keys[j])
+		})
+	}
+
 	return keys
 }
 
@@ -633,16 +898,12 @@ func (me *JSONElement) EachMap(callback func(string, *JSONElement) (bool, error)
 		return nil
 	}
 
-	keys := make([]string, containerLen)
-
-	i := 0
-	for k := range typedObj {
-
-		keys[i] = k
-		i++
+	less := me.KeyLess
+	if less == nil {
+		less = LexicalLess
 	}
 
-	sort.Strings(keys)
+	keys := me.order.keys(typedObj, less)
 
 	for _, k := range keys {
 		cont, err := callback(k, me.child(k, typedObj[k]))
@@ -698,6 +959,11 @@ func walk(argParents []interface{}, argVal interface{}, callback walkCallbackTyp
 	var refArr *[]interface{}
 	var objMap map[string]interface{}
 
+	argVal, err := resolveSpill(argVal)
+	if err != nil {
+		return false, fmt.Errorf("resolveSpill: %w", err)
+	}
+
 	switch v := argVal.(type) {
 	case []interface{}:
 		refArr = &v
@@ -710,6 +976,11 @@ func walk(argParents []interface{}, argVal interface{}, callback walkCallbackTyp
 	if refArr != nil {
 
 		for k, v := range *refArr {
+			v, err := resolveSpill(v)
+			if err != nil {
+				return false, fmt.Errorf("%v: resolveSpill: %w", k, err)
+			}
+
 			cont, err := callback(argParents, k, v)
 			if err != nil {
 				return false, fmt.Errorf("%v: callback: %w", k, err)
@@ -719,7 +990,7 @@ func walk(argParents []interface{}, argVal interface{}, callback walkCallbackTyp
 				return false, nil
 			}
 
-			cont, err = walk(append(argParents, k), v, callback)
+			cont, err = walk(appendParents(argParents, k), v, callback)
 			if err != nil {
 				return false, fmt.Errorf("%v: walk: %w", k, err)
 			}
@@ -732,6 +1003,11 @@ func walk(argParents []interface{}, argVal interface{}, callback walkCallbackTyp
 
 	if objMap != nil {
 		for k, v := range objMap {
+			v, err := resolveSpill(v)
+			if err != nil {
+				return false, fmt.Errorf("%v: resolveSpill: %w", k, err)
+			}
+
 			cont, err := callback(argParents, k, v)
 			if err != nil {
 				return false, fmt.Errorf("%v: callback: %w", k, err)
@@ -741,7 +1017,7 @@ func walk(argParents []interface{}, argVal interface{}, callback walkCallbackTyp
 				return false, nil
 			}
 
-			cont, err = walk(append(argParents, k), v, callback)
+			cont, err = walk(appendParents(argParents, k), v, callback)
 			if err != nil {
 				return false, fmt.Errorf("%v: walk: %w", k, err)
 			}
@@ -756,6 +1032,7 @@ func walk(argParents []interface{}, argVal interface{}, callback walkCallbackTyp
 }
 
 // Walk ... func
+// parents is a fresh slice per level, callbacks may keep it.
 func (me *JSONElement) Walk(callback walkCallbackType) error {
 
 	_, err := walk([]interface{}{}, me.raw, callback)
@@ -781,6 +1058,36 @@ func (me *JSONElement) FullPath() []interface{} {
 	return fullPath
 }
 
+// Parent ... func
+// the element me was selected from, nil for the root.
+func (me *JSONElement) Parent() *JSONElement {
+	return me.parent
+}
+
+// Root ... func
+// the top-most element of the chain me was selected through.
+func (me *JSONElement) Root() *JSONElement {
+
+	elm := me
+	for elm.parent != nil {
+		elm = elm.parent
+	}
+
+	return elm
+}
+
+// Path ... func
+// the keys and indexes from the root to me, same as FullPath.
+func (me *JSONElement) Path() []interface{} {
+	return me.FullPath()
+}
+
+// PathString ... func
+// Path as a JSON Pointer, "" for the root.
+func (me *JSONElement) PathString() string {
+	return Path2Pointer(me.FullPath())
+}
+
 // FullPath2Str ... func
 func FullPath2Str(fullPath []interface{}, sep string) string {
 
@@ -873,6 +1180,8 @@ func (me *JSONElement) AsInt() int {
 		rv = v
 	case float64:
 		rv = int(v)
+	case json.Number:
+		rv = int(me.AsInt64())
 	default:
 		me.Warn("AsInt: Cast: %T", me.raw)
 	}
@@ -895,6 +1204,8 @@ func (me *JSONElement) AsFloat() float64 {
 		rv = float64(v)
 	case float64:
 		rv = v
+	case json.Number:
+		rv = numberFloat(v)
 	default:
 		me.Warn("AsInt: Cast: %T", me.raw)
 	}
@@ -77,7 +77,7 @@ func TestWrite1(t *testing.T) {
 
 	root := NewAsMap()
 	root.WarnHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	m1, err := root.PutEmptyMap("m1")
@@ -126,6 +126,14 @@ func TestRead1(t *testing.T) {
 
 	assert := assert.New(t)
 
+	canned, err := ioutil.ReadFile(filepath.Join(currentDir(), "testdata", "petstore.json"))
+	assert.Nil(err)
+
+	prev := SetDefaultFetcher(MapFetcher{
+		"https://petstore.swagger.io/v2/swagger.json": canned,
+	})
+	defer SetDefaultFetcher(prev)
+
 	root, err := NewByPath("https://petstore.swagger.io/v2/swagger.json")
 	assert.Nil(err)
 
@@ -134,7 +142,7 @@ func TestRead1(t *testing.T) {
 	}
 
 	root.WarnHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	tags := root.Select("tags")
@@ -184,7 +192,7 @@ func TestRead2(t *testing.T) {
 	}
 
 	root.WarnHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	glossDiv := root.Select("glossary").Select("GlossDiv")
@@ -198,7 +206,7 @@ func TestRead2(t *testing.T) {
 	assert.Nil(err)
 
 	root2.WarnHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	seeAlso2 := root2.Select("glossary").Select("GlossDiv").Select("GlossList").Select("GlossEntry").Select("GlossDef").Select("GlossSeeAlso").String()
@@ -215,7 +223,7 @@ func TestReadonly1(t *testing.T) {
 	assert.Nil(err)
 
 	root.WarnHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	assert.Equal("abc", root.Select("str").AsString())
@@ -244,11 +252,11 @@ func TestReadonly2(t *testing.T) {
 	assert.Nil(err)
 
 	root.WarnHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Warn(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	root.FatalHandler = func(me *JSONElement, message string, where string, line int) {
-		fmt.Fprintf(os.Stderr, "Fatal(%d): %s(%d): %s\n", me.Level, where, line, message)
+		fmt.Fprintf(os.Stderr, "Fatal(%d): %s(%d): %s\n", me.level, where, line, message)
 	}
 
 	root.Readonly = true
@@ -286,3 +294,45 @@ func TestReadonly2(t *testing.T) {
 		fmt.Println(err)
 	}
 }
+
+func TestPath(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [{"b/c": 1}], "d": {"~e": true}}`)
+	assert.Nil(err)
+
+	assert.Equal([]interface{}{}, root.Path())
+	assert.Equal("", root.PathString())
+
+	elm := root.Select("a", 0, "b/c")
+	assert.Equal([]interface{}{"a", 0, "b/c"}, elm.Path())
+	assert.Equal("/a/0/b~1c", elm.PathString())
+
+	assert.Equal("/d/~0e", root.Select("d").Select("~e").PathString())
+
+	root.EachMap(func(key string, val *JSONElement) (bool, error) {
+		assert.Equal("/"+key, val.PathString())
+		return true, nil
+	})
+}
+
+func TestParentRoot(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"user": {"name": "a", "age": 3}}`)
+	assert.Nil(err)
+
+	assert.Nil(root.Parent())
+	assert.Equal(root, root.Root())
+
+	name := root.Select("user", "name")
+	assert.Equal(3, name.Parent().Select("age").AsInt())
+	assert.Equal(root, name.Root())
+	assert.Equal(root, name.Parent().Parent())
+
+	// the parent sees changes made through the child and vice versa
+	assert.Nil(name.Parent().Put("age", 4))
+	assert.Equal(4, root.Select("user", "age").AsInt())
+}
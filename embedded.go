@@ -0,0 +1,105 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+func parseEmbeddedJSON(str string) (interface{}, bool) {
+
+	trimmed := strings.TrimSpace(str)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return nil, false
+	}
+
+	var obj interface{}
+
+	err := json.Unmarshal([]byte(trimmed), &obj)
+	if err != nil {
+		return nil, false
+	}
+
+	return obj, true
+}
+
+// ExpandJSONStrings ... func
+// replaces string leaves holding a JSON object or array with the parsed subtree,
+// recursively (JSON inside expanded JSON is expanded too).
+// With paths (patterns, see MatchPath) only the matching leaves are expanded.
+func (me *JSONElement) ExpandJSONStrings(paths ...string) (int, error) {
+
+	if me.IsNil() {
+		return 0, me.fail("ExpandJSONStrings", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return 0, me.fail("ExpandJSONStrings", ErrorReadonly, "")
+	}
+
+	me.unshareAll()
+
+	matches := func(rel []interface{}) bool {
+		return len(paths) == 0 || matchAnyPath(paths, rel)
+	}
+
+	count := 0
+
+	var expand func(raw interface{}, rel []interface{})
+	expand = func(raw interface{}, rel []interface{}) {
+
+		entries, err := walkEntries(rel, raw)
+		if err != nil {
+			me.Warn("ExpandJSONStrings: %v", err)
+			return
+		}
+
+		for _, v := range entries {
+
+			subRel := appendParents(rel, v.key)
+			sub := v.val
+
+			if str, ok := sub.(string); ok && matches(subRel) {
+				if obj, ok := parseEmbeddedJSON(str); ok {
+					setContainerRaw(raw, v.key, obj)
+					sub = obj
+					count++
+				}
+			}
+
+			expand(sub, subRel)
+		}
+	}
+
+	expand(me.raw, []interface{}{})
+
+	if count > 0 {
+		me.notifyReplaced()
+	}
+
+	return count, nil
+}
+
+// CollapseToJSONString ... func
+// the reverse of ExpandJSONStrings, the subtree at path ("/" separated) becomes its compact JSON text.
+func (me *JSONElement) CollapseToJSONString(path string) error {
+
+	keys := me.SlashPath2Keys(path)
+
+	parent, key, err := me.selectParent(keys)
+	if err != nil {
+		return me.Errorf("CollapseToJSONString: %s: %w", path, err)
+	}
+
+	line, err := parent.Select(key).MarshalLine()
+	if err != nil {
+		return me.Errorf("CollapseToJSONString: %s: %w", path, err)
+	}
+
+	err = parent.setChild(key, string(bytes.TrimRight(line, "\n")))
+	if err != nil {
+		return me.Errorf("CollapseToJSONString: %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandJSONStrings(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data := `{
+		"msg": "{\"user\": {\"id\": 7}, \"inner\": \"[1, 2]\"}",
+		"list": ["{\"a\": 1}", "not json", "{broken"],
+		"num": "123"
+	}`
+
+	root, err := NewByString(data)
+	assert.Nil(err)
+
+	count, err := root.ExpandJSONStrings()
+	assert.Nil(err)
+	assert.Equal(3, count)
+	assert.Equal(7, root.Select("msg", "user", "id").AsInt())
+	assert.Equal(2, root.Select("msg", "inner", 1).AsInt())
+	assert.Equal(1, root.Select("list", 0, "a").AsInt())
+	assert.Equal("not json", root.Select("list", 1).AsString())
+	assert.Equal("123", root.Select("num").AsString())
+
+	assert.Nil(root.CollapseToJSONString("msg/user"))
+	assert.Equal(`{"id":7}`, root.Select("msg", "user").AsString())
+
+	assert.Nil(root.CollapseToJSONString("list/0"))
+	assert.Equal(`{"a":1}`, root.Select("list", 0).AsString())
+	assert.NotNil(root.CollapseToJSONString("none/x"))
+
+	root, err = NewByString(data)
+	assert.Nil(err)
+
+	count, err = root.ExpandJSONStrings("list/*")
+	assert.Nil(err)
+	assert.Equal(1, count)
+	assert.True(root.Select("msg").Kind() == KindString)
+}
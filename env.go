@@ -0,0 +1,150 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// envKeys ... func
+// the Select keys of the segments, matching the keys of me case-insensitively
+// (new keys are lower case) and numbers on arrays as indexes.
+func (me *JSONElement) envKeys(segs []string) ([]interface{}, error) {
+
+	keys := make([]interface{}, len(segs))
+	raw := me.Raw()
+
+	for i, seg := range segs {
+
+		if seg == "" {
+			return nil, fmt.Errorf("Empty Segment")
+		}
+
+		var err error
+		if raw, err = resolveSpill(raw); err != nil {
+			return nil, fmt.Errorf("%s: %w", seg, err)
+		}
+
+		switch typed := raw.(type) {
+		case []interface{}, *[]interface{}:
+			pos, err := strconv.Atoi(seg)
+			if err != nil || pos < 0 {
+				return nil, fmt.Errorf("%s: Bad Index", seg)
+			}
+			keys[i] = pos
+			raw = nil
+			if arr := asSlice(typed); pos < len(arr) {
+				raw = arr[pos]
+			}
+
+		case map[string]interface{}:
+			keys[i] = strings.ToLower(seg)
+			raw = nil
+
+			for _, k := range mapKeys(typed, LexicalLess) {
+				if strings.EqualFold(k, seg) {
+					keys[i], raw = k, typed[k]
+					break
+				}
+			}
+
+		default:
+			keys[i] = strings.ToLower(seg)
+			raw = nil
+		}
+	}
+
+	return keys, nil
+}
+
+// envValue ... func
+// str converted to the kind of the current value, a JSON literal or text when
+// there is none.
+func envValue(cur interface{}, str string) (interface{}, error) {
+
+	switch kind := kindOf(cur); kind {
+	case KindNull:
+		var obj interface{}
+		if err := json.Unmarshal([]byte(str), &obj); err == nil {
+			return obj, nil
+		}
+
+		return str, nil
+
+	case KindMap, KindArray:
+		var obj interface{}
+		if err := json.Unmarshal([]byte(str), &obj); err != nil || kindOf(obj) != kind {
+			return nil, fmt.Errorf("Not JSON %s: %q", kind, str)
+		}
+
+		return obj, nil
+
+	default:
+		val, ok := coerceValue(str, kind)
+		if !ok {
+			return nil, fmt.Errorf("Not %s: %q", kind, str)
+		}
+
+		return val, nil
+	}
+}
+
+func (me *JSONElement) applyEnv(environ []string, prefix, sep string) error {
+
+	sort.Strings(environ)
+
+	for _, kv := range environ {
+
+		eq := strings.IndexByte(kv, '=')
+		if eq < 0 || !strings.HasPrefix(kv[:eq], prefix) || eq == len(prefix) {
+			continue
+		}
+
+		name, str := kv[:eq], kv[eq+1:]
+
+		keys, err := me.envKeys(strings.Split(name[len(prefix):], sep))
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		cur, _ := me.SelectE(keys...)
+
+		val, err := envValue(cur.Raw(), str)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		if err := me.PutPath(val, keys...); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyEnvOverrides ... func
+// sets the environment variables named prefix + path onto me, the path being
+// the keys joined by sep: with ("APP_", "__") APP_SERVER__PORT=8080 sets
+// server/port (keys match case-insensitively, numbers index arrays). The text is
+// converted to the kind of the existing value, new values are JSON literals or
+// strings. Missing maps and arrays are created as with PutPath. Variables are
+// applied in name order, those before a failing one stay applied.
+func (me *JSONElement) ApplyEnvOverrides(prefix, sep string) error {
+
+	if sep == "" {
+		return me.fail("ApplyEnvOverrides", ErrorBadArgument, "empty sep")
+	}
+
+	if me.isReadonly() {
+		return me.fail("ApplyEnvOverrides", ErrorReadonly, "")
+	}
+
+	if err := me.applyEnv(os.Environ(), prefix, sep); err != nil {
+		return me.Errorf("ApplyEnvOverrides: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package dynajson
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyEnv(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"server": {"port": 80, "tls": false, "name": "x"}, "hosts": ["a", "b"], "tags": [], "logLevel": "info"}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	err = root.applyEnv([]string{
+		"APP_SERVER__PORT=8080",
+		"APP_SERVER__TLS=yes",
+		"APP_SERVER__NAME=123",
+		"APP_HOSTS__1=c",
+		"APP_TAGS=[\"t\"]",
+		"APP_LOGLEVEL=debug",
+		"APP_NEW__LIMIT=10",
+		"APP_NEW__TEXT=hello",
+		"APP_=ignored",
+		"OTHER_X=1",
+	}, "APP_", "__")
+	assert.Nil(err)
+
+	assert.Equal(`{"hosts": ["a", "c"], "logLevel": "debug", "new": {"limit": 10, "text": "hello"}, `+
+		`"server": {"name": "123", "port": 8080, "tls": true}, "tags": ["t"]}`, root.String())
+
+	for _, bad := range []string{"APP_SERVER__PORT=x", "APP_TAGS=1", "APP_HOSTS__X=1", "APP_SERVER____A=1"} {
+		err = root.applyEnv([]string{bad}, "APP_", "__")
+		assert.NotNil(err, bad)
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+
+	assert := assert.New(t)
+
+	os.Setenv("DYNAJSON_TEST_A_B", "2")
+	defer os.Unsetenv("DYNAJSON_TEST_A_B")
+
+	root, err := NewByString(`{"a": {"b": 1}}`)
+	assert.Nil(err)
+
+	assert.Nil(root.ApplyEnvOverrides("DYNAJSON_TEST_", "_"))
+	assert.Equal(2, root.Select("a", "b").AsInt())
+
+	assert.True(errors.Is(root.ApplyEnvOverrides("X", ""), ErrBadArgument))
+
+	root.Readonly = true
+	assert.True(errors.Is(root.ApplyEnvOverrides("DYNAJSON_TEST_", "_"), ErrReadonly))
+}
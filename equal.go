@@ -0,0 +1,57 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// numberText ... func
+// the text a number is written as by Marshal.
+func numberText(raw interface{}) string {
+
+	switch typed := raw.(type) {
+	case int:
+		return strconv.Itoa(typed)
+	case float64:
+		return fmt.Sprintf("%v", typed)
+	case json.Number:
+		return string(typed)
+	}
+
+	return ""
+}
+
+// Equals ... func
+// deep equality of the values, map key order does not matter but numbers must be
+// written alike: 1 and 1.0 read with UseNumber are different. A spilled value
+// that can not be read is unequal, the error goes to Warn.
+func (me *JSONElement) Equals(other *JSONElement) bool {
+
+	if other == nil {
+		return false
+	}
+
+	eq, err := equalRawBy(me.Raw(), other.Raw(), false)
+	if err != nil {
+		me.Warn("Equals: %v", err)
+	}
+
+	return eq
+}
+
+// EqualsCanonical ... func
+// same as Equals, numbers are compared by value (1, 1.0 and 1e0 are equal).
+func (me *JSONElement) EqualsCanonical(other *JSONElement) bool {
+
+	if other == nil {
+		return false
+	}
+
+	eq, err := equalRawBy(me.Raw(), other.Raw(), true)
+	if err != nil {
+		me.Warn("EqualsCanonical: %v", err)
+	}
+
+	return eq
+}
@@ -0,0 +1,49 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEquals(t *testing.T) {
+
+	assert := assert.New(t)
+
+	a, err := NewByString(`{"a": [1, {"x": null, "y": "s"}], "b": true, "c": 2.5}`)
+	assert.Nil(err)
+
+	b, err := NewByString(`{"c": 2.5, "b": true, "a": [1, {"y": "s", "x": null}]}`)
+	assert.Nil(err)
+
+	assert.True(a.Equals(b))
+	assert.True(a.EqualsCanonical(b))
+	assert.True(a.Select("a").Equals(b.Select("a")))
+
+	// an editable array equals a parsed one
+	c := NewAsMap()
+	c.Put("a", 1, map[string]interface{}{"x": nil, "y": "s"})
+	c.Put("b", true)
+	c.Put("c", 2.5)
+	assert.True(a.Equals(c))
+
+	c.Put("b", false)
+	assert.False(a.Equals(c))
+	assert.False(a.Equals(nil))
+
+	// number representation
+	n1, err := NewByBytesUseNumber([]byte(`[1, 100]`))
+	assert.Nil(err)
+
+	n2, err := NewByBytesUseNumber([]byte(`[1.0, 1e2]`))
+	assert.Nil(err)
+
+	assert.False(n1.Equals(n2))
+	assert.True(n1.EqualsCanonical(n2))
+
+	// int and float64 holding 1 are both written as 1
+	assert.True(New(1).Equals(New(1.0)))
+	assert.True(n1.Select(0).Equals(New(1)))
+	assert.False(New(1).Equals(New("1")))
+	assert.False(New(nil).Equals(New(map[string]interface{}{})))
+}
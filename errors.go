@@ -0,0 +1,161 @@
+package dynajson
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind ... type
+// the class of failure carried by *Error.
+type ErrorKind int
+
+const (
+	// ErrorOther ... none of the following, see Err
+	ErrorOther ErrorKind = iota
+	// ErrorNilObject ... the element is null
+	ErrorNilObject
+	// ErrorReadonly ... Readonly, frozen or Config().Readonly
+	ErrorReadonly
+	// ErrorNotMap ... the element is not a map
+	ErrorNotMap
+	// ErrorNotArray ... the element is not an (editable) array
+	ErrorNotArray
+	// ErrorOverflow ... the index is out of range
+	ErrorOverflow
+	// ErrorNotFound ... the key or index does not exist
+	ErrorNotFound
+	// ErrorTypeMismatch ... the value is of another kind
+	ErrorTypeMismatch
+	// ErrorBadArgument ... the argument is of an unsupported type
+	ErrorBadArgument
+	// ErrorKeyExists ... the key to be created exists
+	ErrorKeyExists
+)
+
+// ErrNilObject ... var
+var ErrNilObject = errors.New("nil object")
+
+// ErrReadonly ... var
+var ErrReadonly = errors.New("readonly")
+
+// ErrNotMap ... var
+var ErrNotMap = errors.New("not map")
+
+// ErrNotArray ... var
+var ErrNotArray = errors.New("not array")
+
+// ErrOverflow ... var
+var ErrOverflow = errors.New("overflow")
+
+// ErrNotFound ... var
+// the key or index of an element does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrTypeMismatch ... var
+// the value of an element is of another kind (null included).
+var ErrTypeMismatch = errors.New("type mismatch")
+
+// ErrBadArgument ... var
+var ErrBadArgument = errors.New("bad argument")
+
+// ErrKeyExists ... var
+var ErrKeyExists = errors.New("key exists")
+
+var errorKindSentinels = []error{
+	nil, ErrNilObject, ErrReadonly, ErrNotMap, ErrNotArray, ErrOverflow,
+	ErrNotFound, ErrTypeMismatch, ErrBadArgument, ErrKeyExists,
+}
+
+// Sentinel ... func
+// the Err* value matching the kind, nil for ErrorOther.
+func (me ErrorKind) Sentinel() error {
+
+	if me <= ErrorOther || int(me) >= len(errorKindSentinels) {
+		return nil
+	}
+
+	return errorKindSentinels[me]
+}
+
+func (me ErrorKind) String() string {
+
+	if err := me.Sentinel(); err != nil {
+		return err.Error()
+	}
+
+	return "other"
+}
+
+// Error ... struct
+// errors.Is(err, ErrReadonly) etc. match on Kind, errors.As gives Op and Path.
+type Error struct {
+	Op   string
+	Path string
+	Kind ErrorKind
+	Err  error
+}
+
+func (me *Error) Error() string {
+
+	parts := []string{}
+
+	if me.Op != "" {
+		parts = append(parts, me.Op)
+	}
+
+	if me.Path != "" {
+		parts = append(parts, me.Path)
+	}
+
+	if me.Kind != ErrorOther {
+		parts = append(parts, me.Kind.String())
+	}
+
+	if me.Err != nil {
+		parts = append(parts, me.Err.Error())
+	}
+
+	return strings.Join(parts, ": ")
+}
+
+// Unwrap ... func
+func (me *Error) Unwrap() error {
+	return me.Err
+}
+
+// Is ... func
+func (me *Error) Is(target error) bool {
+
+	sentinel := me.Kind.Sentinel()
+	return sentinel != nil && sentinel == target
+}
+
+// newError ... func
+// an *Error for me, the path of the root is "/".
+func (me *JSONElement) newError(op string, kind ErrorKind, format string, a ...interface{}) *Error {
+
+	path := me.PathString()
+	if path == "" {
+		path = "/"
+	}
+
+	ret := &Error{Op: op, Path: path, Kind: kind}
+
+	if format != "" {
+		ret.Err = fmt.Errorf(format, a...)
+	}
+
+	return ret
+}
+
+// fail ... func
+// Errorf returning an *Error, to be called directly from the exported method
+// so that Fatal reports the caller of it.
+func (me *JSONElement) fail(op string, kind ErrorKind, format string, a ...interface{}) error {
+
+	err := me.newError(op, kind, format, a...)
+	me.Fatal(err.Error())
+
+	return err
+}
@@ -0,0 +1,72 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [1, 2], "s": "x"}`)
+	assert.Nil(err)
+
+	err = root.Select("a").Put("k", 1)
+	assert.True(errors.Is(err, ErrNotMap))
+	assert.False(errors.Is(err, ErrNotArray))
+
+	var de *Error
+	assert.True(errors.As(err, &de))
+	assert.Equal("Put", de.Op)
+	assert.Equal("/a", de.Path)
+	assert.Equal(ErrorNotMap, de.Kind)
+	assert.Equal("Put: /a: not map: key=[k]: []interface {}", err.Error())
+
+	err = root.Select("s").Append(1)
+	assert.True(errors.Is(err, ErrNotArray))
+
+	err = root.InsertByPos(0, 1)
+	assert.True(errors.Is(err, ErrNotArray))
+	assert.True(errors.As(err, &de))
+	assert.Equal("/", de.Path)
+
+	err = root.Select("a").InsertByPos(9, 1)
+	assert.True(errors.Is(err, ErrOverflow))
+
+	err = root.Select("x").Put("k", 1)
+	assert.True(errors.Is(err, ErrNilObject))
+
+	err = root.Delete(1.5)
+	assert.True(errors.Is(err, ErrBadArgument))
+
+	root.Readonly = true
+	err = root.Put("k", 1)
+	assert.True(errors.Is(err, ErrReadonly))
+	assert.Equal("Put: /: readonly: key=[k]", err.Error())
+
+	// wrapped by another operation
+	_, err = root.PutEmptyMap("m")
+	assert.True(errors.Is(err, ErrReadonly))
+
+	_, err = root.Select("x").GetString()
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.True(errors.As(err, &de))
+	assert.Equal(ErrorNotFound, de.Kind)
+}
+
+func TestErrorWrapsCause(t *testing.T) {
+
+	assert := assert.New(t)
+
+	cause := errors.New("cause")
+	err := &Error{Op: "Op", Path: "/p", Err: cause}
+
+	assert.True(errors.Is(err, cause))
+	assert.False(errors.Is(err, ErrNotFound))
+	assert.Equal("Op: /p: cause", err.Error())
+	assert.Equal("other", ErrorOther.String())
+	assert.Nil(ErrorOther.Sentinel())
+}
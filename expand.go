@@ -0,0 +1,239 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// MissingVarPolicy ... type
+// what Expand does with a placeholder whose variable is not defined.
+type MissingVarPolicy int
+
+const (
+	// MissingVarKeep ... leave ${name} as it is
+	MissingVarKeep MissingVarPolicy = iota
+	// MissingVarEmpty ... "" (null when it is the whole string)
+	MissingVarEmpty
+	// MissingVarError ... fail, the tree is left unchanged
+	MissingVarError
+)
+
+// ExpandOptions ... struct
+type ExpandOptions struct {
+	Missing MissingVarPolicy
+	// Lookup is asked for the variables not in vars, e.g. a wrapper of os.LookupEnv.
+	Lookup func(name string) (interface{}, bool)
+}
+
+// placeholderSyntax ... struct
+// how the placeholders of a template are written, the fields are submatch
+// indexes (escape is 0 when there is no escaped form).
+type placeholderSyntax struct {
+	pattern *regexp.Regexp
+	escape  int
+	name    int
+	def     int
+	// missing is the error format of a placeholder without value
+	missing string
+}
+
+// $${...} is an escaped placeholder, ${name:-default} has a default
+var expandSyntax = &placeholderSyntax{
+	pattern: regexp.MustCompile(`\$(\$)?\{([A-Za-z_][A-Za-z0-9_.\-]*)(:-([^}]*))?\}`),
+	escape:  1,
+	name:    2,
+	def:     4,
+	missing: "${%s}",
+}
+
+// expander ... struct
+// the template engine of Expand and Render.
+type expander struct {
+	syntax *placeholderSyntax
+	vars   map[string]interface{}
+	opts   ExpandOptions
+	err    error
+}
+
+// submatch ... func
+// the text of group i of the match m in str, ok is false when it did not take part.
+func submatch(str string, m []int, i int) (string, bool) {
+
+	if i <= 0 || m[2*i] < 0 {
+		return "", false
+	}
+
+	return str[m[2*i]:m[2*i+1]], true
+}
+
+// lookup ... func
+// the value of name, else the default (a JSON literal or plain text).
+func (me *expander) lookup(name, def string, hasDef bool) (interface{}, bool) {
+
+	if v, ok := me.vars[name]; ok {
+		return elm2Raw(v), true
+	}
+
+	if me.opts.Lookup != nil {
+		if v, ok := me.opts.Lookup(name); ok {
+			return elm2Raw(v), true
+		}
+	}
+
+	if hasDef {
+		var obj interface{}
+		if err := json.Unmarshal([]byte(def), &obj); err == nil {
+			return obj, true
+		}
+
+		return def, true
+	}
+
+	if me.opts.Missing == MissingVarError && me.err == nil {
+		me.err = fmt.Errorf(me.syntax.missing, name)
+	}
+
+	return nil, false
+}
+
+// placeholder ... func
+// the value of the placeholder matched by m in str.
+func (me *expander) placeholder(str string, m []int) (interface{}, bool) {
+
+	name, _ := submatch(str, m, me.syntax.name)
+	def, hasDef := submatch(str, m, me.syntax.def)
+
+	return me.lookup(name, def, hasDef)
+}
+
+func (me *expander) expand(str string) interface{} {
+
+	pattern := me.syntax.pattern
+
+	// a whole-string placeholder keeps the type of the value
+	if m := pattern.FindStringSubmatchIndex(str); m != nil && m[0] == 0 && m[1] == len(str) {
+
+		if _, escaped := submatch(str, m, me.syntax.escape); !escaped {
+
+			val, ok := me.placeholder(str, m)
+			if !ok && me.opts.Missing == MissingVarKeep {
+				return str
+			}
+
+			return val
+		}
+	}
+
+	return pattern.ReplaceAllStringFunc(str, func(match string) string {
+
+		m := pattern.FindStringSubmatchIndex(match)
+
+		if _, escaped := submatch(match, m, me.syntax.escape); escaped {
+			return match[1:]
+		}
+
+		val, ok := me.placeholder(match, m)
+		if !ok {
+			if me.opts.Missing == MissingVarKeep {
+				return match
+			}
+			return ""
+		}
+
+		if typed, ok := val.(string); ok {
+			return typed
+		}
+
+		return New(val).String()
+	})
+}
+
+// expandCopy ... func
+// an expanded copy of me with the key order of me, me.err tells of missing values.
+func (me *JSONElement) expandCopy(x *expander) (interface{}, error) {
+
+	var expand func(raw interface{}) error
+	expand = func(raw interface{}) error {
+
+		entries, err := walkEntries(nil, raw)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range entries {
+
+			if str, ok := v.val.(string); ok {
+				setContainerRaw(raw, v.key, x.expand(str))
+				continue
+			}
+
+			if err := expand(v.val); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	cp, err := editableCopy(me.raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if me.order != nil {
+		if err := me.order.copyTo(me.order, me.raw, cp); err != nil {
+			me.order.forget(cp)
+			return nil, err
+		}
+	}
+
+	if str, ok := cp.(string); ok {
+		return x.expand(str), nil
+	}
+
+	if err := expand(cp); err != nil {
+		me.order.forget(cp)
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// Expand ... func
+// substitutes ${name} placeholders in string values with vars: a placeholder
+// that is the whole string is replaced by the value itself (number, map...),
+// inside a text by its string form. ${name:-default} supplies a fallback and
+// $${name} is written as ${name}. Map keys are not expanded.
+func (me *JSONElement) Expand(vars map[string]interface{}, opts ExpandOptions) error {
+
+	if me.IsNil() {
+		return me.fail("Expand", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return me.fail("Expand", ErrorReadonly, "")
+	}
+
+	x := &expander{syntax: expandSyntax, vars: vars, opts: opts}
+
+	cp, err := me.expandCopy(x)
+	if err != nil {
+		return me.Errorf("Expand: %w", err)
+	}
+
+	if x.err != nil {
+		me.order.forget(cp)
+		return me.fail("Expand", ErrorNotFound, "%v", x.err)
+	}
+
+	old := me.raw
+	if err := me.replaceRaw(cp); err != nil {
+		me.order.forget(cp)
+		return me.Errorf("Expand: %w", err)
+	}
+
+	me.order.forget(old)
+
+	return nil
+}
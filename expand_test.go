@@ -0,0 +1,67 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpand(t *testing.T) {
+
+	assert := assert.New(t)
+
+	tmpl := `{
+		"host": "${env}.example.com",
+		"port": "${port}",
+		"tls": "${tls}",
+		"db": {"hosts": "${db_hosts}", "name": "app_${env}"},
+		"list": ["${env}", "$${env}", "${timeout:-30}", "${region:-us-east-1}"],
+		"other": "${missing}-x"
+	}`
+
+	vars := map[string]interface{}{
+		"env":      "prod",
+		"port":     8443,
+		"tls":      true,
+		"db_hosts": []interface{}{"a", "b"},
+	}
+
+	root, err := NewByString(tmpl)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	assert.Nil(root.Expand(vars, ExpandOptions{}))
+	assert.Equal(`{"db": {"hosts": ["a", "b"], "name": "app_prod"}, "host": "prod.example.com", `+
+		`"list": ["prod", "${env}", 30, "us-east-1"], "other": "${missing}-x", "port": 8443, "tls": true}`, root.String())
+
+	root, _ = NewByString(`{"a": "${missing}", "b": "x${missing}y"}`)
+	root.KeyLess = LexicalLess
+	assert.Nil(root.Expand(vars, ExpandOptions{Missing: MissingVarEmpty}))
+	assert.Equal(`{"a": null, "b": "xy"}`, root.String())
+
+	root, _ = NewByString(`{"a": "${env}", "b": ["${missing}"]}`)
+	err = root.Expand(vars, ExpandOptions{Missing: MissingVarError})
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.Contains(err.Error(), "${missing}")
+	assert.Equal("${env}", root.Select("a").AsString())
+
+	// Lookup after vars
+	root, _ = NewByString(`["${env}", "${HOME}"]`)
+	lookup := func(name string) (interface{}, bool) {
+		if name == "HOME" {
+			return "/home/u", true
+		}
+		return nil, false
+	}
+	assert.Nil(root.Expand(vars, ExpandOptions{Lookup: lookup}))
+	assert.Equal(`["prod", "/home/u"]`, root.String())
+
+	// a string root
+	root = New("${port}")
+	assert.Nil(root.Expand(vars, ExpandOptions{}))
+	assert.Equal(8443, root.AsInt())
+
+	root.Readonly = true
+	assert.True(errors.Is(root.Expand(vars, ExpandOptions{}), ErrReadonly))
+}
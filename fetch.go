@@ -0,0 +1,257 @@
+package dynajson
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FetchResult ... struct
+type FetchResult struct {
+	Body io.ReadCloser
+	// Size is -1 when unknown.
+	Size int64
+	// ContentType "" means guess from the extension of the path.
+	ContentType string
+}
+
+// Fetcher ... interface
+// loads the raw document addressed by argPath for NewByPath and friends.
+type Fetcher interface {
+	Fetch(ctx context.Context, argPath string) (*FetchResult, error)
+}
+
+// FetcherFunc ... type
+type FetcherFunc func(ctx context.Context, argPath string) (*FetchResult, error)
+
+// Fetch ... func
+func (me FetcherFunc) Fetch(ctx context.Context, argPath string) (*FetchResult, error) {
+	return me(ctx, argPath)
+}
+
+// PathFetcher ... struct
+// reads local files and http(s) URLs, this is the initial default.
+type PathFetcher struct {
+	// Client nil means http.DefaultClient.
+	Client *http.Client
+	// Accept "" means AcceptHeader().
+	Accept string
+	// Header is added to every request (Authorization, User-Agent, ...).
+	Header http.Header
+	// Timeout bounds a whole request including the body, 0 means the one of Client.
+	Timeout time.Duration
+	// Retries is the number of retries on network errors, 429 and 5xx responses,
+	// waiting RetryWait, then twice as long each time (Retry-After wins).
+	Retries   int
+	RetryWait time.Duration
+	// Method "" means GET, Body is sent again on every retry.
+	Method string
+	Body   []byte
+}
+
+type drainCloser struct {
+	io.ReadCloser
+}
+
+func (me drainCloser) Close() error {
+
+	io.Copy(ioutil.Discard, me.ReadCloser)
+
+	return me.ReadCloser.Close()
+}
+
+// Fetch ... func
+func (me *PathFetcher) Fetch(ctx context.Context, argPath string) (*FetchResult, error) {
+
+	if strings.HasPrefix(argPath, "http://") || strings.HasPrefix(argPath, "https://") {
+		return me.fetchHTTP(ctx, argPath)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("ReadFile: %s: %w", argPath, err)
+	}
+
+	f, err := os.Open(argPath)
+	if err != nil {
+		return nil, fmt.Errorf("ReadFile: %s: %w", argPath, err)
+	}
+
+	size := int64(-1)
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+
+	return &FetchResult{
+		Body: f,
+		Size: size,
+	}, nil
+}
+
+func (me *PathFetcher) request(ctx context.Context, client *http.Client, argPath string) (*http.Response, error) {
+
+	method := me.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if me.Body != nil {
+		body = bytes.NewReader(me.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, argPath, body)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewRequest: %s: %w", argPath, err)
+	}
+
+	for k, v := range me.Header {
+		req.Header[k] = append([]string{}, v...)
+	}
+
+	if req.Header.Get("Accept") == "" {
+		accept := me.Accept
+		if accept == "" {
+			accept = AcceptHeader()
+		}
+		req.Header.Set("Accept", accept)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http.Client.Do: %s: %w", argPath, err)
+	}
+
+	return resp, nil
+}
+
+func retryable(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+func (me *PathFetcher) fetchHTTP(ctx context.Context, argPath string) (*FetchResult, error) {
+
+	// https://golang.hateblo.jp/entry/golang-http-request
+	// https://qiita.com/ono_matope/items/60e96c01b43c64ed1d18
+	// https://qiita.com/stk0724/items/dc400dccd29a4b3d6471
+
+	client := me.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if me.Timeout > 0 {
+		c := *client
+		c.Timeout = me.Timeout
+		client = &c
+	}
+
+	wait := me.RetryWait
+
+	for attempt := 0; ; attempt++ {
+
+		resp, err := me.request(ctx, client, argPath)
+
+		if attempt < me.Retries && ctx.Err() == nil && (err != nil || retryable(resp)) {
+
+			delay := wait
+			if resp != nil {
+				if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec >= 0 {
+					delay = time.Duration(sec) * time.Second
+				}
+				drainCloser{resp.Body}.Close()
+			}
+
+			t := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return nil, fmt.Errorf("Retry: %s: %w", argPath, ctx.Err())
+			case <-t.C:
+			}
+
+			wait *= 2
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// 201 Created, 202 Accepted ... answer POST and PUT
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			drainCloser{resp.Body}.Close()
+			return nil, fmt.Errorf("StatusCode Not 2xx: %s: %d", argPath, resp.StatusCode)
+		}
+
+		return &FetchResult{
+			Body:        drainCloser{resp.Body},
+			Size:        resp.ContentLength,
+			ContentType: resp.Header.Get("Content-Type"),
+		}, nil
+	}
+}
+
+// MapFetcher ... type
+// serves canned documents keyed by path or URL, e.g. for tests.
+type MapFetcher map[string][]byte
+
+// Fetch ... func
+func (me MapFetcher) Fetch(ctx context.Context, argPath string) (*FetchResult, error) {
+
+	data, ok := me[argPath]
+	if !ok {
+		return nil, fmt.Errorf("MapFetcher: %s: %w", argPath, os.ErrNotExist)
+	}
+
+	return &FetchResult{
+		Body: ioutil.NopCloser(bytes.NewReader(data)),
+		Size: int64(len(data)),
+	}, nil
+}
+
+var defaultFetcher = struct {
+	sync.RWMutex
+	f Fetcher
+}{
+	f: &PathFetcher{},
+}
+
+// GetDefaultFetcher ... func
+func GetDefaultFetcher() Fetcher {
+
+	defaultFetcher.RLock()
+	defer defaultFetcher.RUnlock()
+
+	return defaultFetcher.f
+}
+
+// SetDefaultFetcher ... func
+// replaces the package wide fetcher and returns the previous one, nil restores PathFetcher.
+func SetDefaultFetcher(f Fetcher) Fetcher {
+
+	if f == nil {
+		f = &PathFetcher{}
+	}
+
+	defaultFetcher.Lock()
+	defer defaultFetcher.Unlock()
+
+	prev := defaultFetcher.f
+	defaultFetcher.f = f
+
+	return prev
+}
+
+// NewByPathWithFetcher ... func
+func NewByPathWithFetcher(argPath string, f Fetcher) (*JSONElement, error) {
+
+	return loadByPath(context.Background(), argPath, loadConfig{fetcher: f})
+}
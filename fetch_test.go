@@ -0,0 +1,35 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetcher(t *testing.T) {
+
+	assert := assert.New(t)
+
+	canned := MapFetcher{
+		"https://example.com/a.json": []byte(`{"name": "a"}`),
+	}
+
+	root, err := NewByPathWithFetcher("https://example.com/a.json", canned)
+	assert.Nil(err)
+	assert.Equal("a", root.Select("name").AsString())
+
+	_, err = NewByPathWithFetcher("https://example.com/b.json", canned)
+	assert.True(errors.Is(err, os.ErrNotExist))
+
+	prev := SetDefaultFetcher(FetcherFunc(func(ctx context.Context, argPath string) (*FetchResult, error) {
+		return canned.Fetch(ctx, "https://example.com/a.json")
+	}))
+	defer SetDefaultFetcher(prev)
+
+	root, err = NewByPath("anything")
+	assert.Nil(err)
+	assert.Equal("a", root.Select("name").AsString())
+}
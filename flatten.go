@@ -0,0 +1,207 @@
+package dynajson
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Flatten ... func
+// the leaves of me by their path, map keys joined with sep and indexes as "[i]",
+// e.g. "a.b[2].c". Empty maps and arrays are leaves too, so NewFromFlatMap gives
+// the tree back. sep, '[', ']' and backslashes in map keys get a backslash before them.
+func (me *JSONElement) Flatten(sep string) map[string]interface{} {
+
+	flat := map[string]interface{}{}
+
+	var flatten func(prefix string, raw interface{})
+	flatten = func(prefix string, raw interface{}) {
+
+		raw, err := resolveSpill(raw)
+		if err != nil {
+			me.Warn("Flatten: %s: %v", prefix, err)
+			return
+		}
+
+		switch typed := raw.(type) {
+		case map[string]interface{}:
+			if len(typed) > 0 {
+				for k, v := range typed {
+					if prefix == "" {
+						flatten(flatEscape(k, sep), v)
+					} else {
+						flatten(prefix+sep+flatEscape(k, sep), v)
+					}
+				}
+				return
+			}
+		case []interface{}, *[]interface{}:
+			if arr := asSlice(typed); len(arr) > 0 {
+				for i, v := range arr {
+					flatten(prefix+"["+strconv.Itoa(i)+"]", v)
+				}
+				return
+			}
+		}
+
+		flat[prefix] = copyRaw(raw)
+	}
+
+	flatten("", me.Raw())
+
+	return flat
+}
+
+// flatEscape ... func
+// puts a backslash before sep, '[', ']' and backslashes in a map key
+func flatEscape(key, sep string) string {
+
+	if !strings.Contains(key, sep) && !strings.ContainsAny(key, `[]\`) {
+		return key
+	}
+
+	var buf strings.Builder
+
+	for i := 0; i < len(key); {
+
+		if strings.HasPrefix(key[i:], sep) {
+			buf.WriteString(`\` + sep)
+			i += len(sep)
+			continue
+		}
+
+		switch key[i] {
+		case '[', ']', '\\':
+			buf.WriteByte('\\')
+		}
+
+		buf.WriteByte(key[i])
+		i++
+	}
+
+	return buf.String()
+}
+
+// flatKeyTokens ... func
+// "a.b[2].c" -> ["a", "b", 2, "c"], `a\.b` -> ["a.b"]
+func flatKeyTokens(key, sep string) ([]interface{}, error) {
+
+	tokens := []interface{}{}
+
+	var name strings.Builder
+	indexed := false
+
+	flush := func() {
+
+		if !indexed {
+			tokens = append(tokens, name.String())
+		}
+
+		name.Reset()
+		indexed = false
+	}
+
+	for i := 0; i < len(key); {
+
+		switch {
+		case strings.HasPrefix(key[i:], sep):
+			flush()
+			i += len(sep)
+
+		case key[i] == '[':
+			end := strings.IndexByte(key[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("Bad Key: %q", key)
+			}
+
+			pos, err := strconv.Atoi(key[i+1 : i+end])
+			if err != nil || pos < 0 {
+				return nil, fmt.Errorf("Bad Index: %q", key)
+			}
+
+			if !indexed && name.Len() > 0 {
+				tokens = append(tokens, name.String())
+			}
+
+			tokens = append(tokens, pos)
+			indexed = true
+			i += end + 1
+
+		case indexed:
+			return nil, fmt.Errorf("Bad Key: %q", key)
+
+		case key[i] == '\\':
+			i++
+			if i >= len(key) {
+				return nil, fmt.Errorf("Bad Escape: %q", key)
+			}
+
+			if strings.HasPrefix(key[i:], sep) {
+				name.WriteString(sep)
+				i += len(sep)
+			} else {
+				name.WriteByte(key[i])
+				i++
+			}
+
+		default:
+			name.WriteByte(key[i])
+			i++
+		}
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+// NewFromFlatMap ... func
+// builds the tree Flatten(sep) was made of; when every key starts with an index
+// the root is an array.
+func NewFromFlatMap(flat map[string]interface{}, sep string) (*JSONElement, error) {
+
+	if sep == "" {
+		return nil, fmt.Errorf("NewFromFlatMap: Empty Separator")
+	}
+
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return NaturalLess(keys[i], keys[j])
+	})
+
+	paths := make([][]interface{}, len(keys))
+	isArray := len(keys) > 0
+
+	for i, k := range keys {
+
+		tokens, err := flatKeyTokens(k, sep)
+		if err != nil {
+			return nil, fmt.Errorf("NewFromFlatMap: %w", err)
+		}
+
+		if _, ok := tokens[0].(int); !ok {
+			isArray = false
+		}
+
+		paths[i] = tokens
+	}
+
+	root := NewAsMap()
+	if isArray {
+		root = NewAsArray()
+	}
+
+	for i, k := range keys {
+
+		err := root.PutPath(copyRaw(elm2Raw(flat[k])), paths[i]...)
+		if err != nil {
+			return nil, fmt.Errorf("NewFromFlatMap: %s: %w", k, err)
+		}
+	}
+
+	return root, nil
+}
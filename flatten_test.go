@@ -0,0 +1,77 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [1, {"c": "x"}], "e": {}}, "f": null, "g": []}`)
+	assert.Nil(err)
+
+	flat := root.Flatten(".")
+	assert.Equal(map[string]interface{}{
+		"a.b[0]":   1.0,
+		"a.b[1].c": "x",
+		"a.e":      map[string]interface{}{},
+		"f":        nil,
+		"g":        []interface{}{},
+	}, flat)
+
+	back, err := NewFromFlatMap(flat, ".")
+	assert.Nil(err)
+	assert.True(root.Equals(back))
+
+	assert.Equal(map[string]interface{}{"A__B": true}, New(map[string]interface{}{"A": map[string]interface{}{"B": true}}).Flatten("__"))
+
+	// a root array, indexes out of order
+	arr, err := NewFromFlatMap(map[string]interface{}{"[10]": "k", "[2].x": 1, "[0][1]": 2}, ".")
+	assert.Nil(err)
+	assert.Equal(11, arr.Count())
+	assert.Equal(`[null, 2]`, arr.Select(0).String())
+	assert.Equal(1, arr.Select(2, "x").AsInt())
+	assert.Equal("k", arr.Select(10).AsString())
+	assert.Equal(arr.Flatten("."), map[string]interface{}{
+		"[0][0]": nil, "[0][1]": 2, "[1]": nil, "[2].x": 1, "[3]": nil, "[4]": nil,
+		"[5]": nil, "[6]": nil, "[7]": nil, "[8]": nil, "[9]": nil, "[10]": "k",
+	})
+
+	_, err = NewFromFlatMap(map[string]interface{}{"a": 1, "a.b": 2}, ".")
+	assert.NotNil(err)
+
+	_, err = NewFromFlatMap(map[string]interface{}{"a[x]": 1}, ".")
+	assert.NotNil(err)
+
+	_, err = NewFromFlatMap(map[string]interface{}{"a[1": 1}, ".")
+	assert.NotNil(err)
+
+	_, err = NewFromFlatMap(map[string]interface{}{"a": 1}, "")
+	assert.NotNil(err)
+
+	// keys holding the separator, brackets or backslashes
+	odd, err := NewByString(`{"a.b": 1, "c[0]": {"d]": [2]}, "e\\": {"": 3}, "f": {"g": 4}}`)
+	assert.Nil(err)
+
+	flat = odd.Flatten(".")
+	assert.Equal(map[string]interface{}{
+		`a\.b`: 1.0, `c\[0\].d\][0]`: 2.0, `e\\.`: 3.0, "f.g": 4.0,
+	}, flat)
+
+	back, err = NewFromFlatMap(flat, ".")
+	assert.Nil(err)
+	assert.True(odd.Equals(back))
+
+	_, err = NewFromFlatMap(map[string]interface{}{`a\`: 1}, ".")
+	assert.NotNil(err)
+
+	_, err = NewFromFlatMap(map[string]interface{}{"a[0]b": 1}, ".")
+	assert.NotNil(err)
+
+	empty, err := NewFromFlatMap(map[string]interface{}{}, ".")
+	assert.Nil(err)
+	assert.Equal(`{}`, empty.String())
+}
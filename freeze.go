@@ -0,0 +1,163 @@
+package dynajson
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// frozenState ... struct
+// the containers of a document Freeze was called on, with the digest of each
+// frozen subtree to notice changes made through Raw().
+type frozenState struct {
+	mu      sync.Mutex
+	addrs   map[uintptr]interface{}
+	digests map[uintptr][sha256.Size]byte
+}
+
+func frozenDigest(raw interface{}) [sha256.Size]byte {
+
+	data, _ := New(raw).Marshal(DumpOptions{SortKeys: true})
+
+	return sha256.Sum256(data)
+}
+
+func (me *frozenState) has(raw interface{}) bool {
+
+	addr := rawAddr(raw)
+	if addr == 0 {
+		return false
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	_, ok := me.addrs[addr]
+
+	return ok
+}
+
+// isReadonly ... func
+// Readonly (of me or the Config), or inside a frozen subtree.
+func (me *JSONElement) isReadonly() bool {
+
+	if me.Readonly || me.IsFrozen() {
+		return true
+	}
+
+	conf := me.sharedConfig()
+
+	return conf != nil && conf.Readonly
+}
+
+// Freeze ... func
+// makes me and everything below it immutable for every element of the document,
+// also those selected before the call. Changes made to Raw() values behind the
+// back of the document are reported by VerifyFrozen.
+func (me *JSONElement) Freeze() {
+
+	root := me.Root()
+	if root.frozen == nil {
+		root.frozen = &frozenState{
+			addrs:   map[uintptr]interface{}{},
+			digests: map[uintptr][sha256.Size]byte{},
+		}
+	}
+
+	fs := root.frozen
+
+	raw, err := resolveSpill(me.raw)
+	if err != nil {
+		me.Warn("Freeze: %v", err)
+	}
+
+	addr := rawAddr(raw)
+	if addr == 0 {
+		// a scalar is only changed through its parent
+		me.Readonly = true
+		return
+	}
+
+	var mark func(raw interface{})
+	mark = func(raw interface{}) {
+
+		raw, err := resolveSpill(raw)
+		if err != nil {
+			me.Warn("Freeze: %v", err)
+			return
+		}
+
+		if addr := rawAddr(raw); addr != 0 {
+			fs.addrs[addr] = raw
+		}
+
+		switch typed := raw.(type) {
+		case map[string]interface{}:
+			for _, v := range typed {
+				mark(v)
+			}
+		case []interface{}, *[]interface{}:
+			for _, v := range asSlice(typed) {
+				mark(v)
+			}
+		}
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	mark(raw)
+	fs.digests[addr] = frozenDigest(raw)
+}
+
+// IsFrozen ... func
+// whether me is in a subtree Freeze was called on.
+func (me *JSONElement) IsFrozen() bool {
+
+	if me == nil {
+		return false
+	}
+
+	fs := me.Root().frozen
+	if fs == nil {
+		return false
+	}
+
+	for elm := me; elm != nil; elm = elm.parent {
+		if fs.has(elm.raw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VerifyFrozen ... func
+// reports the frozen subtrees of the document whose content changed since Freeze,
+// which can only happen by mutating Raw() values directly.
+func (me *JSONElement) VerifyFrozen() error {
+
+	fs := me.Root().frozen
+	if fs == nil {
+		return nil
+	}
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	changed := []string{}
+
+	for addr, digest := range fs.digests {
+		if frozenDigest(fs.addrs[addr]) != digest {
+			changed = append(changed, fmt.Sprintf("%T@%x", fs.addrs[addr], addr))
+		}
+	}
+
+	if len(changed) > 0 {
+		sort.Strings(changed)
+		return me.Errorf("VerifyFrozen: Modified: %v", changed)
+	}
+
+	return nil
+}
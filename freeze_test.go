@@ -0,0 +1,61 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [1, {"c": 2}]}, "d": {"e": 1}}`)
+	assert.Nil(err)
+
+	// selected before the freeze
+	early := root.Select("a", "b", 1)
+	other := root.Select("d")
+
+	assert.False(root.IsFrozen())
+
+	root.Select("a").Freeze()
+
+	assert.True(early.IsFrozen())
+	assert.True(root.Select("a", "b").IsFrozen())
+	assert.True(root.Select("a", "b", 0).IsFrozen())
+	assert.False(root.IsFrozen())
+	assert.False(other.IsFrozen())
+
+	assert.NotNil(early.Put("c", 3))
+	assert.NotNil(root.Select("a").Put("x", 1))
+	assert.NotNil(root.Select("a").DeleteByKey("b"))
+	assert.NotNil(root.Select("a", "b").InsertByPos(0, 0))
+	assert.NotNil(root.Select("a").PutPath(1, "b", 5))
+	assert.Equal(2, root.Select("a", "b", 1, "c").AsInt())
+
+	// the rest of the document stays editable
+	assert.Nil(other.Put("e", 2))
+	assert.Nil(root.Put("f", true))
+
+	assert.Nil(root.VerifyFrozen())
+
+	// direct mutation of the raw values is detected
+	root.Select("a", "b", 1).Raw().(map[string]interface{})["c"] = 9
+	assert.NotNil(root.VerifyFrozen())
+
+	// freezing the root freezes everything
+	root.Freeze()
+	assert.True(other.IsFrozen())
+	assert.NotNil(other.Put("e", 3))
+	assert.NotNil(root.Select("f").Parent().Put("f", false))
+
+	leaf, err := NewByString(`{"s": "x"}`)
+	assert.Nil(err)
+
+	s := leaf.Select("s")
+	s.Freeze()
+	assert.True(s.Readonly)
+	assert.False(leaf.IsFrozen())
+	assert.Nil(leaf.VerifyFrozen())
+}
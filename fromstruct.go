@@ -0,0 +1,49 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// editableArrays ... func
+// turns the arrays of raw into *[]interface{} in place, maps keep their identity.
+func editableArrays(raw interface{}) interface{} {
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			typed[k] = editableArrays(v)
+		}
+	case []interface{}:
+		for i, v := range typed {
+			typed[i] = editableArrays(v)
+		}
+		return &typed
+	}
+
+	return raw
+}
+
+// NewFromStruct ... func
+// converts any Go value as json.Marshal does (json tags, MarshalJSON, time.Time, ...)
+// into an editable tree. Map keys keep the field order of structs, numbers are
+// json.Number so 64-bit integers survive.
+func NewFromStruct(v interface{}) (*JSONElement, error) {
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("NewFromStruct: %w", err)
+	}
+
+	order := newKeyOrderState()
+
+	obj, err := decodeOrdered(data, order, true)
+	if err != nil {
+		return nil, fmt.Errorf("NewFromStruct: %w", err)
+	}
+
+	elm := New(editableArrays(obj))
+	elm.order = order
+
+	return elm, nil
+}
@@ -0,0 +1,64 @@
+package dynajson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fromStructItem struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price,omitempty"`
+}
+
+type fromStructOrder struct {
+	ID      int64            `json:"id"`
+	Created time.Time        `json:"created"`
+	Items   []fromStructItem `json:"items"`
+	Meta    map[string]int   `json:"meta"`
+	Note    *string          `json:"note"`
+	secret  string
+}
+
+func TestNewFromStruct(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := fromStructOrder{
+		ID:      9007199254740993,
+		Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Items:   []fromStructItem{{SKU: "a", Price: 1.5}, {SKU: "b"}},
+		Meta:    map[string]int{"z": 1, "a": 2},
+		secret:  "s",
+	}
+
+	elm, err := NewFromStruct(src)
+	assert.Nil(err)
+
+	assert.Equal([]string{"id", "created", "items", "meta", "note"}, elm.Keys())
+	assert.Equal(int64(9007199254740993), elm.Select("id").AsInt64())
+	assert.Equal("2020-01-02T03:04:05Z", elm.Select("created").AsString())
+	assert.Equal(1.5, elm.Select("items", 0, "price").AsFloat())
+	assert.Equal([]string{"sku"}, elm.Select("items", 1).Keys())
+	assert.True(elm.Select("note").IsNil())
+
+	assert.Nil(elm.Select("items").Append(map[string]interface{}{"sku": "c"}))
+	assert.Nil(elm.Select("items").DeleteByPos(0))
+	assert.Nil(elm.Select("meta").Put("m", 3))
+	assert.Nil(elm.DeleteByKey("note"))
+
+	assert.Equal(`{"id": 9007199254740993, "created": "2020-01-02T03:04:05Z", "items": [{"sku": "b"}, {"sku": "c"}], "meta": {"a": 2, "z": 1, "m": 3}}`, elm.String())
+
+	var back fromStructOrder
+	assert.Nil(elm.Unmarshal(&back))
+	assert.Equal(src.ID, back.ID)
+	assert.Equal(src.Created, back.Created)
+
+	_, err = NewFromStruct(make(chan int))
+	assert.NotNil(err)
+
+	null, err := NewFromStruct(nil)
+	assert.Nil(err)
+	assert.True(null.IsNil())
+}
@@ -0,0 +1,178 @@
+package dynajson
+
+// derive ... func
+// a new document for raw, with the settings of me.
+func (me *JSONElement) derive(raw interface{}) *JSONElement {
+
+	elm := New(raw)
+	elm.KeyLess = me.KeyLess
+	elm.WarnHandler = me.WarnHandler
+	elm.FatalHandler = me.FatalHandler
+
+	return elm
+}
+
+// copyValue ... func
+// a copy of raw for a derived document, a spilled value that can not be read goes to Warn.
+func (me *JSONElement) copyValue(op string, raw interface{}) interface{} {
+
+	cp, err := copyRawChecked(raw)
+	if err != nil {
+		me.Warn("%s: %v", op, err)
+	}
+
+	return cp
+}
+
+// arrayChildren ... func
+func (me *JSONElement) arrayChildren(op string) []*JSONElement {
+
+	raw, err := resolveSpill(me.Raw())
+	if err != nil {
+		me.Warn("%s: %v", op, err)
+		return nil
+	}
+
+	arr := asSlice(raw)
+	if arr == nil {
+		me.Warn("%s: Not Array: %T", op, raw)
+		return nil
+	}
+
+	elms := make([]*JSONElement, len(arr))
+	for i, v := range arr {
+		elms[i] = me.child(i, v)
+	}
+
+	return elms
+}
+
+// mapChildren ... func
+// keys in the order of EachMap.
+func (me *JSONElement) mapChildren(op string) ([]string, []*JSONElement) {
+
+	raw, err := resolveSpill(me.Raw())
+	if err != nil {
+		me.Warn("%s: %v", op, err)
+		return nil, nil
+	}
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		me.Warn("%s: Not Map: %T", op, raw)
+		return nil, nil
+	}
+
+	less := me.KeyLess
+	if less == nil {
+		less = LexicalLess
+	}
+
+	keys := me.order.keys(obj, less)
+
+	elms := make([]*JSONElement, len(keys))
+	for i, k := range keys {
+		elms[i] = me.child(k, obj[k])
+	}
+
+	return keys, elms
+}
+
+// Filter ... func
+// a new array of copies of the elements pred accepts, null (with a warning) when
+// me is not an array.
+func (me *JSONElement) Filter(pred func(*JSONElement) bool) *JSONElement {
+
+	elms := me.arrayChildren("Filter")
+	if elms == nil {
+		return me.derive(nil)
+	}
+
+	arr := []interface{}{}
+	for _, v := range elms {
+		if pred(v) {
+			arr = append(arr, me.copyValue("Filter", v.raw))
+		}
+	}
+
+	return me.derive(&arr)
+}
+
+// MapElements ... func
+// a new array of what fn returns for each element (values or elements, copied).
+func (me *JSONElement) MapElements(fn func(*JSONElement) interface{}) *JSONElement {
+
+	elms := me.arrayChildren("MapElements")
+	if elms == nil {
+		return me.derive(nil)
+	}
+
+	arr := make([]interface{}, len(elms))
+	for i, v := range elms {
+		arr[i] = me.copyValue("MapElements", elm2Raw(fn(v)))
+	}
+
+	return me.derive(&arr)
+}
+
+// Reduce ... func
+// folds the elements of an array into acc, starting with init.
+func (me *JSONElement) Reduce(fn func(acc interface{}, elm *JSONElement) interface{}, init interface{}) interface{} {
+
+	acc := init
+	for _, v := range me.arrayChildren("Reduce") {
+		acc = fn(acc, v)
+	}
+
+	return acc
+}
+
+// FilterMap ... func
+// the map counterpart of Filter.
+func (me *JSONElement) FilterMap(pred func(string, *JSONElement) bool) *JSONElement {
+
+	keys, elms := me.mapChildren("FilterMap")
+	if elms == nil {
+		return me.derive(nil)
+	}
+
+	obj := map[string]interface{}{}
+	for i, v := range elms {
+		if pred(keys[i], v) {
+			obj[keys[i]] = me.copyValue("FilterMap", v.raw)
+		}
+	}
+
+	return me.derive(obj)
+}
+
+// MapValues ... func
+// the map counterpart of MapElements, keys are kept.
+func (me *JSONElement) MapValues(fn func(string, *JSONElement) interface{}) *JSONElement {
+
+	keys, elms := me.mapChildren("MapValues")
+	if elms == nil {
+		return me.derive(nil)
+	}
+
+	obj := make(map[string]interface{}, len(elms))
+	for i, v := range elms {
+		obj[keys[i]] = me.copyValue("MapValues", elm2Raw(fn(keys[i], v)))
+	}
+
+	return me.derive(obj)
+}
+
+// ReduceMap ... func
+// the map counterpart of Reduce, in the order of EachMap.
+func (me *JSONElement) ReduceMap(fn func(acc interface{}, key string, elm *JSONElement) interface{}, init interface{}) interface{} {
+
+	acc := init
+
+	keys, elms := me.mapChildren("ReduceMap")
+	for i, v := range elms {
+		acc = fn(acc, keys[i], v)
+	}
+
+	return acc
+}
@@ -0,0 +1,67 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterMapReduce(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"items": [{"name": "a", "price": 3}, {"name": "b", "price": 10}, {"name": "c", "price": 7}]}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	items := root.Select("items")
+
+	cheap := items.Filter(func(elm *JSONElement) bool {
+		return elm.Select("price").AsInt() < 8
+	})
+	assert.Equal(`[{"name": "a", "price": 3}, {"name": "c", "price": 7}]`, cheap.String())
+
+	names := cheap.MapElements(func(elm *JSONElement) interface{} {
+		return elm.Select("name")
+	})
+	assert.Equal(`["a", "c"]`, names.String())
+
+	total := items.Reduce(func(acc interface{}, elm *JSONElement) interface{} {
+		return acc.(int) + elm.Select("price").AsInt()
+	}, 0)
+	assert.Equal(20, total)
+
+	// results are copies
+	assert.Nil(cheap.Select(0).Put("name", "x"))
+	assert.Nil(names.Append("d"))
+	assert.Equal("a", root.Select("items", 0, "name").AsString())
+
+	// maps
+	prices, err := NewByString(`{"a": 3, "b": 10, "c": 7}`)
+	assert.Nil(err)
+	prices.KeyLess = LexicalLess
+
+	assert.Equal(`{"b": 10}`, prices.FilterMap(func(key string, elm *JSONElement) bool {
+		return elm.AsInt() > 8
+	}).String())
+
+	assert.Equal(`{"a": 6, "b": 20, "c": 14}`, prices.MapValues(func(key string, elm *JSONElement) interface{} {
+		return elm.AsInt() * 2
+	}).String())
+
+	assert.Equal("a3b10c7", prices.ReduceMap(func(acc interface{}, key string, elm *JSONElement) interface{} {
+		return acc.(string) + key + elm.String()
+	}, ""))
+
+	// wrong kinds
+	warned := 0
+	prices.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		warned++
+	}
+
+	assert.True(prices.Filter(func(*JSONElement) bool { return true }).IsNil())
+	assert.Equal(1, prices.Reduce(func(acc interface{}, elm *JSONElement) interface{} { return 2 }, 1))
+	assert.Equal(2, warned)
+
+	assert.True(items.FilterMap(func(string, *JSONElement) bool { return true }).IsNil())
+}
@@ -0,0 +1,113 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// getError ... func
+// ErrNotFound when me was selected through a missing key or index, otherwise
+// ErrTypeMismatch. Neither goes through the Warn/Fatal handlers.
+func (me *JSONElement) getError(op, want string) error {
+
+	if me.IsNil() && (me.parent == nil || !me.parent.Has(me.key)) {
+		return me.newError(op, ErrorNotFound, "")
+	}
+
+	return me.newError(op, ErrorTypeMismatch, "%s is not %s", kindOf(me.Raw()), want)
+}
+
+// GetString ... func
+// AsString with an error instead of a warning.
+func (me *JSONElement) GetString() (string, error) {
+
+	s, ok := me.Raw().(string)
+	if !ok {
+		return "", me.getError("GetString", "string")
+	}
+
+	return s, nil
+}
+
+// GetBool ... func
+func (me *JSONElement) GetBool() (bool, error) {
+
+	b, ok := me.Raw().(bool)
+	if !ok {
+		return false, me.getError("GetBool", "bool")
+	}
+
+	return b, nil
+}
+
+// GetInt ... func
+// numbers with a fraction or out of the range of int are ErrTypeMismatch.
+func (me *JSONElement) GetInt() (int, error) {
+
+	switch v := me.Raw().(type) {
+	case int:
+		return v, nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil && int64(int(i)) == i {
+			return int(i), nil
+		}
+	case float64:
+		if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 && float64(int(v)) == v {
+			return int(v), nil
+		}
+	}
+
+	return 0, me.getError("GetInt", "int")
+}
+
+// GetFloat ... func
+func (me *JSONElement) GetFloat() (float64, error) {
+
+	f, ok := jpNumber(me.Raw())
+	if !ok {
+		return 0, me.getError("GetFloat", "number")
+	}
+
+	return f, nil
+}
+
+// AsStringOr ... func
+// def when me is missing, null or not a string, without warnings.
+func (me *JSONElement) AsStringOr(def string) string {
+
+	if v, err := me.GetString(); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// AsIntOr ... func
+func (me *JSONElement) AsIntOr(def int) int {
+
+	if v, err := me.GetInt(); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// AsFloatOr ... func
+func (me *JSONElement) AsFloatOr(def float64) float64 {
+
+	if v, err := me.GetFloat(); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// AsBoolOr ... func
+func (me *JSONElement) AsBoolOr(def bool) bool {
+
+	if v, err := me.GetBool(); err == nil {
+		return v
+	}
+
+	return def
+}
@@ -0,0 +1,98 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"s": "x", "b": false, "i": 0, "f": 1.5, "n": null, "a": [1]}`)
+	assert.Nil(err)
+
+	s, err := root.Select("s").GetString()
+	assert.Nil(err)
+	assert.Equal("x", s)
+
+	b, err := root.Select("b").GetBool()
+	assert.Nil(err)
+	assert.False(b)
+
+	i, err := root.Select("i").GetInt()
+	assert.Nil(err)
+	assert.Equal(0, i)
+
+	f, err := root.Select("f").GetFloat()
+	assert.Nil(err)
+	assert.Equal(1.5, f)
+
+	i, err = root.Select("a", 0).GetInt()
+	assert.Nil(err)
+	assert.Equal(1, i)
+
+	// wrong type
+	_, err = root.Select("f").GetInt()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+	assert.Equal("GetInt: /f: type mismatch: number is not int", err.Error())
+
+	_, err = root.Select("s").GetBool()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+
+	_, err = root.GetString()
+	assert.Equal("GetString: /: type mismatch: map is not string", err.Error())
+
+	// null is present, but not a string
+	_, err = root.Select("n").GetString()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+
+	// missing
+	_, err = root.Select("x").GetString()
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.Equal("GetString: /x: not found", err.Error())
+
+	_, err = root.Select("a").SelectByPos(3).GetFloat()
+	assert.True(errors.Is(err, ErrNotFound))
+
+	_, err = New(nil).GetBool()
+	assert.True(errors.Is(err, ErrNotFound))
+
+	n, err := NewByBytesUseNumber([]byte(`[7, 1e400]`))
+	assert.Nil(err)
+
+	i, err = n.Select(0).GetInt()
+	assert.Nil(err)
+	assert.Equal(7, i)
+
+	_, err = n.Select(1).GetInt()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+}
+
+func TestAsOr(t *testing.T) {
+
+	assert := assert.New(t)
+
+	warned := 0
+
+	root, err := NewByString(`{"name": "a", "port": 0, "ratio": 0.5, "debug": false, "n": null}`)
+	assert.Nil(err)
+	root.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		warned++
+	}
+
+	assert.Equal("a", root.Select("name").AsStringOr("b"))
+	assert.Equal(0, root.Select("port").AsIntOr(80))
+	assert.Equal(0.5, root.Select("ratio").AsFloatOr(1))
+	assert.False(root.Select("debug").AsBoolOr(true))
+
+	assert.Equal("b", root.Select("x").AsStringOr("b"))
+	assert.Equal(80, root.Select("n").AsIntOr(80))
+	assert.Equal(1.0, root.Select("name").AsFloatOr(1))
+	assert.True(root.Select("port").AsBoolOr(true))
+	assert.Equal(80, root.Select("ratio").AsIntOr(80))
+
+	assert.Equal(0, warned)
+}
@@ -0,0 +1,791 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// MediaTypeHJSON ... const
+const MediaTypeHJSON = "application/hjson"
+
+func init() {
+	RegisterDecoder(MediaTypeHJSON, decodeHJSON, ".hjson")
+}
+
+func decodeHJSON(data []byte) (interface{}, error) {
+	return hjsonDecode(data, nil)
+}
+
+// hjsonParser ... struct
+// Hjson (https://hjson.github.io): comments, optional commas and root braces,
+// quoteless keys and strings, multiline strings in triple single quotes.
+type hjsonParser struct {
+	src   string
+	pos   int
+	depth int
+	order *keyOrderState
+}
+
+func hjsonDecode(data []byte, order *keyOrderState) (interface{}, error) {
+
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("HJSON: Invalid UTF-8")
+	}
+
+	p := &hjsonParser{
+		src:   strings.TrimPrefix(string(data), "\ufeff"),
+		order: order,
+	}
+
+	obj, err := p.root()
+	if err != nil {
+		return nil, fmt.Errorf("HJSON: line %d: %w", p.line(), err)
+	}
+
+	return obj, nil
+}
+
+func (me *hjsonParser) line() int {
+	return 1 + strings.Count(me.src[:me.pos], "\n")
+}
+
+func (me *hjsonParser) eof() bool {
+	return me.pos >= len(me.src)
+}
+
+func (me *hjsonParser) peek() byte {
+
+	if me.eof() {
+		return 0
+	}
+
+	return me.src[me.pos]
+}
+
+func (me *hjsonParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(me.src[me.pos:], s)
+}
+
+// skipWhite ... func
+// white space, line breaks and #, // and /* */ comments.
+func (me *hjsonParser) skipWhite() error {
+
+	for !me.eof() {
+
+		switch c := me.peek(); {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			me.pos++
+
+		case c == '#' || me.hasPrefix("//"):
+			for !me.eof() && me.peek() != '\n' {
+				me.pos++
+			}
+
+		case me.hasPrefix("/*"):
+			end := strings.Index(me.src[me.pos+2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("Unterminated Comment")
+			}
+			me.pos += 2 + end + 2
+
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// root ... func
+// the braces of a root object may be omitted.
+func (me *hjsonParser) root() (interface{}, error) {
+
+	if err := me.skipWhite(); err != nil {
+		return nil, err
+	}
+
+	if c := me.peek(); c == '{' || c == '[' {
+
+		v, err := me.value()
+		if err != nil {
+			return nil, err
+		}
+
+		return v, me.end()
+	}
+
+	start := me.pos
+
+	obj, err := me.object(true)
+	if err == nil {
+		return obj, nil
+	}
+
+	errPos := me.pos
+	me.pos = start
+
+	// a single literal or quoted string, a broken object is not taken for a
+	// quoteless string
+	quoted := me.peek() == '"' || me.peek() == '\''
+
+	if v, valueErr := me.value(); valueErr == nil && me.end() == nil {
+		if _, ok := v.(string); !ok || quoted {
+			return v, nil
+		}
+	}
+
+	me.pos = errPos
+
+	return nil, err
+}
+
+func (me *hjsonParser) end() error {
+
+	if err := me.skipWhite(); err != nil {
+		return err
+	}
+
+	if !me.eof() {
+		return fmt.Errorf("Trailing Characters: %q", me.peek())
+	}
+
+	return nil
+}
+
+// enter ... func
+// counts a level of nesting, the caller decrements depth on return.
+func (me *hjsonParser) enter() error {
+
+	me.depth++
+
+	if me.depth > binaryMaxDepth {
+		return fmt.Errorf("Too Deep")
+	}
+
+	return nil
+}
+
+func (me *hjsonParser) object(rootless bool) (map[string]interface{}, error) {
+
+	defer func() { me.depth-- }()
+
+	if err := me.enter(); err != nil {
+		return nil, err
+	}
+
+	if !rootless {
+		me.pos++
+	}
+
+	obj := map[string]interface{}{}
+	keys := []string{}
+
+	for {
+		if err := me.skipWhite(); err != nil {
+			return nil, err
+		}
+
+		if !rootless && me.peek() == '}' {
+			me.pos++
+			break
+		}
+
+		if me.eof() {
+			if rootless {
+				break
+			}
+			return nil, fmt.Errorf("Unterminated Object")
+		}
+
+		key, err := me.key()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := me.skipWhite(); err != nil {
+			return nil, err
+		}
+
+		if me.peek() != ':' {
+			return nil, fmt.Errorf("%s: Expected ':'", key)
+		}
+		me.pos++
+
+		// not wrapped per level, the line number locates it
+		val, err := me.value()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := obj[key]; !ok {
+			keys = append(keys, key)
+		}
+		obj[key] = val
+
+		if err := me.skipWhite(); err != nil {
+			return nil, err
+		}
+
+		if me.peek() == ',' {
+			me.pos++
+		}
+	}
+
+	if me.order != nil {
+		me.order.record(obj, keys)
+	}
+
+	return obj, nil
+}
+
+func (me *hjsonParser) array() (interface{}, error) {
+
+	defer func() { me.depth-- }()
+
+	if err := me.enter(); err != nil {
+		return nil, err
+	}
+
+	me.pos++
+
+	arr := []interface{}{}
+
+	for {
+		if err := me.skipWhite(); err != nil {
+			return nil, err
+		}
+
+		if me.peek() == ']' {
+			me.pos++
+			break
+		}
+
+		if me.eof() {
+			return nil, fmt.Errorf("Unterminated Array")
+		}
+
+		v, err := me.value()
+		if err != nil {
+			return nil, err
+		}
+
+		arr = append(arr, v)
+
+		if err := me.skipWhite(); err != nil {
+			return nil, err
+		}
+
+		if me.peek() == ',' {
+			me.pos++
+		}
+	}
+
+	return &arr, nil
+}
+
+const hjsonPunctuators = ",:[]{}"
+
+func (me *hjsonParser) key() (string, error) {
+
+	if c := me.peek(); c == '"' || c == '\'' {
+		return me.quoted()
+	}
+
+	start := me.pos
+
+	for !me.eof() {
+
+		c := me.peek()
+		if c == ':' || c == ' ' || c == '\t' || c == '\r' || c == '\n' {
+			break
+		}
+
+		if strings.IndexByte(hjsonPunctuators, c) >= 0 {
+			return "", fmt.Errorf("Bad Key: Found %q", c)
+		}
+
+		me.pos++
+	}
+
+	if me.pos == start {
+		return "", fmt.Errorf("Empty Key")
+	}
+
+	return me.src[start:me.pos], nil
+}
+
+func (me *hjsonParser) value() (interface{}, error) {
+
+	if err := me.skipWhite(); err != nil {
+		return nil, err
+	}
+
+	switch me.peek() {
+	case '{':
+		return me.object(false)
+	case '[':
+		return me.array()
+	case '"':
+		return me.quoted()
+	case '\'':
+		if me.hasPrefix("'''") {
+			return me.multiline()
+		}
+		return me.quoted()
+	case 0:
+		if me.eof() {
+			return nil, fmt.Errorf("Unexpected End")
+		}
+	}
+
+	return me.quoteless()
+}
+
+// quoted ... func
+// "..." or '...' with the escapes of JSON (\' too).
+func (me *hjsonParser) quoted() (string, error) {
+
+	quote := me.peek()
+	me.pos++
+
+	sb := strings.Builder{}
+
+	for {
+		if me.eof() || me.peek() == '\n' {
+			return "", fmt.Errorf("Unterminated String")
+		}
+
+		c := me.peek()
+		me.pos++
+
+		switch c {
+		case quote:
+			return sb.String(), nil
+
+		case '\\':
+			if me.eof() {
+				return "", fmt.Errorf("Unterminated String")
+			}
+
+			esc := me.peek()
+			me.pos++
+
+			switch esc {
+			case '"', '\'', '\\', '/':
+				sb.WriteByte(esc)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				r, err := me.escapeU()
+				if err != nil {
+					return "", err
+				}
+				sb.WriteRune(r)
+			default:
+				return "", fmt.Errorf("Bad Escape: \\%c", esc)
+			}
+
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+// escapeU ... func
+// the XXXX of \uXXXX, a following low surrogate is combined.
+func (me *hjsonParser) escapeU() (rune, error) {
+
+	hex4 := func() (rune, error) {
+
+		if me.pos+4 > len(me.src) {
+			return 0, fmt.Errorf("Bad Escape: \\u")
+		}
+
+		v, err := strconv.ParseUint(me.src[me.pos:me.pos+4], 16, 32)
+		if err != nil {
+			return 0, fmt.Errorf("Bad Escape: \\u%s", me.src[me.pos:me.pos+4])
+		}
+
+		me.pos += 4
+
+		return rune(v), nil
+	}
+
+	r, err := hex4()
+	if err != nil {
+		return 0, err
+	}
+
+	if utf16.IsSurrogate(r) && me.hasPrefix(`\u`) {
+
+		save := me.pos
+		me.pos += 2
+
+		r2, err := hex4()
+		if err == nil {
+			if dec := utf16.DecodeRune(r, r2); dec != utf8.RuneError {
+				return dec, nil
+			}
+		}
+
+		me.pos = save
+	}
+
+	if utf16.IsSurrogate(r) {
+		return utf8.RuneError, nil
+	}
+
+	return r, nil
+}
+
+// multiline ... func
+// a string in triple single quotes, the indentation of the opening quotes is
+// removed from every line and the line break before the closing ones is not
+// part of the string.
+func (me *hjsonParser) multiline() (string, error) {
+
+	indent := me.pos - (strings.LastIndexByte(me.src[:me.pos], '\n') + 1)
+
+	me.pos += 3
+
+	for me.peek() == ' ' || me.peek() == '\t' {
+		me.pos++
+	}
+
+	if me.hasPrefix("\r\n") {
+		me.pos += 2
+	} else if me.peek() == '\n' {
+		me.pos++
+	}
+
+	end := strings.Index(me.src[me.pos:], "'''")
+	if end < 0 {
+		return "", fmt.Errorf("Unterminated Multiline String")
+	}
+
+	text := me.src[me.pos : me.pos+end]
+	me.pos += end + 3
+
+	lines := strings.Split(text, "\n")
+	for i, v := range lines {
+
+		v = strings.TrimSuffix(v, "\r")
+
+		for n := 0; n < indent && v != "" && (v[0] == ' ' || v[0] == '\t'); n++ {
+			v = v[1:]
+		}
+
+		lines[i] = v
+	}
+
+	return strings.TrimSuffix(strings.Join(lines, "\n"), "\n"), nil
+}
+
+var hjsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// hjsonLiteral ... func
+// true, false, null or a number (float64 as with JSON).
+func hjsonLiteral(tok string) (interface{}, bool) {
+
+	switch tok {
+	case "true":
+		return true, true
+	case "false":
+		return false, true
+	case "null":
+		return nil, true
+	}
+
+	if hjsonNumber.MatchString(tok) {
+		if f, err := strconv.ParseFloat(tok, 64); err == nil {
+			return f, true
+		}
+	}
+
+	return nil, false
+}
+
+// quoteless ... func
+// a literal when it ends at a comma, bracket or comment, otherwise the rest of
+// the line is a string (trailing white space removed).
+func (me *hjsonParser) quoteless() (interface{}, error) {
+
+	if c := me.peek(); strings.IndexByte(hjsonPunctuators, c) >= 0 {
+		return nil, fmt.Errorf("Found Punctuator %q", c)
+	}
+
+	start := me.pos
+
+	for {
+		c := me.peek()
+		eol := me.eof() || c == '\n' || c == '\r'
+
+		if eol || c == ',' || c == '}' || c == ']' || c == '#' || me.hasPrefix("//") || me.hasPrefix("/*") {
+
+			if v, ok := hjsonLiteral(strings.TrimSpace(me.src[start:me.pos])); ok {
+				return v, nil
+			}
+
+			if eol {
+				return strings.TrimRight(me.src[start:me.pos], " \t"), nil
+			}
+		}
+
+		me.pos++
+	}
+}
+
+// NewByHJSON ... func
+// loads an Hjson document, map keys keep the order of the source.
+// Arrays are editable.
+func NewByHJSON(data []byte) (*JSONElement, error) {
+
+	order := newKeyOrderState()
+
+	obj, err := hjsonDecode(data, order)
+	if err != nil {
+		return nil, fmt.Errorf("NewByHJSON: %w", err)
+	}
+
+	elm := New(obj)
+	elm.order = order
+
+	return elm, nil
+}
+
+// hjsonWriter ... struct
+type hjsonWriter struct {
+	buf  *bytes.Buffer
+	elm  *JSONElement
+	less func(string, string) bool
+}
+
+const hjsonIndent = "  "
+
+func hjsonKey(k string) string {
+
+	if k == "" || strings.ContainsAny(k, hjsonPunctuators+"\"'#/ \t\r\n") {
+		buf := &bytes.Buffer{}
+		writeJSONString(buf, k)
+		return buf.String()
+	}
+
+	for _, r := range k {
+		if r < 0x20 || r == 0x7f {
+			buf := &bytes.Buffer{}
+			writeJSONString(buf, k)
+			return buf.String()
+		}
+	}
+
+	return k
+}
+
+// quotelessOK ... func
+// s reads back as the same string without quotes.
+func quotelessOK(s string) bool {
+
+	if s == "" || s != strings.TrimSpace(s) || strings.IndexByte(hjsonPunctuators+"\"'#", s[0]) >= 0 {
+		return false
+	}
+
+	if strings.HasPrefix(s, "//") || strings.HasPrefix(s, "/*") {
+		return false
+	}
+
+	if _, ok := hjsonLiteral(s); ok {
+		return false
+	}
+
+	for i, r := range s {
+
+		if r < 0x20 || r == 0x7f {
+			return false
+		}
+
+		// where quoteless() checks for a literal
+		if strings.ContainsRune(",}]#", r) || strings.HasPrefix(s[i:], "//") || strings.HasPrefix(s[i:], "/*") {
+			if _, ok := hjsonLiteral(strings.TrimSpace(s[:i])); ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// multilineOK ... func
+// s can be written as a ”' string.
+func multilineOK(s string) bool {
+
+	if !strings.Contains(s, "\n") || strings.Contains(s, "'''") {
+		return false
+	}
+
+	for _, r := range s {
+		if (r < 0x20 && r != '\n' && r != '\t') || r == 0x7f {
+			return false
+		}
+	}
+
+	// leading white space of the first line would be skipped
+	return s[0] != ' ' && s[0] != '\t'
+}
+
+// multiline ... func
+// the ”' lines at indent.
+func (me *hjsonWriter) multiline(s, indent string) {
+
+	me.buf.WriteString("'''\n")
+
+	for _, line := range strings.Split(s, "\n") {
+		if line != "" {
+			me.buf.WriteString(indent + line)
+		}
+		me.buf.WriteByte('\n')
+	}
+
+	me.buf.WriteString(indent + "'''")
+}
+
+func (me *hjsonWriter) value(path []interface{}, raw interface{}, indent string) error {
+
+	raw, err := resolveSpill(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	inner := indent + hjsonIndent
+
+	switch typed := raw.(type) {
+	case nil:
+		me.buf.WriteString("null")
+	case bool:
+		me.buf.WriteString(strconv.FormatBool(typed))
+	case int, json.Number:
+		me.buf.WriteString(numberText(typed))
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			return fmt.Errorf("%s: Unsupported Value: %v", Path2Pointer(path), typed)
+		}
+		me.buf.WriteString(numberText(typed))
+
+	case string:
+		switch {
+		case quotelessOK(typed):
+			me.buf.WriteString(typed)
+		case multilineOK(typed):
+			me.multiline(typed, indent)
+		default:
+			writeJSONString(me.buf, typed)
+		}
+
+	case []interface{}, *[]interface{}:
+		arr := asSlice(typed)
+		if len(arr) == 0 {
+			me.buf.WriteString("[]")
+			return nil
+		}
+
+		me.buf.WriteString("[\n")
+
+		for i, v := range arr {
+
+			me.buf.WriteString(inner)
+
+			if err := me.value(appendParents(path, i), v, inner); err != nil {
+				return err
+			}
+
+			me.buf.WriteByte('\n')
+		}
+
+		me.buf.WriteString(indent + "]")
+
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			me.buf.WriteString("{}")
+			return nil
+		}
+
+		me.buf.WriteString("{\n")
+
+		for _, k := range me.elm.order.keys(typed, me.less) {
+
+			me.buf.WriteString(inner + hjsonKey(k) + ":")
+
+			v, err := resolveSpill(typed[k])
+			if err != nil {
+				return fmt.Errorf("%s: %w", Path2Pointer(appendParents(path, k)), err)
+			}
+
+			if s, ok := v.(string); ok && !quotelessOK(s) && multilineOK(s) {
+				// the ''' lines below the key
+				me.buf.WriteString("\n" + inner + hjsonIndent)
+				me.multiline(s, inner+hjsonIndent)
+			} else {
+				me.buf.WriteByte(' ')
+
+				if err := me.value(appendParents(path, k), v, inner); err != nil {
+					return err
+				}
+			}
+
+			me.buf.WriteByte('\n')
+		}
+
+		me.buf.WriteString(indent + "}")
+
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+		}
+
+		var obj interface{}
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+		}
+
+		return me.value(path, obj, indent)
+	}
+
+	return nil
+}
+
+// ToHJSON ... func
+// writes me as an Hjson document: quoteless keys and strings where they read
+// back the same, ”' for multiline strings, no commas. Map keys in insertion
+// order for ordered elements, otherwise by KeyLess (lexical when nil).
+func (me *JSONElement) ToHJSON() ([]byte, error) {
+
+	less := me.KeyLess
+	if less == nil {
+		less = LexicalLess
+	}
+
+	w := &hjsonWriter{buf: &bytes.Buffer{}, elm: me, less: less}
+
+	if err := w.value([]interface{}{}, me.Raw(), ""); err != nil {
+		return nil, me.Errorf("ToHJSON: %w", err)
+	}
+
+	w.buf.WriteByte('\n')
+
+	return w.buf.Bytes(), nil
+}
@@ -0,0 +1,148 @@
+package dynajson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByHJSON(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := `# service config
+// no root braces
+name: my service
+port: 8080
+debug: false
+ratio: 0.5, # a number before a comment
+note: hello, world # not a comment inside a quoteless string
+"quoted key": 'single "quoted"'
+empty: ""
+tags: [
+  a
+  b, c
+  3
+]
+nested: {x: 1, y: "two",}
+/* block
+   comment */
+text:
+  '''
+  first line
+    indented
+  last
+  '''
+`
+
+	root, err := NewByHJSON([]byte(src))
+	assert.Nil(err)
+
+	assert.Equal("my service", root.Select("name").AsString())
+	assert.Equal(8080, root.Select("port").AsInt())
+	assert.Equal(false, root.Select("debug").AsBool())
+	assert.Equal(0.5, root.Select("ratio").AsFloat())
+	assert.Equal("hello, world # not a comment inside a quoteless string", root.Select("note").AsString())
+	assert.Equal(`single "quoted"`, root.Select("quoted key").AsString())
+	assert.Equal("", root.Select("empty").AsString())
+	assert.Equal(`["a", "b, c", 3]`, root.Select("tags").String())
+	assert.Equal(`{"x": 1, "y": "two"}`, root.Select("nested").String())
+	assert.Equal("first line\n  indented\nlast", root.Select("text").AsString())
+
+	// source order
+	assert.Equal([]string{"name", "port", "debug", "ratio", "note", "quoted key", "empty", "tags", "nested", "text"}, root.Keys())
+
+	// plain JSON is Hjson
+	root, err = NewByHJSON([]byte(`{"a": [1, {"b": null}], "c": "\u00e9\ud83d\ude00"}`))
+	assert.Nil(err)
+	assert.Equal(`{"a": [1, {"b": null}], "c": "é😀"}`, root.String())
+
+	root, err = NewByHJSON([]byte(`[1, 2]`))
+	assert.Nil(err)
+	assert.Nil(root.Append(3))
+
+	for _, bad := range []string{`{a: 1`, `[1, 2`, `{a b: 1}`, `a: "x`, "a: '''x", `a: 1 /* x`, `{a: 1} x`, `a: {b}`} {
+		_, err := NewByHJSON([]byte(bad))
+		assert.NotNil(err, bad)
+	}
+
+	_, err = NewByHJSON([]byte("a:\n  b\nc: }"))
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "line 3")
+
+	// nesting is limited, the error does not grow with it
+	_, err = NewByHJSON([]byte(strings.Repeat("[", 3000000)))
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "Too Deep")
+	assert.True(len(err.Error()) < 100)
+
+	_, err = NewByHJSON([]byte("a: " + strings.Repeat("{b: ", 2000)))
+	assert.Contains(err.Error(), "Too Deep")
+
+	deep := strings.Repeat("[", binaryMaxDepth) + strings.Repeat("]", binaryMaxDepth)
+	_, err = NewByHJSON([]byte(deep))
+	assert.Nil(err)
+}
+
+func TestToHJSON(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesOrdered([]byte(`{
+		"name": "my service",
+		"port": 8080,
+		"flag": "true",
+		"num": "12",
+		"comma": "a, 1",
+		"lit": "1, a",
+		"space": " x",
+		"text": "line 1\n  line 2\n",
+		"empty": "",
+		"list": ["a", "# b", {}, [], null, "x\ny"],
+		"obj": {"k y": 1, "": 2}
+	}`))
+	assert.Nil(err)
+
+	data, err := root.ToHJSON()
+	assert.Nil(err)
+
+	assert.Equal(`{
+  name: my service
+  port: 8080
+  flag: "true"
+  num: "12"
+  comma: a, 1
+  lit: "1, a"
+  space: " x"
+  text:
+    '''
+    line 1
+      line 2
+
+    '''
+  empty: ""
+  list: [
+    a
+    "# b"
+    {}
+    []
+    null
+    '''
+    x
+    y
+    '''
+  ]
+  obj: {
+    "k y": 1
+    "": 2
+  }
+}
+`, string(data))
+
+	// round trip
+	back, err := NewByHJSON(data)
+	assert.Nil(err)
+	assert.True(root.Equals(back))
+	assert.Equal(root.Keys(), back.Keys())
+}
@@ -0,0 +1,150 @@
+package dynajson
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type httpConfig struct {
+	ctx     context.Context
+	fetcher PathFetcher
+}
+
+// HTTPOption ... type
+// configures NewByURL.
+type HTTPOption func(*httpConfig)
+
+// WithHTTPClient ... func
+// proxies, TLS config, transports and cookie jars go through the client.
+func WithHTTPClient(client *http.Client) HTTPOption {
+	return func(me *httpConfig) {
+		me.fetcher.Client = client
+	}
+}
+
+// WithHeader ... func
+func WithHeader(key, value string) HTTPOption {
+	return func(me *httpConfig) {
+		if me.fetcher.Header == nil {
+			me.fetcher.Header = http.Header{}
+		}
+		me.fetcher.Header.Add(key, value)
+	}
+}
+
+// WithBasicAuth ... func
+func WithBasicAuth(user, password string) HTTPOption {
+	token := base64.StdEncoding.EncodeToString([]byte(user + ":" + password))
+	return WithHeader("Authorization", "Basic "+token)
+}
+
+// WithBearerToken ... func
+func WithBearerToken(token string) HTTPOption {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithTimeout ... func
+// bounds each request including reading the body.
+func WithTimeout(d time.Duration) HTTPOption {
+	return func(me *httpConfig) {
+		me.fetcher.Timeout = d
+	}
+}
+
+// WithRetry ... func
+// retries network errors, 429 and 5xx up to n times, waiting wait, 2*wait, 4*wait, ...
+func WithRetry(n int, wait time.Duration) HTTPOption {
+	return func(me *httpConfig) {
+		me.fetcher.Retries = n
+		me.fetcher.RetryWait = wait
+	}
+}
+
+// WithContext ... func
+// cancels the request, retries and the read of the body.
+func WithContext(ctx context.Context) HTTPOption {
+	return func(me *httpConfig) {
+		me.ctx = ctx
+	}
+}
+
+// NewByURL ... func
+// loads an http(s) URL, decoding the body by its Content-Type like NewByPath.
+func NewByURL(url string, opts ...HTTPOption) (*JSONElement, error) {
+
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("NewByURL: Not HTTP URL: %s", url)
+	}
+
+	conf := &httpConfig{ctx: context.Background()}
+
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	return loadByPath(conf.ctx, url, loadConfig{fetcher: &conf.fetcher})
+}
+
+// NewByURLContext ... func
+// same as NewByURL, ctx wins over a WithContext option.
+func NewByURLContext(ctx context.Context, url string, opts ...HTTPOption) (*JSONElement, error) {
+
+	all := make([]HTTPOption, 0, len(opts)+1)
+	all = append(all, opts...)
+
+	return NewByURL(url, append(all, WithContext(ctx))...)
+}
+
+// FetchJSON ... func
+// sends body (may be nil) with method and loads the response, which must be 2xx.
+// hdrs override the default Accept header, ctx wins over a WithContext option.
+func FetchJSON(ctx context.Context, method, url string, body io.Reader, hdrs http.Header, opts ...HTTPOption) (*JSONElement, error) {
+
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("FetchJSON: Not HTTP URL: %s", url)
+	}
+
+	conf := &httpConfig{}
+
+	for _, opt := range opts {
+		opt(conf)
+	}
+
+	conf.ctx = ctx
+
+	if body != nil {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("FetchJSON: ReadAll: %w", err)
+		}
+		conf.fetcher.Body = data
+	}
+
+	header := http.Header{}
+	for k, v := range conf.fetcher.Header {
+		header[k] = v
+	}
+	for k, v := range hdrs {
+		header[k] = v
+	}
+
+	if body != nil && header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json")
+	}
+
+	conf.fetcher.Method = method
+	conf.fetcher.Header = header
+
+	elm, err := loadByPath(conf.ctx, url, loadConfig{fetcher: &conf.fetcher})
+	if err != nil {
+		return nil, fmt.Errorf("FetchJSON: %s: %w", method, err)
+	}
+
+	return elm, nil
+}
@@ -0,0 +1,197 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByURL(t *testing.T) {
+
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		fmt.Fprintf(w, `{"auth": %q, "user": %q, "pass": %q, "x": %q}`,
+			r.Header.Get("Authorization"), user, pass, r.Header.Get("X-Test"))
+	}))
+	defer srv.Close()
+
+	root, err := NewByURL(srv.URL, WithBearerToken("tok"), WithHeader("X-Test", "1"))
+	assert.Nil(err)
+	assert.Equal("Bearer tok", root.Select("auth").AsString())
+	assert.Equal("1", root.Select("x").AsString())
+
+	root, err = NewByURL(srv.URL, WithBasicAuth("me", "secret"), WithHTTPClient(srv.Client()))
+	assert.Nil(err)
+	assert.Equal("me", root.Select("user").AsString())
+	assert.Equal("secret", root.Select("pass").AsString())
+
+	_, err = NewByURL("testdata/a.json")
+	assert.NotNil(err)
+}
+
+func TestNewByURLRetry(t *testing.T) {
+
+	assert := assert.New(t)
+
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"calls": 3}`)
+	}))
+	defer srv.Close()
+
+	root, err := NewByURL(srv.URL, WithRetry(2, time.Millisecond))
+	assert.Nil(err)
+	assert.Equal(3, root.Select("calls").AsInt())
+
+	calls = 0
+	_, err = NewByURL(srv.URL, WithRetry(1, time.Millisecond))
+	assert.NotNil(err)
+	assert.Equal(2, calls)
+
+	// 404 is not retried
+	calls = 0
+	nf := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.NotFound(w, r)
+	}))
+	defer nf.Close()
+
+	_, err = NewByURL(nf.URL, WithRetry(3, time.Millisecond))
+	assert.NotNil(err)
+	assert.Equal(1, calls)
+}
+
+func TestNewByURLContext(t *testing.T) {
+
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := NewByURL(srv.URL, WithContext(ctx), WithRetry(5, time.Second))
+	assert.NotNil(err)
+	assert.True(time.Since(start) < time.Second)
+}
+
+func TestFetchJSON(t *testing.T) {
+
+	assert := assert.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"method": %q, "type": %q, "x": %q, "body": %s}`,
+			r.Method, r.Header.Get("Content-Type"), r.Header.Get("X-Test"), data)
+	}))
+	defer srv.Close()
+
+	hdrs := http.Header{}
+	hdrs.Set("X-Test", "1")
+
+	root, err := FetchJSON(context.Background(), http.MethodPost, srv.URL, strings.NewReader(`{"q": "a"}`), hdrs)
+	assert.Nil(err)
+	assert.Equal("POST", root.Select("method").AsString())
+	assert.Equal("application/json", root.Select("type").AsString())
+	assert.Equal("1", root.Select("x").AsString())
+	assert.Equal("a", root.Select("body", "q").AsString())
+
+	// the body is sent again on retry
+	calls := 0
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Write(data)
+	}))
+	defer flaky.Close()
+
+	root, err = FetchJSON(context.Background(), http.MethodPut, flaky.URL, strings.NewReader(`[1, 2]`), nil, WithRetry(1, time.Millisecond))
+	assert.Nil(err)
+	assert.Equal(2, root.Select(1).AsInt())
+
+	_, err = FetchJSON(context.Background(), http.MethodPost, "testdata/a.json", nil, nil)
+	assert.NotNil(err)
+}
+
+func TestFetchJSONStatus(t *testing.T) {
+
+	assert := assert.New(t)
+
+	status := http.StatusCreated
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"status": %d}`, status)
+	}))
+	defer srv.Close()
+
+	for _, code := range []int{http.StatusOK, http.StatusCreated, http.StatusAccepted} {
+		status = code
+		root, err := FetchJSON(context.Background(), http.MethodPost, srv.URL, strings.NewReader(`{}`), nil)
+		assert.Nil(err)
+		assert.Equal(code, root.Select("status").AsInt())
+	}
+
+	status = http.StatusNotFound
+	_, err := FetchJSON(context.Background(), http.MethodGet, srv.URL, nil, nil)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "404")
+
+	// ctx wins over WithContext
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	status = http.StatusOK
+	_, err = FetchJSON(ctx, http.MethodGet, srv.URL, nil, nil, WithContext(context.Background()))
+	assert.True(errors.Is(err, context.Canceled))
+
+	_, err = NewByURLContext(ctx, srv.URL, WithContext(context.Background()))
+	assert.True(errors.Is(err, context.Canceled))
+
+	// the caller's options are left alone
+	opts := make([]HTTPOption, 1, 2)
+	opts[0] = WithContext(context.Background())
+
+	_, err = NewByURLContext(ctx, srv.URL, opts...)
+	assert.True(errors.Is(err, context.Canceled))
+	assert.Nil(opts[:2][1])
+
+	// the error names the client in use
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("down")
+	})}
+
+	_, err = FetchJSON(context.Background(), http.MethodGet, srv.URL, nil, nil, WithHTTPClient(client))
+	assert.NotNil(err)
+	assert.NotContains(err.Error(), "DefaultClient")
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (me roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return me(r)
+}
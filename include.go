@@ -0,0 +1,230 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrIncludeCycle ... var
+// a document includes itself, directly or through others.
+var ErrIncludeCycle = errors.New("include cycle")
+
+// IncludeOptions ... struct
+type IncludeOptions struct {
+	// Key is the directive, "" means "$include".
+	Key string
+	// Fetcher loads the included documents, nil means GetDefaultFetcher().
+	Fetcher Fetcher
+	// MaxDepth limits nested includes, 0 means 32.
+	MaxDepth int
+}
+
+type includer struct {
+	ws       *Workspace
+	key      string
+	maxDepth int
+}
+
+// load ... func
+// the document ref points to (relative to from) with its own includes resolved.
+func (me *includer) load(from, ref string, stack []string) (interface{}, error) {
+
+	fragment := ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		fragment = ref[i:]
+	}
+
+	name, elm, err := me.ws.Resolve(context.Background(), from, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	id := name + fragment
+
+	for _, v := range stack {
+		if v == id {
+			return nil, fmt.Errorf("%s -> %s: %w", strings.Join(stack, " -> "), id, ErrIncludeCycle)
+		}
+	}
+
+	if len(stack) >= me.maxDepth {
+		return nil, fmt.Errorf("%s: Too Deep Includes", id)
+	}
+
+	cp, err := editableCopy(elm.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+
+	return me.resolve(cp, name, append(stack, id))
+}
+
+// directive ... func
+// the refs of the directive, a string or an array of strings.
+func (me *includer) directive(val interface{}) ([]string, error) {
+
+	if str, ok := val.(string); ok {
+		return []string{str}, nil
+	}
+
+	arr := asSlice(val)
+	if arr == nil {
+		return nil, fmt.Errorf("%s: %T: Not String Or Array", me.key, val)
+	}
+
+	refs := make([]string, 0, len(arr))
+
+	for _, v := range arr {
+
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: %T: Not String", me.key, v)
+		}
+
+		refs = append(refs, str)
+	}
+
+	return refs, nil
+}
+
+// resolve ... func
+// replaces the directives in raw (editable, modified in place) read from the document from.
+func (me *includer) resolve(raw interface{}, from string, stack []string) (interface{}, error) {
+
+	obj, isMap := raw.(map[string]interface{})
+
+	if val, ok := obj[me.key]; isMap && ok {
+
+		refs, err := me.directive(val)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(obj, me.key)
+
+		var ret interface{}
+
+		for _, ref := range refs {
+
+			loaded, err := me.load(from, ref, stack)
+			if err != nil {
+				return nil, err
+			}
+
+			if ret == nil {
+				ret = loaded
+				continue
+			}
+
+			ret = mergeRaw(ret, loaded)
+		}
+
+		if len(obj) == 0 {
+			return ret, nil
+		}
+
+		// the siblings of the directive override what is included
+		if _, ok := ret.(map[string]interface{}); !ok {
+
+			if ret != nil {
+				return nil, fmt.Errorf("%s: %T: Not Map With Siblings", me.key, ret)
+			}
+
+			ret = map[string]interface{}{}
+		}
+
+		siblings, err := me.resolve(obj, from, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return mergeRaw(ret, siblings), nil
+	}
+
+	entries, err := walkEntries(nil, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range entries {
+
+		val, err := me.resolve(v.val, from, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		setContainerRaw(raw, v.key, val)
+	}
+
+	return raw, nil
+}
+
+// ResolveIncludes ... func
+// replaces {"$include": "other.json"} with the document it names, paths and
+// URLs being relative to baseDir (a directory) or to the including document.
+// "other.json#/json/pointer" includes a part, an array of refs is merged in
+// order and keys beside the directive override the included ones. Included
+// documents are resolved recursively, a cycle fails with ErrIncludeCycle and
+// the tree is left unchanged on any error.
+func (me *JSONElement) ResolveIncludes(baseDir string, opts IncludeOptions) error {
+
+	if me.IsNil() {
+		return me.fail("ResolveIncludes", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return me.fail("ResolveIncludes", ErrorReadonly, "")
+	}
+
+	x := &includer{
+		ws:       NewWorkspace(),
+		key:      opts.Key,
+		maxDepth: opts.MaxDepth,
+	}
+
+	x.ws.Fetcher = opts.Fetcher
+
+	if x.key == "" {
+		x.key = "$include"
+	}
+
+	if x.maxDepth <= 0 {
+		x.maxDepth = 32
+	}
+
+	// a name in baseDir, so that refs resolve as from a document there
+	from := ""
+	if baseDir != "" {
+		from = strings.TrimSuffix(baseDir, "/") + "/"
+	}
+
+	cp, err := editableCopy(me.raw)
+	if err != nil {
+		return me.Errorf("ResolveIncludes: %w", err)
+	}
+
+	if me.order != nil {
+		if err := me.order.copyTo(me.order, me.raw, cp); err != nil {
+			me.order.forget(cp)
+			return me.Errorf("ResolveIncludes: %w", err)
+		}
+	}
+
+	ret, err := x.resolve(cp, from, []string{})
+	if err != nil {
+		me.order.forget(cp)
+		return me.Errorf("ResolveIncludes: %w", err)
+	}
+
+	old := me.raw
+	if err := me.replaceRaw(ret); err != nil {
+		me.order.forget(ret)
+		return me.Errorf("ResolveIncludes: %w", err)
+	}
+
+	me.order.forget(old)
+
+	return nil
+}
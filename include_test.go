@@ -0,0 +1,95 @@
+package dynajson
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveIncludes(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "include")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"db.json":          `{"host": "localhost", "port": 5432}`,
+		"log.json":         `{"level": "info", "out": {"$include": "sub/out.json"}}`,
+		"sub/out.json":     `{"file": "app.log", "rotate": {"$include": "rotate.json#/daily"}}`,
+		"sub/rotate.json":  `{"daily": {"keep": 7}}`,
+		"base.json":        `{"a": 1, "b": {"x": 1, "y": 1}}`,
+		"override.json":    `{"b": {"y": 2}}`,
+		"list.json":        `["a", "b"]`,
+		"cycle/one.json":   `{"next": {"$include": "two.json"}}`,
+		"cycle/two.json":   `{"next": {"$include": "one.json"}}`,
+		"cycle/start.json": `{"$include": "one.json"}`,
+	}
+
+	for name, data := range files {
+		fullPath := filepath.Join(dir, name)
+		assert.Nil(os.MkdirAll(filepath.Dir(fullPath), 0755))
+		assert.Nil(ioutil.WriteFile(fullPath, []byte(data), 0644))
+	}
+
+	root, err := NewByString(`{
+		"db": {"$include": "db.json", "port": 6432},
+		"log": {"$include": "log.json"},
+		"merged": {"$include": ["base.json", "override.json"], "c": 3},
+		"items": [{"$include": "list.json"}, 1]
+	}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	assert.Nil(root.ResolveIncludes(dir, IncludeOptions{}))
+	assert.Equal(`{"db": {"host": "localhost", "port": 6432}, "items": [["a", "b"], 1], `+
+		`"log": {"level": "info", "out": {"file": "app.log", "rotate": {"keep": 7}}}, `+
+		`"merged": {"a": 1, "b": {"x": 1, "y": 2}, "c": 3}}`, root.String())
+
+	// a cycle leaves the tree unchanged
+	root, _ = NewByString(`{"conf": {"$include": "cycle/start.json"}}`)
+	err = root.ResolveIncludes(dir, IncludeOptions{})
+	assert.True(errors.Is(err, ErrIncludeCycle))
+	assert.Equal(`{"conf": {"$include": "cycle/start.json"}}`, root.String())
+
+	// missing file
+	root, _ = NewByString(`{"$include": "none.json"}`)
+	assert.NotNil(root.ResolveIncludes(dir, IncludeOptions{}))
+
+	// siblings need an included map
+	root, _ = NewByString(`{"$include": "list.json", "x": 1}`)
+	assert.NotNil(root.ResolveIncludes(dir, IncludeOptions{}))
+
+	root, _ = NewByString(`{"$include": 1}`)
+	assert.NotNil(root.ResolveIncludes(dir, IncludeOptions{}))
+
+	// MaxDepth
+	root, _ = NewByString(`{"$include": "log.json"}`)
+	assert.NotNil(root.ResolveIncludes(dir, IncludeOptions{MaxDepth: 2}))
+	assert.Nil(root.ResolveIncludes(dir, IncludeOptions{MaxDepth: 3}))
+
+	root.Readonly = true
+	assert.True(errors.Is(root.ResolveIncludes(dir, IncludeOptions{}), ErrReadonly))
+}
+
+func TestResolveIncludesURL(t *testing.T) {
+
+	assert := assert.New(t)
+
+	fetcher := MapFetcher{
+		"https://example.com/conf/common.json":   []byte(`{"name": "svc", "limits": {"@import": "../shared/limits.json"}}`),
+		"https://example.com/shared/limits.json": []byte(`{"rps": 100}`),
+	}
+
+	root, err := NewByString(`{"@import": "common.json", "env": "prod"}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	assert.Nil(root.ResolveIncludes("https://example.com/conf", IncludeOptions{Key: "@import", Fetcher: fetcher}))
+	assert.Equal(`{"env": "prod", "limits": {"rps": 100}, "name": "svc"}`, root.String())
+}
@@ -0,0 +1,313 @@
+package dynajson
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// InternStats ... struct
+type InternStats struct {
+	Documents int
+	// Nodes counts every value interned, UniqueNodes those actually stored.
+	Nodes       int
+	UniqueNodes int
+	// SharedNodes is Nodes - UniqueNodes, the values not allocated again.
+	SharedNodes int
+}
+
+// Interner ... struct
+// stores identical subtrees of the documents given to Intern once (hash-consing).
+// Interned documents copy a container on its first mutation (copy-on-write), so
+// documents never see each other's changes.
+type Interner struct {
+	mu    sync.Mutex
+	table map[string]internNode
+	stats InternStats
+}
+
+type internNode struct {
+	id  int
+	raw interface{}
+}
+
+// NewInterner ... func
+func NewInterner() *Interner {
+
+	return &Interner{
+		table: map[string]internNode{},
+	}
+}
+
+// Stats ... func
+func (me *Interner) Stats() InternStats {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	stats := me.stats
+	stats.UniqueNodes = len(me.table)
+	stats.SharedNodes = stats.Nodes - stats.UniqueNodes
+
+	return stats
+}
+
+// Intern ... func
+// returns a document equal to elm sharing memory with the documents interned before.
+// elm itself is left as is.
+func (me *Interner) Intern(elm *JSONElement) (*JSONElement, error) {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	node, err := me.intern(elm.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("Intern: %w", err)
+	}
+
+	me.stats.Documents++
+
+	root := New(node.raw)
+	root.cow = &cowState{owned: map[uintptr]interface{}{}}
+
+	return root, nil
+}
+
+func (me *Interner) store(key string, node internNode) internNode {
+
+	me.stats.Nodes++
+
+	if found, ok := me.table[key]; ok {
+		return found
+	}
+
+	node.id = len(me.table) + 1
+	me.table[key] = node
+
+	return node
+}
+
+func (me *Interner) intern(raw interface{}) (internNode, error) {
+
+	raw, err := resolveSpill(raw)
+	if err != nil {
+		return internNode{}, err
+	}
+
+	b := strings.Builder{}
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for k := range typed {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		obj := make(map[string]interface{}, len(typed))
+		b.WriteString("m")
+
+		for _, k := range keys {
+
+			sub, err := me.intern(typed[k])
+			if err != nil {
+				return internNode{}, fmt.Errorf("%s: %w", k, err)
+			}
+
+			obj[k] = sub.raw
+			fmt.Fprintf(&b, "%s:%d,", strconv.Quote(k), sub.id)
+		}
+
+		return me.store(b.String(), internNode{raw: obj}), nil
+
+	case []interface{}, *[]interface{}:
+		src := asSlice(typed)
+		arr := make([]interface{}, len(src))
+
+		_, editable := typed.(*[]interface{})
+		if editable {
+			b.WriteString("p")
+		} else {
+			b.WriteString("a")
+		}
+
+		for i, v := range src {
+
+			sub, err := me.intern(v)
+			if err != nil {
+				return internNode{}, fmt.Errorf("%d: %w", i, err)
+			}
+
+			arr[i] = sub.raw
+			fmt.Fprintf(&b, "%d,", sub.id)
+		}
+
+		if editable {
+			return me.store(b.String(), internNode{raw: &arr}), nil
+		}
+
+		return me.store(b.String(), internNode{raw: arr}), nil
+
+	case string:
+		return me.store("s"+typed, internNode{raw: typed}), nil
+	}
+
+	return me.store(fmt.Sprintf("%T:%v", raw, raw), internNode{raw: raw}), nil
+}
+
+// cowState ... struct
+// the containers a document has copied from the interned (shared) ones.
+// Values keep the copies reachable so their addresses are not reused.
+type cowState struct {
+	mu    sync.Mutex
+	owned map[uintptr]interface{}
+}
+
+func rawAddr(raw interface{}) uintptr {
+
+	switch raw.(type) {
+	case map[string]interface{}, *[]interface{}, []interface{}:
+		return reflect.ValueOf(raw).Pointer()
+	}
+
+	return 0
+}
+
+func (me *cowState) isOwned(raw interface{}) bool {
+
+	addr := rawAddr(raw)
+	if addr == 0 {
+		return false
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	_, ok := me.owned[addr]
+
+	return ok
+}
+
+func (me *cowState) own(raw interface{}) {
+
+	if addr := rawAddr(raw); addr != 0 {
+		me.mu.Lock()
+		me.owned[addr] = raw
+		me.mu.Unlock()
+	}
+}
+
+func containerRaw(container, key interface{}) (interface{}, bool) {
+
+	switch typed := key.(type) {
+	case string:
+		obj, ok := container.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		v, ok := obj[typed]
+		return v, ok
+	case int:
+		arr := asSlice(container)
+		if typed < 0 || typed >= len(arr) {
+			return nil, false
+		}
+
+		return arr[typed], true
+	}
+
+	return nil, false
+}
+
+func shallowCopyRaw(raw interface{}) interface{} {
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			obj[k] = v
+		}
+		return obj
+	case []interface{}:
+		return append([]interface{}{}, typed...)
+	case *[]interface{}:
+		arr := append([]interface{}{}, (*typed)...)
+		return &arr
+	}
+
+	return raw
+}
+
+// cowOf ... func
+// the cowState of the nearest element (me or an ancestor) having one, so that
+// elements selected before Snapshot or Intern see it too.
+func (me *JSONElement) cowOf() *cowState {
+
+	for elm := me; elm != nil; elm = elm.parent {
+		if elm.cow != nil {
+			return elm.cow
+		}
+	}
+
+	return nil
+}
+
+// unshare ... func
+// makes the container of me (and those on the way from the root) private to this
+// document before it is mutated, a no-op unless the document was interned.
+func (me *JSONElement) unshare() {
+
+	cs := me.cowOf()
+
+	if cs == nil || cs.isOwned(me.raw) {
+		return
+	}
+
+	if me.parent != nil && me.parent.cowOf() == cs {
+
+		me.parent.unshare()
+
+		if cur, ok := containerRaw(me.parent.raw, me.key); ok && cs.isOwned(cur) {
+			me.raw = cur
+			return
+		}
+	}
+
+	copied := shallowCopyRaw(me.raw)
+	cs.own(copied)
+
+	if obj, ok := me.raw.(map[string]interface{}); ok && me.order != nil {
+		me.order.record(copied.(map[string]interface{}), me.order.keys(obj, LexicalLess))
+		me.order.drop(obj)
+	}
+
+	if me.parent != nil && me.parent.cowOf() == cs {
+		setContainerRaw(me.parent.raw, me.key, copied)
+	}
+
+	me.raw = copied
+}
+
+// unshareAll ... func
+// same as unshare for the whole subtree, used before rewriting it in place.
+func (me *JSONElement) unshareAll() {
+
+	if me.cowOf() == nil {
+		return
+	}
+
+	me.unshare()
+
+	entries, err := walkEntries(nil, me.raw)
+	if err != nil {
+		return
+	}
+
+	for _, v := range entries {
+		setContainerRaw(me.raw, v.key, copyRaw(v.val))
+	}
+}
@@ -0,0 +1,65 @@
+package dynajson
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterner(t *testing.T) {
+
+	assert := assert.New(t)
+
+	in := NewInterner()
+	docs := []*JSONElement{}
+
+	for i := 0; i < 3; i++ {
+
+		src, err := NewByString(fmt.Sprintf(`{"id": %d, "meta": {"tags": ["a", "b"], "owner": {"name": "x"}}}`, i))
+		assert.Nil(err)
+
+		doc, err := in.Intern(src)
+		assert.Nil(err)
+
+		docs = append(docs, doc)
+	}
+
+	stats := in.Stats()
+	assert.Equal(3, stats.Documents)
+	assert.Equal(24, stats.Nodes)
+	assert.Equal(12, stats.UniqueNodes)
+	assert.Equal(12, stats.SharedNodes)
+
+	meta0 := docs[0].Select("meta").Raw().(map[string]interface{})
+	meta1 := docs[1].Select("meta").Raw().(map[string]interface{})
+	assert.Equal(rawAddr(meta0), rawAddr(meta1))
+
+	owner := docs[0].Select("meta", "owner")
+	assert.Nil(owner.Put("name", "y"))
+	assert.Equal("y", docs[0].Select("meta", "owner", "name").AsString())
+	assert.Equal("x", docs[1].Select("meta", "owner", "name").AsString())
+
+	tags := docs[0].Select("meta", "tags")
+	assert.Nil(tags.setChild(0, "z"))
+	assert.Nil(owner.Put("age", 3))
+	assert.Equal(`["z", "b"]`, docs[0].Select("meta", "tags").String())
+	assert.Equal(3, docs[0].Select("meta", "owner", "age").AsInt())
+	assert.Equal(`["a", "b"]`, docs[2].Select("meta", "tags").String())
+	assert.True(docs[2].Select("meta", "owner", "age").IsNil())
+
+	assert.Nil(docs[1].DeleteByKey("meta"))
+	assert.True(docs[2].Select("meta").IsMap())
+
+	_, err := docs[0].Prune(PruneOptions{RemoveZeroScalars: true})
+	assert.Nil(err)
+	assert.True(docs[0].Select("id").IsNil())
+	assert.Equal(2, docs[2].Select("id").AsInt())
+	assert.Equal(`{"name": "x"}`, docs[2].Select("meta", "owner").String())
+
+	fresh, err := NewByString(`{"id": 0}`)
+	assert.Nil(err)
+	fresh, err = in.Intern(fresh)
+	assert.Nil(err)
+	assert.Equal(0, fresh.Select("id").AsInt())
+}
@@ -0,0 +1,265 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// utf16Less ... func
+// orders keys by their UTF-16 code units as RFC 8785 requires.
+func utf16Less(a, b string) bool {
+
+	ua, ub := utf16.Encode([]rune(a)), utf16.Encode([]rune(b))
+
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+
+	return len(ua) < len(ub)
+}
+
+// jcsNumber ... func
+// the ECMAScript Number.prototype.toString() form of f (RFC 8785 3.2.2.3).
+func jcsNumber(f float64) (string, error) {
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("Unsupported Value: %v", f)
+	}
+
+	if f == 0 {
+		// -0 included
+		return "0", nil
+	}
+
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+
+	// shortest digits d1.d2d3...e±x
+	parts := strings.SplitN(strconv.FormatFloat(f, 'e', -1, 64), "e", 2)
+	digits := strings.Replace(parts[0], ".", "", 1)
+
+	x, _ := strconv.Atoi(parts[1])
+	k, n := len(digits), x+1
+
+	switch {
+	case k <= n && n <= 21:
+		return sign + digits + strings.Repeat("0", n-k), nil
+	case 0 < n && n <= 21:
+		return sign + digits[:n] + "." + digits[n:], nil
+	case -6 < n && n <= 0:
+		return sign + "0." + strings.Repeat("0", -n) + digits, nil
+	}
+
+	ret := sign + digits[:1]
+	if k > 1 {
+		ret += "." + digits[1:]
+	}
+
+	if n-1 >= 0 {
+		return ret + "e+" + strconv.Itoa(n-1), nil
+	}
+
+	return ret + "e" + strconv.Itoa(n-1), nil
+}
+
+// writeJCSString ... func
+// only '"', '\' and control characters are escaped (RFC 8785 3.2.2.2).
+func writeJCSString(buf *bytes.Buffer, str string) error {
+
+	if !utf8.ValidString(str) {
+		return fmt.Errorf("Invalid UTF-8: %q", str)
+	}
+
+	buf.WriteByte('"')
+
+	for _, r := range str {
+
+		switch r {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[r>>4])
+				buf.WriteByte(hexDigits[r&0xf])
+				continue
+			}
+
+			buf.WriteRune(r)
+		}
+	}
+
+	buf.WriteByte('"')
+
+	return nil
+}
+
+func writeJCS(buf *bytes.Buffer, path []interface{}, raw interface{}) error {
+
+	wrap := func(err error) error {
+		return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	switch typed := raw.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(typed))
+	case string:
+		if err := writeJCSString(buf, typed); err != nil {
+			return wrap(err)
+		}
+	case int:
+		s, _ := jcsNumber(float64(typed))
+		buf.WriteString(s)
+	case float64:
+		s, err := jcsNumber(typed)
+		if err != nil {
+			return wrap(err)
+		}
+
+		buf.WriteString(s)
+	case json.Number:
+		f, err := typed.Float64()
+		if err != nil {
+			return wrap(err)
+		}
+
+		s, err := jcsNumber(f)
+		if err != nil {
+			return wrap(err)
+		}
+
+		buf.WriteString(s)
+
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return wrap(err)
+		}
+
+		return writeJCS(buf, path, obj)
+
+	case []interface{}, *[]interface{}:
+		buf.WriteByte('[')
+
+		for i, v := range asSlice(typed) {
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJCS(buf, appendParents(path, i), v); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for k := range typed {
+			keys = append(keys, k)
+		}
+
+		sort.Slice(keys, func(i, j int) bool {
+			return utf16Less(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+
+		for i, k := range keys {
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJCSString(buf, k); err != nil {
+				return wrap(err)
+			}
+
+			buf.WriteByte(':')
+
+			if err := writeJCS(buf, appendParents(path, k), typed[k]); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+
+	default:
+		// through encoding/json, e.g. structs put as values
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return wrap(err)
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return wrap(err)
+		}
+
+		return writeJCS(buf, path, v)
+	}
+
+	return nil
+}
+
+// CanonicalBytes ... func
+// me in the RFC 8785 JSON Canonicalization Scheme (JCS): no whitespace, keys
+// sorted by UTF-16 code units, numbers as ECMAScript doubles and minimal string
+// escapes. The same data always gives the same bytes, for hashing and signing.
+func (me *JSONElement) CanonicalBytes() ([]byte, error) {
+
+	buf := &bytes.Buffer{}
+
+	if err := writeJCS(buf, []interface{}{}, me.Raw()); err != nil {
+		return nil, me.Errorf("CanonicalBytes: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Hash ... func
+// the digest by h (e.g. sha256.New()) of CanonicalBytes, equal values give the
+// same digest whatever their key order or number notation. h is not reset.
+func (me *JSONElement) Hash(h hash.Hash) ([]byte, error) {
+
+	buf := &bytes.Buffer{}
+
+	if err := writeJCS(buf, []interface{}{}, me.Raw()); err != nil {
+		return nil, me.Errorf("Hash: %w", err)
+	}
+
+	if _, err := h.Write(buf.Bytes()); err != nil {
+		return nil, me.Errorf("Hash: %w", err)
+	}
+
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,108 @@
+package dynajson
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalBytes(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// RFC 8785 3.2.2
+	root, err := NewByString(`{
+		"numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+		"string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+		"literals": [null, true, false]
+	}`)
+	assert.Nil(err)
+
+	data, err := root.CanonicalBytes()
+	assert.Nil(err)
+	assert.Equal(`{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`, string(data))
+
+	// RFC 8785 3.2.3
+	root, err = NewByString(`{"\u20ac": "Euro Sign", "\r": "Carriage Return", "\ufb33": "Hebrew Letter Dalet With Dagesh",
+		"1": "One", "\ud83d\ude00": "Emoji: Grinning Face", "\u0080": "Control", "\u00f6": "Latin Small Letter O With Diaeresis"}`)
+	assert.Nil(err)
+
+	data, err = root.CanonicalBytes()
+	assert.Nil(err)
+	assert.Equal("{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\","+
+		"\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}", string(data))
+
+	// same bytes whatever the number representation
+	a := New(map[string]interface{}{"n": 1, "m": json.Number("1.0")})
+	b := New(map[string]interface{}{"m": 1.0, "n": json.Number("1e0")})
+
+	da, _ := a.CanonicalBytes()
+	db, _ := b.CanonicalBytes()
+	assert.Equal(`{"m":1,"n":1}`, string(da))
+	assert.Equal(da, db)
+
+	_, err = New(math.NaN()).CanonicalBytes()
+	assert.NotNil(err)
+
+	_, err = New("\xff").CanonicalBytes()
+	assert.NotNil(err)
+}
+
+func TestJCSNumber(t *testing.T) {
+
+	assert := assert.New(t)
+
+	cases := map[float64]string{
+		0:                      "0",
+		math.Copysign(0, -1):   "0",
+		1:                      "1",
+		-1.5:                   "-1.5",
+		1e21:                   "1e+21",
+		1e20:                   "100000000000000000000",
+		123456789012345680000:  "123456789012345680000",
+		0.000001:               "0.000001",
+		1e-7:                   "1e-7",
+		-1.2345e-10:            "-1.2345e-10",
+		9007199254740992:       "9007199254740992",
+		5e-324:                 "5e-324",
+		1.7976931348623157e308: "1.7976931348623157e+308",
+	}
+
+	for f, want := range cases {
+		s, err := jcsNumber(f)
+		assert.Nil(err)
+		assert.Equal(want, s, "%v", f)
+	}
+}
+
+func TestHash(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"db": {"host": "h", "port": 5432}, "web": {"port": 80}}`)
+	assert.Nil(err)
+
+	sum, err := root.Select("db").Hash(sha256.New())
+	assert.Nil(err)
+
+	want := sha256.Sum256([]byte(`{"host":"h","port":5432}`))
+	assert.Equal(hex.EncodeToString(want[:]), hex.EncodeToString(sum))
+
+	other, err := NewByString(`{"web": {"port": 81}, "db": {"port": 5432.0, "host": "h"}}`)
+	assert.Nil(err)
+
+	same, err := other.Select("db").Hash(sha256.New())
+	assert.Nil(err)
+	assert.Equal(sum, same)
+
+	a, _ := root.Select("web").Hash(sha256.New())
+	b, _ := other.Select("web").Hash(sha256.New())
+	assert.NotEqual(a, b)
+
+	_, err = New(math.Inf(1)).Hash(sha256.New())
+	assert.NotNil(err)
+}
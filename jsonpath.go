@@ -0,0 +1,753 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	jpName = iota
+	jpIndex
+	jpWildcard
+	jpSlice
+	jpFilter
+)
+
+type jpSelector struct {
+	kind   int
+	name   string
+	index  int
+	slice  [3]*int
+	filter jpExpr
+}
+
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+type jpExpr interface {
+	eval(root, cur *JSONElement) (interface{}, bool)
+}
+
+type jpParser struct {
+	src string
+	pos int
+}
+
+func (me *jpParser) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("pos=[%d]: %s", me.pos, fmt.Sprintf(format, a...))
+}
+
+func (me *jpParser) eof() bool {
+	return me.pos >= len(me.src)
+}
+
+func (me *jpParser) peek() byte {
+
+	if me.eof() {
+		return 0
+	}
+
+	return me.src[me.pos]
+}
+
+func (me *jpParser) skipSpace() {
+
+	for !me.eof() && (me.src[me.pos] == ' ' || me.src[me.pos] == '\t') {
+		me.pos++
+	}
+}
+
+func (me *jpParser) consume(s string) bool {
+
+	me.skipSpace()
+
+	if strings.HasPrefix(me.src[me.pos:], s) {
+		me.pos += len(s)
+		return true
+	}
+
+	return false
+}
+
+func (me *jpParser) name() (string, error) {
+
+	start := me.pos
+
+	for !me.eof() {
+		r := rune(me.src[me.pos])
+		if r != '_' && r != '-' && r != '$' && r < 0x80 && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		me.pos++
+	}
+
+	if start == me.pos {
+		return "", me.errorf("Name Expected")
+	}
+
+	return me.src[start:me.pos], nil
+}
+
+func (me *jpParser) quoted() (string, error) {
+
+	quote := me.peek()
+	b := strings.Builder{}
+
+	for me.pos++; !me.eof(); me.pos++ {
+
+		c := me.src[me.pos]
+
+		switch {
+		case c == quote:
+			me.pos++
+			return b.String(), nil
+		case c == '\\' && me.pos+1 < len(me.src):
+			me.pos++
+			b.WriteByte(me.src[me.pos])
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", me.errorf("Unterminated String")
+}
+
+func (me *jpParser) integer() (*int, error) {
+
+	me.skipSpace()
+	start := me.pos
+
+	if me.peek() == '-' {
+		me.pos++
+	}
+
+	for !me.eof() && me.src[me.pos] >= '0' && me.src[me.pos] <= '9' {
+		me.pos++
+	}
+
+	if start == me.pos {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(me.src[start:me.pos])
+	if err != nil {
+		return nil, me.errorf("Bad Integer: %s", me.src[start:me.pos])
+	}
+
+	return &n, nil
+}
+
+func (me *jpParser) selector() (jpSelector, error) {
+
+	me.skipSpace()
+
+	switch c := me.peek(); {
+	case c == '\'' || c == '"':
+		name, err := me.quoted()
+		return jpSelector{kind: jpName, name: name}, err
+
+	case c == '*':
+		me.pos++
+		return jpSelector{kind: jpWildcard}, nil
+
+	case c == '?':
+		me.pos++
+
+		paren := me.consume("(")
+
+		expr, err := me.or()
+		if err != nil {
+			return jpSelector{}, err
+		}
+
+		if paren && !me.consume(")") {
+			return jpSelector{}, me.errorf(") Expected")
+		}
+
+		return jpSelector{kind: jpFilter, filter: expr}, nil
+	}
+
+	sel := jpSelector{kind: jpSlice}
+
+	for i := 0; i < 3; i++ {
+
+		n, err := me.integer()
+		if err != nil {
+			return sel, err
+		}
+
+		sel.slice[i] = n
+
+		if i == 0 && !strings.HasPrefix(me.src[me.pos:], ":") {
+
+			if n == nil {
+				return sel, me.errorf("Selector Expected")
+			}
+
+			return jpSelector{kind: jpIndex, index: *n}, nil
+		}
+
+		if !me.consume(":") {
+			break
+		}
+	}
+
+	return sel, nil
+}
+
+func (me *jpParser) bracket() ([]jpSelector, error) {
+
+	selectors := []jpSelector{}
+
+	for {
+		sel, err := me.selector()
+		if err != nil {
+			return nil, err
+		}
+
+		selectors = append(selectors, sel)
+
+		if me.consume("]") {
+			return selectors, nil
+		}
+
+		if !me.consume(",") {
+			return nil, me.errorf("] Expected")
+		}
+	}
+}
+
+// segments ... func
+// parses segments until something that is not part of a path.
+func (me *jpParser) segments() ([]jpSegment, error) {
+
+	segments := []jpSegment{}
+
+	for !me.eof() {
+
+		seg := jpSegment{}
+
+		switch {
+		case strings.HasPrefix(me.src[me.pos:], ".."):
+			me.pos += 2
+			seg.descendant = true
+
+			if me.peek() == '[' {
+				me.pos++
+
+				sels, err := me.bracket()
+				if err != nil {
+					return nil, err
+				}
+
+				seg.selectors = sels
+				break
+			}
+
+			fallthrough
+
+		case me.peek() == '.':
+			if !seg.descendant {
+				me.pos++
+			}
+
+			if me.peek() == '*' {
+				me.pos++
+				seg.selectors = []jpSelector{{kind: jpWildcard}}
+				break
+			}
+
+			name, err := me.name()
+			if err != nil {
+				return nil, err
+			}
+
+			seg.selectors = []jpSelector{{kind: jpName, name: name}}
+
+		case me.peek() == '[':
+			me.pos++
+
+			sels, err := me.bracket()
+			if err != nil {
+				return nil, err
+			}
+
+			seg.selectors = sels
+
+		default:
+			return segments, nil
+		}
+
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+// ---------------------------------------------------------------------------
+
+type jpPath struct {
+	absolute bool
+	segments []jpSegment
+}
+
+func (me jpPath) eval(root, cur *JSONElement) (interface{}, bool) {
+
+	start := cur
+	if me.absolute {
+		start = root
+	}
+
+	found := jpEvalSegments(root, []*JSONElement{start}, me.segments)
+	if len(found) == 0 {
+		return nil, false
+	}
+
+	return found[0].Raw(), true
+}
+
+type jpLiteral struct {
+	val interface{}
+}
+
+func (me jpLiteral) eval(root, cur *JSONElement) (interface{}, bool) {
+	return me.val, true
+}
+
+type jpNot struct {
+	expr jpExpr
+}
+
+func (me jpNot) eval(root, cur *JSONElement) (interface{}, bool) {
+	return !jpTruthy(me.expr.eval(root, cur)), true
+}
+
+type jpBinary struct {
+	op          string
+	left, right jpExpr
+}
+
+func jpTruthy(val interface{}, ok bool) bool {
+
+	if b, isBool := val.(bool); isBool {
+		return ok && b
+	}
+
+	return ok
+}
+
+func jpNumber(val interface{}) (float64, bool) {
+
+	switch typed := val.(type) {
+	case float64:
+		return typed, true
+	case int:
+		return float64(typed), true
+	case json.Number:
+		f, err := typed.Float64()
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+func jpCompare(op string, a, b interface{}) bool {
+
+	if fa, ok := jpNumber(a); ok {
+		if fb, ok := jpNumber(b); ok {
+			switch op {
+			case "==":
+				return fa == fb
+			case "!=":
+				return fa != fb
+			case "<":
+				return fa < fb
+			case "<=":
+				return fa <= fb
+			case ">":
+				return fa > fb
+			case ">=":
+				return fa >= fb
+			}
+		}
+	}
+
+	if sa, ok := a.(string); ok {
+		if sb, ok := b.(string); ok {
+			switch op {
+			case "==":
+				return sa == sb
+			case "!=":
+				return sa != sb
+			case "<":
+				return sa < sb
+			case "<=":
+				return sa <= sb
+			case ">":
+				return sa > sb
+			case ">=":
+				return sa >= sb
+			}
+		}
+	}
+
+	switch a.(type) {
+	case nil, bool:
+		switch op {
+		case "==":
+			return a == b
+		case "!=":
+			return a != b
+		}
+	}
+
+	return op == "!="
+}
+
+func (me jpBinary) eval(root, cur *JSONElement) (interface{}, bool) {
+
+	switch me.op {
+	case "&&":
+		return jpTruthy(me.left.eval(root, cur)) && jpTruthy(me.right.eval(root, cur)), true
+	case "||":
+		return jpTruthy(me.left.eval(root, cur)) || jpTruthy(me.right.eval(root, cur)), true
+	}
+
+	a, okA := me.left.eval(root, cur)
+	b, okB := me.right.eval(root, cur)
+
+	if !okA || !okB {
+		return me.op == "!=" && okA != okB, true
+	}
+
+	return jpCompare(me.op, a, b), true
+}
+
+func (me *jpParser) or() (jpExpr, error) {
+
+	left, err := me.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for me.consume("||") {
+
+		right, err := me.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = jpBinary{op: "||", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (me *jpParser) and() (jpExpr, error) {
+
+	left, err := me.comparison()
+	if err != nil {
+		return nil, err
+	}
+
+	for me.consume("&&") {
+
+		right, err := me.comparison()
+		if err != nil {
+			return nil, err
+		}
+
+		left = jpBinary{op: "&&", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (me *jpParser) comparison() (jpExpr, error) {
+
+	left, err := me.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+
+		if me.consume(op) {
+
+			right, err := me.primary()
+			if err != nil {
+				return nil, err
+			}
+
+			return jpBinary{op: op, left: left, right: right}, nil
+		}
+	}
+
+	return left, nil
+}
+
+func (me *jpParser) primary() (jpExpr, error) {
+
+	me.skipSpace()
+
+	switch c := me.peek(); {
+	case c == '!':
+		me.pos++
+
+		expr, err := me.primary()
+		if err != nil {
+			return nil, err
+		}
+
+		return jpNot{expr}, nil
+
+	case c == '(':
+		me.pos++
+
+		expr, err := me.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if !me.consume(")") {
+			return nil, me.errorf(") Expected")
+		}
+
+		return expr, nil
+
+	case c == '@' || c == '$':
+		me.pos++
+
+		segments, err := me.segments()
+		if err != nil {
+			return nil, err
+		}
+
+		return jpPath{absolute: c == '$', segments: segments}, nil
+
+	case c == '\'' || c == '"':
+		str, err := me.quoted()
+		return jpLiteral{str}, err
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		start := me.pos
+		for me.pos++; !me.eof() && strings.IndexByte("0123456789.eE+-", me.src[me.pos]) >= 0; me.pos++ {
+		}
+
+		f, err := strconv.ParseFloat(me.src[start:me.pos], 64)
+		if err != nil {
+			return nil, me.errorf("Bad Number: %s", me.src[start:me.pos])
+		}
+
+		return jpLiteral{f}, nil
+	}
+
+	for word, val := range map[string]interface{}{"true": true, "false": false, "null": nil} {
+		if me.consume(word) {
+			return jpLiteral{val}, nil
+		}
+	}
+
+	return nil, me.errorf("Expression Expected")
+}
+
+// ---------------------------------------------------------------------------
+
+func jpChildren(elm *JSONElement) []*JSONElement {
+
+	raw, err := resolveSpill(elm.raw)
+	if err != nil {
+		return nil
+	}
+
+	children := []*JSONElement{}
+
+	if arr := asSlice(raw); arr != nil {
+		for i := range arr {
+			children = append(children, elm.SelectByPos(i))
+		}
+	}
+
+	if obj, ok := raw.(map[string]interface{}); ok {
+
+		less := elm.KeyLess
+		if less == nil {
+			less = LexicalLess
+		}
+
+		for _, k := range elm.order.keys(obj, less) {
+			children = append(children, elm.SelectByKey(k))
+		}
+	}
+
+	return children
+}
+
+func jpSliceBounds(sel jpSelector, n int) (int, int, int) {
+
+	step := 1
+	if sel.slice[2] != nil {
+		step = *sel.slice[2]
+	}
+
+	norm := func(p *int, def int) int {
+
+		if p == nil {
+			return def
+		}
+
+		v := *p
+		if v < 0 {
+			v += n
+		}
+
+		switch {
+		case v < -1:
+			return -1
+		case v > n:
+			return n
+		}
+
+		return v
+	}
+
+	if step > 0 {
+
+		start, end := norm(sel.slice[0], 0), norm(sel.slice[1], n)
+		if start < 0 {
+			start = 0
+		}
+		if end < 0 {
+			end = 0
+		}
+
+		return start, end, step
+	}
+
+	start, end := norm(sel.slice[0], n-1), norm(sel.slice[1], -1)
+	if start >= n {
+		start = n - 1
+	}
+
+	return start, end, step
+}
+
+func jpApply(root, elm *JSONElement, sel jpSelector) []*JSONElement {
+
+	ret := []*JSONElement{}
+
+	switch sel.kind {
+	case jpName:
+		if obj, ok := elm.raw.(map[string]interface{}); ok {
+			if _, exists := obj[sel.name]; exists {
+				ret = append(ret, elm.SelectByKey(sel.name))
+			}
+		}
+
+	case jpIndex:
+		if arr := asSlice(elm.raw); arr != nil {
+
+			pos := sel.index
+			if pos < 0 {
+				pos += len(arr)
+			}
+
+			if pos >= 0 && pos < len(arr) {
+				ret = append(ret, elm.SelectByPos(pos))
+			}
+		}
+
+	case jpWildcard:
+		ret = append(ret, jpChildren(elm)...)
+
+	case jpSlice:
+		if arr := asSlice(elm.raw); arr != nil && (sel.slice[2] == nil || *sel.slice[2] != 0) {
+
+			start, end, step := jpSliceBounds(sel, len(arr))
+
+			for i := start; (step > 0 && i < end) || (step < 0 && i > end); i += step {
+				ret = append(ret, elm.SelectByPos(i))
+			}
+		}
+
+	case jpFilter:
+		for _, v := range jpChildren(elm) {
+			if jpTruthy(sel.filter.eval(root, v)) {
+				ret = append(ret, v)
+			}
+		}
+	}
+
+	return ret
+}
+
+func jpDescendants(elm *JSONElement) []*JSONElement {
+
+	ret := []*JSONElement{elm}
+
+	for _, v := range jpChildren(elm) {
+		ret = append(ret, jpDescendants(v)...)
+	}
+
+	return ret
+}
+
+func jpEvalSegments(root *JSONElement, nodes []*JSONElement, segments []jpSegment) []*JSONElement {
+
+	for _, seg := range segments {
+
+		targets := nodes
+		if seg.descendant {
+
+			targets = []*JSONElement{}
+			for _, v := range nodes {
+				targets = append(targets, jpDescendants(v)...)
+			}
+		}
+
+		next := []*JSONElement{}
+
+		for _, v := range targets {
+			for _, sel := range seg.selectors {
+				next = append(next, jpApply(root, v, sel)...)
+			}
+		}
+
+		nodes = next
+	}
+
+	return nodes
+}
+
+// SelectByJSONPath ... func
+// returns the elements matching a JSONPath expression evaluated against me as "$", e.g.
+// "$.store.book[*].author", "$..price", "$.book[?(@.price < 10 && @.isbn)]", "$.book[-1:]".
+// Map members are visited in KeyLess (default lexical) order.
+func (me *JSONElement) SelectByJSONPath(expr string) ([]*JSONElement, error) {
+
+	if me.IsNil() {
+		return nil, me.fail("SelectByJSONPath", ErrorNilObject, "")
+	}
+
+	p := &jpParser{src: strings.TrimSpace(expr)}
+
+	if !p.consume("$") {
+		return nil, me.Errorf("SelectByJSONPath: %s: Not Start With $", expr)
+	}
+
+	segments, err := p.segments()
+	if err != nil {
+		return nil, me.Errorf("SelectByJSONPath: %s: %w", expr, err)
+	}
+
+	if p.skipSpace(); !p.eof() {
+		return nil, me.Errorf("SelectByJSONPath: %s: %w", expr, p.errorf("Unexpected: %s", p.src[p.pos:]))
+	}
+
+	return jpEvalSegments(me, []*JSONElement{me}, segments), nil
+}
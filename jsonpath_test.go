@@ -0,0 +1,90 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectByJSONPath(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"store": {
+		"book": [
+			{"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+			{"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+			{"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+			{"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	}, "limit": 10}`)
+	assert.Nil(err)
+
+	strs := func(elms []*JSONElement) []string {
+		ret := []string{}
+		for _, v := range elms {
+			ret = append(ret, v.AsString())
+		}
+		return ret
+	}
+
+	found, err := root.SelectByJSONPath("$.store.book[*].author")
+	assert.Nil(err)
+	assert.Equal([]string{"Nigel Rees", "Evelyn Waugh", "Herman Melville", "J. R. R. Tolkien"}, strs(found))
+	assert.Equal([]interface{}{"store", "book", 0, "author"}, found[0].FullPath())
+
+	found, err = root.SelectByJSONPath("$..author")
+	assert.Nil(err)
+	assert.Equal(4, len(found))
+
+	found, err = root.SelectByJSONPath("$.store..price")
+	assert.Nil(err)
+	assert.Equal(5, len(found))
+
+	found, err = root.SelectByJSONPath("$.store.book[?(@.price < 10)].title")
+	assert.Nil(err)
+	assert.Equal([]string{"Sayings of the Century", "Moby Dick"}, strs(found))
+
+	found, err = root.SelectByJSONPath("$..book[?(@.isbn && @.price > $.limit)].title")
+	assert.Nil(err)
+	assert.Equal([]string{"The Lord of the Rings"}, strs(found))
+
+	found, err = root.SelectByJSONPath(`$.store.book[?(@.category == 'fiction' || !@.isbn)]['title']`)
+	assert.Nil(err)
+	assert.Equal(4, len(found))
+
+	found, err = root.SelectByJSONPath("$.store.book[-1].title")
+	assert.Nil(err)
+	assert.Equal([]string{"The Lord of the Rings"}, strs(found))
+
+	found, err = root.SelectByJSONPath("$.store.book[0,2].author")
+	assert.Nil(err)
+	assert.Equal([]string{"Nigel Rees", "Herman Melville"}, strs(found))
+
+	found, err = root.SelectByJSONPath("$.store.book[1:3].price")
+	assert.Nil(err)
+	assert.Equal(2, len(found))
+
+	found, err = root.SelectByJSONPath("$.store.book[::-2].author")
+	assert.Nil(err)
+	assert.Equal([]string{"J. R. R. Tolkien", "Evelyn Waugh"}, strs(found))
+
+	found, err = root.SelectByJSONPath("$.store.*")
+	assert.Nil(err)
+	assert.Equal(2, len(found))
+	assert.True(found[0].IsMap())
+
+	found, err = root.SelectByJSONPath("$.none[0]")
+	assert.Nil(err)
+	assert.Equal(0, len(found))
+
+	found, err = root.SelectByJSONPath("$")
+	assert.Nil(err)
+	assert.Equal(1, len(found))
+
+	for _, bad := range []string{"store", "$.store[", "$.book[?(@.price <)]", "$.a b", "$['x"} {
+		_, err = root.SelectByJSONPath(bad)
+		assert.NotNil(err, bad)
+	}
+}
@@ -0,0 +1,91 @@
+package dynajson
+
+import (
+	"sort"
+	"unicode"
+)
+
+// LexicalLess ... func
+// byte-wise order, the default of EachMap.
+func LexicalLess(a, b string) bool {
+	return a < b
+}
+
+func digitRun(runes []rune, i int) int {
+
+	j := i
+	for j < len(runes) && unicode.IsDigit(runes[j]) {
+		j++
+	}
+
+	return j
+}
+
+// NaturalLess ... func
+// compares digit runs by numeric value, so "item9" < "item10".
+func NaturalLess(a, b string) bool {
+
+	ra, rb := []rune(a), []rune(b)
+	i, j := 0, 0
+
+	for i < len(ra) && j < len(rb) {
+
+		if unicode.IsDigit(ra[i]) && unicode.IsDigit(rb[j]) {
+
+			ei, ej := digitRun(ra, i), digitRun(rb, j)
+
+			// strip leading zeros, then longer means larger
+			si, sj := i, j
+			for si < ei-1 && ra[si] == '0' {
+				si++
+			}
+			for sj < ej-1 && rb[sj] == '0' {
+				sj++
+			}
+
+			if ei-si != ej-sj {
+				return ei-si < ej-sj
+			}
+
+			for k := 0; k < ei-si; k++ {
+				if ra[si+k] != rb[sj+k] {
+					return ra[si+k] < rb[sj+k]
+				}
+			}
+
+			if ei-i != ej-j {
+				return ei-i < ej-j
+			}
+
+			i, j = ei, ej
+			continue
+		}
+
+		if ra[i] != rb[j] {
+			return ra[i] < rb[j]
+		}
+
+		i++
+		j++
+	}
+
+	return len(ra)-i < len(rb)-j
+}
+
+// mapKeys ... func
+// returns the keys ordered by less, or in map order when less is nil.
+func mapKeys(obj map[string]interface{}, less func(string, string) bool) []string {
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+
+	if less != nil {
+		sort.Slice(keys, func(i, j int) bool {
+			return less(keys[i], keys[j])
+		})
+	}
+
+	return keys
+}
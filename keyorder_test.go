@@ -0,0 +1,60 @@
+package dynajson
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNaturalLess(t *testing.T) {
+
+	assert := assert.New(t)
+
+	keys := []string{"item10", "item9", "item1", "a", "item010", "item2b", "item2a", "b1"}
+	sort.Slice(keys, func(i, j int) bool {
+		return NaturalLess(keys[i], keys[j])
+	})
+
+	assert.Equal([]string{"a", "b1", "item1", "item2a", "item2b", "item9", "item10", "item010"}, keys)
+	assert.False(NaturalLess("x", "x"))
+}
+
+func TestKeyLess(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"item10": 1, "item9": {"z": 1, "y2": 2, "y10": 3}, "item1": 3}`)
+	assert.Nil(err)
+
+	order := []string{}
+	root.EachMap(func(key string, elm *JSONElement) (bool, error) {
+		order = append(order, key)
+		return true, nil
+	})
+	assert.Equal([]string{"item1", "item10", "item9"}, order)
+
+	root.KeyLess = NaturalLess
+
+	order = []string{}
+	root.EachMap(func(key string, elm *JSONElement) (bool, error) {
+		order = append(order, key)
+		return true, nil
+	})
+	assert.Equal([]string{"item1", "item9", "item10"}, order)
+	assert.Equal(order, root.Keys())
+	assert.Equal([]string{"y2", "y10", "z"}, root.Select("item9").Keys())
+	assert.Equal(`{"item1": 3, "item9": {"y2": 2, "y10": 3, "z": 1}, "item10": 1}`, root.String())
+
+	root.KeyLess = func(a, b string) bool {
+		return strings.ToLower(a) > strings.ToLower(b)
+	}
+	assert.Equal([]string{"item9", "item10", "item1"}, root.Keys())
+
+	buf := &bytes.Buffer{}
+	raw := root.Select("item9").Raw()
+	DumpSorted(&raw, buf, nil)
+	assert.Equal(`{"y10": 3, "y2": 2, "z": 1}`, buf.String())
+}
@@ -0,0 +1,242 @@
+package dynajson
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// KeyStyle ... type
+type KeyStyle int
+
+const (
+	// CamelCase ... fooBarBaz
+	CamelCase KeyStyle = iota
+	// SnakeCase ... foo_bar_baz
+	SnakeCase
+	// KebabCase ... foo-bar-baz
+	KebabCase
+	// PascalCase ... FooBarBaz
+	PascalCase
+)
+
+// splitWords ... func
+// "HTTPServer_id-2x" -> ["HTTP", "Server", "id", "2x"]
+func splitWords(key string) []string {
+
+	words := []string{}
+	runes := []rune(key)
+	start := 0
+
+	flush := func(end int) {
+		if end > start {
+			words = append(words, string(runes[start:end]))
+		}
+		start = end
+	}
+
+	for i := 0; i < len(runes); i++ {
+
+		r := runes[i]
+
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			flush(i)
+			start = i + 1
+		case i > start && unicode.IsUpper(r):
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush(i)
+			}
+		}
+	}
+
+	flush(len(runes))
+
+	return words
+}
+
+func capitalize(word string) string {
+
+	runes := []rune(strings.ToLower(word))
+	if len(runes) > 0 {
+		runes[0] = unicode.ToUpper(runes[0])
+	}
+
+	return string(runes)
+}
+
+// ConvertKeyStyle ... func
+func ConvertKeyStyle(key string, style KeyStyle) string {
+
+	words := splitWords(key)
+	if len(words) == 0 {
+		return key
+	}
+
+	switch style {
+	case SnakeCase, KebabCase:
+		for i, v := range words {
+			words[i] = strings.ToLower(v)
+		}
+
+		sep := "_"
+		if style == KebabCase {
+			sep = "-"
+		}
+
+		return strings.Join(words, sep)
+
+	case CamelCase, PascalCase:
+		for i, v := range words {
+			words[i] = capitalize(v)
+		}
+
+		if style == CamelCase {
+			words[0] = strings.ToLower(words[0])
+		}
+
+		return strings.Join(words, "")
+	}
+
+	return key
+}
+
+// ConvertKeys ... func
+// renames the keys of all nested maps to style. A key whose path (relative to me)
+// matches an exclude pattern (see MatchPath) keeps its name and its subtree is left as is.
+// When two keys of a map would have the same name, nothing is renamed and the
+// error (ErrKeyExists) lists the conflicts.
+func (me *JSONElement) ConvertKeys(style KeyStyle, excludes ...string) (int, error) {
+
+	if me.IsNil() {
+		return 0, me.fail("ConvertKeys", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return 0, me.fail("ConvertKeys", ErrorReadonly, "")
+	}
+
+	me.unshareAll()
+
+	excluded := func(rel []interface{}) bool {
+		return matchAnyPath(excludes, rel)
+	}
+
+	// the new names of every map, checked before anything is renamed
+	renames := map[uintptr]map[string]string{}
+	objs := map[uintptr]map[string]interface{}{}
+	conflicts := []string{}
+	var limitErr error
+
+	var plan func(raw interface{}, rel []interface{})
+	plan = func(raw interface{}, rel []interface{}) {
+
+		if typedObj, ok := raw.(map[string]interface{}); ok {
+
+			keys := make([]string, 0, len(typedObj))
+			for k := range typedObj {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			renamed := map[string]string{}
+			owner := map[string]string{}
+
+			for _, k := range keys {
+
+				conv := k
+				if !excluded(appendParents(rel, k)) {
+					conv = ConvertKeyStyle(k, style)
+				}
+
+				if err := me.checkKeyLimits(conv); err != nil && conv != k && limitErr == nil {
+					limitErr = fmt.Errorf("%s: [%s] -> [%s]: %w", FullPath2Str(rel, "/"), k, conv, err)
+				}
+
+				if prev, ok := owner[conv]; ok {
+					conflicts = append(conflicts, fmt.Sprintf("%s: [%s] [%s] -> [%s]", FullPath2Str(rel, "/"), prev, k, conv))
+					continue
+				}
+
+				owner[conv] = k
+
+				if conv != k {
+					renamed[k] = conv
+				}
+			}
+
+			if len(renamed) > 0 {
+				id := rawAddr(typedObj)
+				renames[id] = renamed
+				objs[id] = typedObj
+			}
+		}
+
+		entries, err := walkEntries(rel, raw)
+		if err != nil {
+			me.Warn("ConvertKeys: %v", err)
+			return
+		}
+
+		for _, v := range entries {
+
+			subRel := appendParents(rel, v.key)
+			if _, ok := v.key.(string); ok && excluded(subRel) {
+				continue
+			}
+
+			plan(v.val, subRel)
+		}
+	}
+
+	plan(me.raw, []interface{}{})
+
+	if limitErr != nil {
+		return 0, me.Errorf("ConvertKeys: %w", limitErr)
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return 0, me.fail("ConvertKeys", ErrorKeyExists, "%s", strings.Join(conflicts, ", "))
+	}
+
+	count := 0
+
+	for id, renamed := range renames {
+
+		typedObj := objs[id]
+
+		if me.order != nil && me.order.known(typedObj) {
+			keys := me.order.keys(typedObj, LexicalLess)
+			for i, k := range keys {
+				if conv, ok := renamed[k]; ok {
+					keys[i] = conv
+				}
+			}
+
+			me.order.record(typedObj, keys)
+		}
+
+		// a new name may be the old name of another key
+		vals := make(map[string]interface{}, len(renamed))
+		for k := range renamed {
+			vals[k] = typedObj[k]
+			delete(typedObj, k)
+		}
+
+		for k, conv := range renamed {
+			typedObj[conv] = vals[k]
+			count++
+		}
+	}
+
+	if count > 0 {
+		me.notifyReplaced()
+	}
+
+	return count, nil
+}
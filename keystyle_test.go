@@ -0,0 +1,77 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertKeyStyle(t *testing.T) {
+
+	assert := assert.New(t)
+
+	assert.Equal([]string{"HTTP", "Server", "id", "2x"}, splitWords("HTTPServer_id-2x"))
+
+	assert.Equal("user_id", ConvertKeyStyle("userId", SnakeCase))
+	assert.Equal("http_server", ConvertKeyStyle("HTTPServer", SnakeCase))
+	assert.Equal("user-name", ConvertKeyStyle("user_name", KebabCase))
+	assert.Equal("userName", ConvertKeyStyle("user-name", CamelCase))
+	assert.Equal("UserName", ConvertKeyStyle("user_name", PascalCase))
+	assert.Equal("apiKey2", ConvertKeyStyle("API_KEY2", CamelCase))
+	assert.Equal("name", ConvertKeyStyle("name", CamelCase))
+}
+
+func TestConvertKeys(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{
+		"userId": 1,
+		"homeAddress": {"zipCode": "x", "lines": [{"lineNo": 1}]},
+		"customHeaders": {"X-Request-Id": "a"},
+		"user_id": 2
+	}`)
+	assert.Nil(err)
+
+	// userId and user_id conflict, nothing is renamed
+	count, err := root.ConvertKeys(SnakeCase, "custom_headers", "customHeaders")
+	assert.True(errors.Is(err, ErrKeyExists))
+	assert.Contains(err.Error(), "[userId] [user_id] -> [user_id]")
+	assert.Equal(0, count)
+	assert.Equal("x", root.Select("homeAddress", "zipCode").AsString())
+
+	assert.Nil(root.DeleteByKey("user_id"))
+
+	count, err = root.ConvertKeys(SnakeCase, "custom_headers", "customHeaders")
+	assert.Nil(err)
+	assert.Equal(4, count)
+
+	assert.Equal(1, root.Select("user_id").AsInt())
+	assert.Equal("x", root.Select("home_address", "zip_code").AsString())
+	assert.Equal(1, root.Select("home_address", "lines", 0, "line_no").AsInt())
+	assert.Equal("a", root.Select("customHeaders", "X-Request-Id").AsString())
+}
+
+func TestConvertKeysOrder(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesWithOptions([]byte(`{"zipCode": 1, "a": {"lineNo": 2, "b": 3}, "userId": 4}`), ParseOptions{PreserveOrder: true})
+	assert.Nil(err)
+
+	count, err := root.ConvertKeys(SnakeCase)
+	assert.Nil(err)
+	assert.Equal(3, count)
+	assert.Equal(`{"zip_code": 1, "a": {"line_no": 2, "b": 3}, "user_id": 4}`, root.String())
+
+	// a new name may be the old name of another key
+	root, err = NewByString(`{"a": {"fooBar": 1, "foo_bar_x": 2}}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	count, err = root.ConvertKeys(CamelCase, "a/fooBar")
+	assert.Nil(err)
+	assert.Equal(1, count)
+	assert.Equal(`{"a": {"fooBar": 1, "fooBarX": 2}}`, root.String())
+}
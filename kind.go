@@ -0,0 +1,59 @@
+package dynajson
+
+import "encoding/json"
+
+// Kind ... type
+type Kind int
+
+const (
+	// KindNull ... null
+	KindNull Kind = iota
+	// KindBool ... true / false
+	KindBool
+	// KindNumber ... int / float64
+	KindNumber
+	// KindString ... string
+	KindString
+	// KindArray ... []interface{} / *[]interface{}
+	KindArray
+	// KindMap ... map[string]interface{}
+	KindMap
+	// KindUnknown ... anything else
+	KindUnknown
+)
+
+var kindNames = []string{"null", "bool", "number", "string", "array", "map", "unknown"}
+
+func (me Kind) String() string {
+
+	if me < 0 || int(me) >= len(kindNames) {
+		return kindNames[KindUnknown]
+	}
+
+	return kindNames[me]
+}
+
+func kindOf(arg interface{}) Kind {
+
+	switch arg.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBool
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return KindNumber
+	case string:
+		return KindString
+	case []interface{}, *[]interface{}:
+		return KindArray
+	case map[string]interface{}:
+		return KindMap
+	}
+
+	return KindUnknown
+}
+
+// Kind ... func
+func (me *JSONElement) Kind() Kind {
+	return kindOf(me.Raw())
+}
@@ -0,0 +1,139 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LazyRefOptions ... struct
+type LazyRefOptions struct {
+	// Base is the path or URL of the document, relative $ref are resolved against it.
+	Base string
+	// Fetcher nil means GetDefaultFetcher().
+	Fetcher Fetcher
+}
+
+type lazyRefState struct {
+	base string
+	ws   *Workspace
+}
+
+// EnableLazyRefs ... func
+// makes Select on elements derived from me afterwards replace a {"$ref": "other.json#/path"}
+// object with the referenced value on first access. Documents are fetched once and cached,
+// the value is spliced into the tree so later access (and Walk, String ...) sees it.
+// Local "#/..." refs are left as is.
+func (me *JSONElement) EnableLazyRefs(opts LazyRefOptions) {
+
+	ws := NewWorkspace()
+	ws.Fetcher = opts.Fetcher
+
+	me.lazyRefs = &lazyRefState{
+		base: opts.Base,
+		ws:   ws,
+	}
+}
+
+// NewByPathLazyRefs ... func
+func NewByPathLazyRefs(argPath string) (*JSONElement, error) {
+
+	root, err := NewByPath(argPath)
+	if err != nil {
+		return nil, fmt.Errorf("NewByPathLazyRefs: %w", err)
+	}
+
+	root.EnableLazyRefs(LazyRefOptions{Base: argPath})
+
+	return root, nil
+}
+
+func remoteRef(raw interface{}) (string, bool) {
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+
+	ref, ok := obj["$ref"].(string)
+	if !ok || ref == "" || strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+
+	return ref, true
+}
+
+// absolutizeRefs ... func
+// rewrites the refs of a subtree copied out of the document name so they still
+// point at the same place once spliced into another document.
+func (me *lazyRefState) absolutizeRefs(raw interface{}, name string) {
+
+	entries, err := walkEntries(nil, raw)
+	if err != nil {
+		return
+	}
+
+	for _, v := range entries {
+
+		if ref, ok := v.val.(string); ok && v.key == "$ref" {
+
+			docRef, fragment := ref, ""
+			if i := strings.Index(ref, "#"); i >= 0 {
+				docRef, fragment = ref[:i], ref[i:]
+			}
+
+			setContainerRaw(raw, v.key, me.ws.resolveName(name, docRef)+fragment)
+			continue
+		}
+
+		me.absolutizeRefs(v.val, name)
+	}
+}
+
+func (me *lazyRefState) resolve(raw interface{}) (interface{}, error) {
+
+	from := me.base
+
+	for i := 0; i < 32; i++ {
+
+		ref, ok := remoteRef(raw)
+		if !ok {
+			return raw, nil
+		}
+
+		name, elm, err := me.ws.Resolve(context.Background(), from, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if raw, err = copyRawChecked(elm.Raw()); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		me.absolutizeRefs(raw, name)
+		from = name
+	}
+
+	return nil, fmt.Errorf("Too Many Indirections")
+}
+
+// resolveLazyRef ... func
+// called by child, returns raw with a remote $ref resolved and spliced into me.
+func (me *JSONElement) resolveLazyRef(key, raw interface{}) interface{} {
+
+	if _, ok := remoteRef(raw); !ok {
+		return raw
+	}
+
+	resolved, err := me.lazyRefs.resolve(raw)
+	if err != nil {
+		me.Warn("child(%v): $ref: %v", key, err)
+		return raw
+	}
+
+	if !me.isReadonly() {
+		setContainerRaw(me.raw, key, resolved)
+	}
+
+	return resolved
+}
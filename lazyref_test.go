@@ -0,0 +1,50 @@
+package dynajson
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLazyRefs(t *testing.T) {
+
+	assert := assert.New(t)
+
+	fetched := []string{}
+	canned := MapFetcher{
+		"https://example.com/specs/api.json":        []byte(`{"paths": {"/pets": {"$ref": "paths/pets.json"}, "/err": {"$ref": "#/components/Error"}}}`),
+		"https://example.com/specs/paths/pets.json": []byte(`{"get": {"schema": {"$ref": "../models.json#/Pet"}}}`),
+		"https://example.com/specs/models.json":     []byte(`{"Pet": {"type": "object", "properties": {"owner": {"$ref": "#/Owner"}}}, "Owner": {"type": "string"}}`),
+	}
+	fetcher := FetcherFunc(func(ctx context.Context, argPath string) (*FetchResult, error) {
+		fetched = append(fetched, argPath)
+		return canned.Fetch(ctx, argPath)
+	})
+
+	root, err := NewByPathWithFetcher("https://example.com/specs/api.json", fetcher)
+	assert.Nil(err)
+	root.EnableLazyRefs(LazyRefOptions{Base: "https://example.com/specs/api.json", Fetcher: fetcher})
+	fetched = fetched[:0]
+
+	pets := root.Select("paths", "/pets")
+	assert.Equal([]string{"https://example.com/specs/paths/pets.json"}, fetched)
+
+	get := pets.Raw().(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal("https://example.com/specs/models.json#/Pet", get["schema"].(map[string]interface{})["$ref"])
+
+	schema := root.Select("paths", "/pets", "get", "schema")
+	assert.Equal("object", schema.Select("type").AsString())
+	assert.Equal(2, len(fetched))
+
+	props := schema.Select("properties").Raw().(map[string]interface{})
+	assert.Equal("https://example.com/specs/models.json#/Owner", props["owner"].(map[string]interface{})["$ref"])
+	assert.Equal("string", schema.Select("properties", "owner", "type").AsString())
+	assert.Equal(2, len(fetched))
+
+	assert.Equal("#/components/Error", root.Select("paths", "/err", "$ref").AsString())
+
+	root.Select("paths", "/pets")
+	assert.Equal(2, len(fetched))
+	assert.Contains(root.String(), `"type": "object"`)
+}
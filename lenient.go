@@ -0,0 +1,180 @@
+package dynajson
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// MediaTypeJSONC ... const
+const MediaTypeJSONC = "application/jsonc"
+
+func init() {
+	// not .json5: single quotes, hex numbers and the like are not decoded
+	RegisterDecoder(MediaTypeJSONC, decodeJSONC, ".jsonc")
+}
+
+func decodeJSONC(data []byte) (interface{}, error) {
+
+	data, err := LenientToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeJSON(data)
+}
+
+// blankComments ... func
+// replaces // and /* */ comments outside strings with spaces, line breaks are
+// kept so offsets and line numbers of errors still match the source.
+func blankComments(data []byte) ([]byte, error) {
+
+	out := make([]byte, len(data))
+	copy(out, data)
+
+	inString := false
+
+	for i := 0; i < len(out); i++ {
+
+		c := out[i]
+
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		if c == '"' {
+			inString = true
+			continue
+		}
+
+		if c != '/' || i+1 >= len(out) {
+			continue
+		}
+
+		switch out[i+1] {
+		case '/':
+			for ; i < len(out) && out[i] != '\n'; i++ {
+				out[i] = ' '
+			}
+		case '*':
+			end := bytes.Index(out[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, fmt.Errorf("offset %d: Unterminated Comment", i)
+			}
+
+			last := i + 2 + end + 1
+			for ; i <= last; i++ {
+				if out[i] != '\n' && out[i] != '\r' {
+					out[i] = ' '
+				}
+			}
+			i--
+		}
+	}
+
+	return out, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || ('0' <= c && c <= '9')
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// nextToken ... func
+// the first byte from i that is not white space, 0 at the end.
+func nextToken(data []byte, i int) byte {
+
+	for ; i < len(data); i++ {
+		if !isJSONSpace(data[i]) {
+			return data[i]
+		}
+	}
+
+	return 0
+}
+
+// LenientToJSON ... func
+// converts JSONC / the JSON5 subset used by config files (tsconfig.json,
+// devcontainer.json) to JSON: // and /* */ comments are removed, trailing
+// commas dropped and unquoted keys (identifiers) quoted. Comments are not kept.
+func LenientToJSON(data []byte) ([]byte, error) {
+
+	data, err := blankComments(data)
+	if err != nil {
+		return nil, fmt.Errorf("LenientToJSON: %w", err)
+	}
+
+	out := bytes.Buffer{}
+	out.Grow(len(data))
+
+	inString := false
+
+	for i := 0; i < len(data); i++ {
+
+		c := data[i]
+
+		if inString {
+			out.WriteByte(c)
+
+			switch c {
+			case '\\':
+				if i+1 < len(data) {
+					i++
+					out.WriteByte(data[i])
+				}
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+
+		case c == ',':
+			if next := nextToken(data, i+1); next == '}' || next == ']' {
+				continue
+			}
+
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(data) && isIdentPart(data[end]) {
+				end++
+			}
+
+			if nextToken(data, end) == ':' {
+				out.WriteByte('"')
+				out.Write(data[i:end])
+				out.WriteByte('"')
+			} else {
+				out.Write(data[i:end])
+			}
+
+			i = end - 1
+			continue
+		}
+
+		out.WriteByte(c)
+	}
+
+	return out.Bytes(), nil
+}
+
+// NewByBytesLenient ... func
+// NewByBytes for JSONC / JSON5-style config files, see LenientToJSON.
+func NewByBytesLenient(data []byte) (*JSONElement, error) {
+	return NewByBytesWithOptions(data, ParseOptions{Lenient: true})
+}
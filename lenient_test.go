@@ -0,0 +1,84 @@
+package dynajson
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByBytesLenient(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := `// tsconfig.json
+{
+	/* compiler
+	   settings */
+	compilerOptions: {
+		"target": "es2017", // trailing comment
+		$strict: true,
+		"paths": {"@/*": ["src/*",],},
+		"url": "http://example.com/a//b /* not a comment */",
+	},
+	"exclude": [
+		"node_modules", // deps
+	],
+}
+`
+
+	root, err := NewByBytesLenient([]byte(src))
+	assert.Nil(err)
+
+	assert.Equal("es2017", root.Select("compilerOptions", "target").AsString())
+	assert.Equal(true, root.Select("compilerOptions", "$strict").AsBool())
+	paths, err := root.Select("compilerOptions", "paths", "@/*").AsStringSlice()
+	assert.Nil(err)
+	assert.Equal([]string{"src/*"}, paths)
+	assert.Equal("http://example.com/a//b /* not a comment */", root.Select("compilerOptions", "url").AsString())
+	assert.Equal(1, root.Select("exclude").Count())
+
+	// values named like keys stay as they are
+	root, err = NewByBytesLenient([]byte(`{a: true, b: null, c: [false,]}`))
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+	assert.Equal(`{"a": true, "b": null, "c": [false]}`, root.String())
+
+	_, err = NewByBytesLenient([]byte(`{"a": 1 /* open`))
+	assert.NotNil(err)
+
+	_, err = NewByBytes([]byte(`{"a": 1,}`))
+	assert.NotNil(err)
+
+	// with the other options
+	root, err = NewByBytesWithOptions([]byte(`{z: 1, a: 2,}`), ParseOptions{Lenient: true, PreserveOrder: true})
+	assert.Nil(err)
+	assert.Equal(`{"z": 1, "a": 2}`, root.String())
+}
+
+func TestLenientToJSON(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data, err := LenientToJSON([]byte("{\n  // c\n  k: \"a\\\"b,]\", /* x */\n}"))
+	assert.Nil(err)
+	assert.Equal("{\n      \n  \"k\": \"a\\\"b,]\"        \n}", string(data))
+}
+
+func TestNewByPathJSONC(t *testing.T) {
+
+	assert := assert.New(t)
+
+	file := filepath.Join(t.TempDir(), "devcontainer.jsonc")
+	assert.Nil(ioutil.WriteFile(file, []byte(`{name: "dev", /* image */ "image": "go",}`), 0644))
+
+	root, err := NewByPath(file)
+	assert.Nil(err)
+	assert.Equal("dev", root.Select("name").AsString())
+	assert.Equal("go", root.Select("image").AsString())
+
+	// only the JSON5 subset is decoded
+	assert.Equal(MediaTypeJSONC, ContentTypeByExt("x.jsonc"))
+	assert.NotEqual(MediaTypeJSONC, ContentTypeByExt("x.json5"))
+}
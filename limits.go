@@ -0,0 +1,222 @@
+package dynajson
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Limits ... struct
+// bounds enforced by Put, Append and the other mutators, 0 means unlimited.
+// Bulk rewrites (Merge, ApplyPatch, Expand...) are checked as a whole.
+type Limits struct {
+	// MaxNodes counts every value (containers and scalars) below the root.
+	MaxNodes int
+	// MaxArrayLen is the maximum number of elements of any array.
+	MaxArrayLen int
+	// MaxStringLen is in runes, applies to values and keys.
+	MaxStringLen int
+	// MaxDepth is the nesting depth of values below the root (children of the root are 1).
+	MaxDepth int
+}
+
+// ErrLimitExceeded ... var
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// LimitError ... struct
+type LimitError struct {
+	Limit  string
+	Max    int
+	Actual int
+}
+
+func (me *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s: %d > %d", ErrLimitExceeded, me.Limit, me.Actual, me.Max)
+}
+
+// Is ... func
+func (me *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+type limitState struct {
+	Limits
+	root *JSONElement
+	// nodes below the root, -1 when they have to be counted again
+	nodes int
+	// pending is the count checked for the change being made, -1 when none
+	pending int
+}
+
+// SetLimits ... func
+// makes me the root of the limits, elements derived from me afterwards share them.
+func (me *JSONElement) SetLimits(limits Limits) {
+
+	me.limits = &limitState{
+		Limits:  limits,
+		root:    me,
+		nodes:   -1,
+		pending: -1,
+	}
+}
+
+// update ... func
+// called on every change: the count checked for it is kept, a change that was
+// not checked (a delete) makes the next check count again.
+func (me *limitState) update() {
+
+	if me == nil {
+		return
+	}
+
+	me.nodes, me.pending = me.pending, -1
+}
+
+type rawStats struct {
+	nodes     int
+	depth     int
+	arrayLen  int
+	stringLen int
+}
+
+func statsOf(raw interface{}) rawStats {
+
+	stats := rawStats{}
+
+	var visit func(raw interface{}, depth int)
+	visit = func(raw interface{}, depth int) {
+
+		stats.nodes++
+		if depth > stats.depth {
+			stats.depth = depth
+		}
+
+		switch typed := raw.(type) {
+		case string:
+			if n := utf8.RuneCountInString(typed); n > stats.stringLen {
+				stats.stringLen = n
+			}
+		case map[string]interface{}:
+			for k, sub := range typed {
+				if n := utf8.RuneCountInString(k); n > stats.stringLen {
+					stats.stringLen = n
+				}
+				visit(sub, depth+1)
+			}
+		default:
+			if arr := asSlice(raw); arr != nil {
+				if len(arr) > stats.arrayLen {
+					stats.arrayLen = len(arr)
+				}
+				for _, sub := range arr {
+					visit(sub, depth+1)
+				}
+			}
+		}
+	}
+
+	visit(raw, 1)
+
+	return stats
+}
+
+func checkLimit(name string, max, actual int) error {
+
+	if max > 0 && actual > max {
+		return &LimitError{Limit: name, Max: max, Actual: actual}
+	}
+
+	return nil
+}
+
+// checkValues ... func
+// for replacing oldRaw with newRaws at depthBase below the root of the limits.
+func (me *JSONElement) checkValues(depthBase int, oldRaw interface{}, newRaws []interface{}) error {
+
+	limits := me.limits
+
+	added := 0
+	for _, v := range newRaws {
+
+		stats := statsOf(v)
+		added += stats.nodes
+
+		if err := checkLimit("MaxDepth", limits.MaxDepth, depthBase+stats.depth); err != nil {
+			return err
+		}
+
+		if err := checkLimit("MaxArrayLen", limits.MaxArrayLen, stats.arrayLen); err != nil {
+			return err
+		}
+
+		if err := checkLimit("MaxStringLen", limits.MaxStringLen, stats.stringLen); err != nil {
+			return err
+		}
+	}
+
+	if limits.MaxNodes > 0 {
+
+		if limits.nodes < 0 {
+			limits.nodes = statsOf(limits.root.raw).nodes - 1
+		}
+
+		total := limits.nodes + added
+		if oldRaw != nil {
+			total -= statsOf(oldRaw).nodes
+		}
+
+		if err := checkLimit("MaxNodes", limits.MaxNodes, total); err != nil {
+			return err
+		}
+
+		limits.pending = total
+	}
+
+	return nil
+}
+
+// checkLimits ... func
+// for replacing oldRaw (nil when absent) of me with newRaw.
+func (me *JSONElement) checkLimits(oldRaw, newRaw interface{}) error {
+
+	if me.limits == nil {
+		return nil
+	}
+
+	return me.checkValues(me.level-me.limits.root.level, oldRaw, []interface{}{newRaw})
+}
+
+// checkReplaceLimits ... func
+// for replacing the whole value of me with newRaw.
+func (me *JSONElement) checkReplaceLimits(newRaw interface{}) error {
+
+	if me.limits == nil {
+		return nil
+	}
+
+	return me.checkValues(me.level-me.limits.root.level-1, me.raw, []interface{}{newRaw})
+}
+
+// checkKeyLimits ... func
+// for a key added to the map me.
+func (me *JSONElement) checkKeyLimits(key string) error {
+
+	if me.limits == nil {
+		return nil
+	}
+
+	return checkLimit("MaxStringLen", me.limits.MaxStringLen, utf8.RuneCountInString(key))
+}
+
+func (me *JSONElement) checkAppendLimits(curLen int, added []interface{}) error {
+
+	if me.limits == nil {
+		return nil
+	}
+
+	if err := checkLimit("MaxArrayLen", me.limits.MaxArrayLen, curLen+len(added)); err != nil {
+		return err
+	}
+
+	return me.checkValues(me.level-me.limits.root.level, nil, added)
+}
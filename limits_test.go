@@ -0,0 +1,109 @@
+package dynajson
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := NewAsMap()
+	root.SetLimits(Limits{MaxNodes: 6, MaxArrayLen: 3, MaxStringLen: 5, MaxDepth: 3})
+
+	assert.Nil(root.Put("s", "12345"))
+
+	err := root.Put("s", "123456")
+	assert.True(errors.Is(err, ErrLimitExceeded))
+
+	var limitErr *LimitError
+	assert.True(errors.As(err, &limitErr))
+	assert.Equal("MaxStringLen", limitErr.Limit)
+	assert.Equal(6, limitErr.Actual)
+	assert.Equal("12345", root.Select("s").AsString())
+
+	arr, err := root.PutEmptyArray("arr")
+	assert.Nil(err)
+	assert.Nil(arr.Append(1, 2))
+	assert.True(errors.Is(arr.Append(3, 4), ErrLimitExceeded))
+	assert.Equal(2, arr.Count())
+	assert.True(errors.Is(root.Put("arr2", 1, 2, 3, 4), ErrLimitExceeded))
+
+	m, err := root.PutEmptyMap("m")
+	assert.Nil(err)
+	assert.Nil(m.Put("k", 1))
+
+	// s, arr, 1, 2, m, k = 6
+	err = m.Put("k2", 1)
+	assert.True(errors.As(err, &limitErr))
+	assert.Equal("MaxNodes", limitErr.Limit)
+
+	// replacing does not grow
+	assert.Nil(m.Put("k", 2))
+
+	root2, err := NewByString(`{"a": {"b": {}}}`)
+	assert.Nil(err)
+	root2.SetLimits(Limits{MaxDepth: 3})
+	assert.Nil(root2.Select("a", "b").Put("c", 1))
+	assert.NotNil(root2.Select("a", "b").Put("c", map[string]interface{}{"d": 1}))
+}
+
+func TestLimitsKeysAndBulk(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := NewAsMap()
+	root.SetLimits(Limits{MaxStringLen: 5, MaxNodes: 3})
+
+	var limitErr *LimitError
+
+	// keys are checked too
+	err := root.Put("123456", 1)
+	assert.True(errors.As(err, &limitErr))
+	assert.Equal("MaxStringLen", limitErr.Limit)
+	assert.Nil(root.Put("a", 1))
+	assert.True(errors.Is(root.RenameKey("a", "abcdef", false), ErrLimitExceeded))
+
+	// bulk rewrites are checked as a whole and leave the tree unchanged
+	other, err := NewByString(`{"b": 1, "c": 2, "d": 3, "e": 4, "f": 5}`)
+	assert.Nil(err)
+
+	err = root.Merge(other, MergeOptions{})
+	assert.True(errors.As(err, &limitErr))
+	assert.Equal("MaxNodes", limitErr.Limit)
+	assert.Equal(6, limitErr.Actual)
+	assert.Equal([]string{"a"}, root.Keys())
+
+	patch, err := NewByString(`[{"op": "add", "path": "/x", "value": [1, 2]}]`)
+	assert.Nil(err)
+	assert.True(errors.Is(root.ApplyPatch(patch), ErrLimitExceeded))
+	assert.True(errors.Is(root.MergePatch(other), ErrLimitExceeded))
+	assert.True(errors.Is(root.Copy("/a", "/abcdef"), ErrLimitExceeded))
+	assert.Equal(1, root.Count())
+
+	assert.Nil(root.Copy("/a", "/b"))
+	assert.Nil(root.Put("c", 1))
+	assert.NotNil(root.Put("d", 1))
+
+	// a delete is counted
+	assert.Nil(root.DeleteByKey("c"))
+	assert.Nil(root.Put("d", 1))
+}
+
+func TestLimitsManyPuts(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := NewAsMap()
+	root.SetLimits(Limits{MaxNodes: 20000})
+
+	for i := 0; i < 20000; i++ {
+		assert.Nil(root.Put(strconv.Itoa(i), i))
+	}
+
+	assert.True(errors.Is(root.Put("x", 1), ErrLimitExceeded))
+}
@@ -0,0 +1,205 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LintIssue ... struct
+type LintIssue struct {
+	Rule    string
+	Path    []interface{}
+	Message string
+}
+
+func (me LintIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", FullPath2Str(me.Path, "/"), me.Rule, me.Message)
+}
+
+// Rule ... struct
+// Check is called for every node and returns one message per problem found.
+type Rule struct {
+	Name  string
+	Check func(elm *JSONElement) []string
+}
+
+// key styles, "flat" keys (lower case letters and digits only) fit every lower style.
+func keyStyle(key string) string {
+
+	hasUpper := strings.IndexFunc(key, unicode.IsUpper) >= 0
+	hasLower := strings.IndexFunc(key, unicode.IsLower) >= 0
+
+	switch {
+	case key == "":
+		return ""
+	case strings.Contains(key, "_") && !hasLower:
+		return "SCREAMING_SNAKE"
+	case strings.Contains(key, "_"):
+		return "snake_case"
+	case strings.Contains(key, "-"):
+		return "kebab-case"
+	case unicode.IsUpper([]rune(key)[0]) && hasLower:
+		return "PascalCase"
+	case hasUpper && hasLower:
+		return "camelCase"
+	}
+
+	return ""
+}
+
+// RuleKeyCasing ... var
+// reports maps mixing key styles (camelCase, snake_case, kebab-case, ...).
+var RuleKeyCasing = Rule{
+	Name: "key-casing",
+	Check: func(elm *JSONElement) []string {
+
+		if !elm.IsMap() {
+			return nil
+		}
+
+		styles := map[string]bool{}
+		for _, k := range elm.Keys() {
+			if style := keyStyle(k); style != "" {
+				styles[style] = true
+			}
+		}
+
+		if len(styles) < 2 {
+			return nil
+		}
+
+		names := []string{}
+		for k := range styles {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+
+		return []string{fmt.Sprintf("mixed key styles: %s", strings.Join(names, ", "))}
+	},
+}
+
+// RuleMixedArrayTypes ... var
+// reports arrays whose elements are of different kinds (null is ignored).
+var RuleMixedArrayTypes = Rule{
+	Name: "mixed-array-types",
+	Check: func(elm *JSONElement) []string {
+
+		if !elm.IsArray() {
+			return nil
+		}
+
+		kinds := map[Kind]bool{}
+		for _, v := range elm.AsArray() {
+			if kind := v.Kind(); kind != KindNull {
+				kinds[kind] = true
+			}
+		}
+
+		if len(kinds) < 2 {
+			return nil
+		}
+
+		names := []string{}
+		for k := range kinds {
+			names = append(names, k.String())
+		}
+		sort.Strings(names)
+
+		return []string{fmt.Sprintf("mixed element kinds: %s", strings.Join(names, ", "))}
+	},
+}
+
+// RuleEmptyContainers ... var
+var RuleEmptyContainers = Rule{
+	Name: "empty-container",
+	Check: func(elm *JSONElement) []string {
+
+		if (elm.IsMap() || elm.IsArray()) && elm.Count() == 0 {
+			return []string{fmt.Sprintf("empty %s", elm.Kind())}
+		}
+
+		return nil
+	},
+}
+
+// RuleDuplicateSiblings ... var
+// reports array elements equal to an earlier element of the same array.
+var RuleDuplicateSiblings = Rule{
+	Name: "duplicate-siblings",
+	Check: func(elm *JSONElement) []string {
+
+		if !elm.IsArray() {
+			return nil
+		}
+
+		msgs := []string{}
+		seen := map[string]int{}
+
+		for i, v := range elm.AsArray() {
+
+			data, err := json.Marshal(v.Raw())
+			if err != nil {
+				continue
+			}
+
+			if first, ok := seen[string(data)]; ok {
+				msgs = append(msgs, fmt.Sprintf("element %d duplicates element %d", i, first))
+				continue
+			}
+
+			seen[string(data)] = i
+		}
+
+		return msgs
+	},
+}
+
+// DefaultLintRules ... var
+var DefaultLintRules = []Rule{
+	RuleKeyCasing,
+	RuleMixedArrayTypes,
+	RuleEmptyContainers,
+	RuleDuplicateSiblings,
+}
+
+func (me *JSONElement) lint(rules []Rule, issues []LintIssue) []LintIssue {
+
+	for _, rule := range rules {
+		for _, msg := range rule.Check(me) {
+			issues = append(issues, LintIssue{
+				Rule:    rule.Name,
+				Path:    me.FullPath(),
+				Message: msg,
+			})
+		}
+	}
+
+	switch {
+	case me.IsMap():
+		me.EachMap(func(key string, elm *JSONElement) (bool, error) {
+			issues = elm.lint(rules, issues)
+			return true, nil
+		})
+	case me.IsArray():
+		me.EachArray(func(i int, elm *JSONElement) (bool, error) {
+			issues = elm.lint(rules, issues)
+			return true, nil
+		})
+	}
+
+	return issues
+}
+
+// Lint ... func
+// runs rules (DefaultLintRules when none given) on every node, map keys in sorted order.
+func (me *JSONElement) Lint(rules ...Rule) []LintIssue {
+
+	if len(rules) == 0 {
+		rules = DefaultLintRules
+	}
+
+	return me.lint(rules, []LintIssue{})
+}
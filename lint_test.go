@@ -0,0 +1,47 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLint(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{
+		"userName": "a",
+		"user_id": 1,
+		"tags": ["x", 1, "x", null],
+		"empty": {},
+		"ok": {"firstName": "b", "lastName": "c", "age": 3}
+	}`)
+	assert.Nil(err)
+
+	strs := []string{}
+	for _, v := range root.Lint() {
+		strs = append(strs, v.String())
+	}
+
+	assert.Equal([]string{
+		": key-casing: mixed key styles: camelCase, snake_case",
+		"/empty: empty-container: empty map",
+		"/tags: mixed-array-types: mixed element kinds: number, string",
+		"/tags: duplicate-siblings: element 2 duplicates element 0",
+	}, strs)
+
+	noNumbers := Rule{
+		Name: "no-numbers",
+		Check: func(elm *JSONElement) []string {
+			if elm.Kind() == KindNumber {
+				return []string{"number found"}
+			}
+			return nil
+		},
+	}
+
+	issues := root.Lint(noNumbers)
+	assert.Equal(3, len(issues))
+	assert.Equal([]interface{}{"ok", "age"}, issues[0].Path)
+}
@@ -0,0 +1,334 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// DumpOptions ... struct
+type DumpOptions struct {
+	// Prefix starts every line but the first, Indent is repeated per level.
+	// Both "" means one line ({"a": 1, "b": [2]}).
+	Prefix string
+	Indent string
+	// SortKeys orders map keys by KeyLess (lexical when nil), otherwise
+	// the insertion order of an ordered element or its KeyLess (map order when nil) is used.
+	SortKeys bool
+	KeyLess  func(string, string) bool
+	// TrailingNewline appends "\n".
+	TrailingNewline bool
+	// EscapeHTML writes <, >, & and U+2028, U+2029 as \uXXXX, safe to embed in
+	// HTML and JavaScript.
+	EscapeHTML bool
+	// EscapeUnicode writes every non-ASCII character as \uXXXX (surrogate pairs
+	// above U+FFFF), for ASCII-only consumers.
+	EscapeUnicode bool
+	// UTF8 is for invalid UTF-8 in strings: U+FFFD (UTF8Replace), an error
+	// (UTF8Reject) or the bytes as they are (UTF8PassThrough).
+	UTF8 UTF8Policy
+}
+
+const hexDigits = "0123456789abcdef"
+
+// writeJSONString ... func
+// RFC 8259 string, control characters are escaped, invalid UTF-8 becomes U+FFFD.
+func writeJSONString(buf *bytes.Buffer, str string) {
+	_ = writeJSONStringWith(buf, str, DumpOptions{})
+}
+
+func writeEscapeU(buf *bytes.Buffer, r rune) {
+
+	buf.WriteString(`\u`)
+	buf.WriteByte(hexDigits[r>>12&0xf])
+	buf.WriteByte(hexDigits[r>>8&0xf])
+	buf.WriteByte(hexDigits[r>>4&0xf])
+	buf.WriteByte(hexDigits[r&0xf])
+}
+
+// writeJSONStringWith ... func
+// writeJSONString with the escaping of opts, an error only with UTF8Reject.
+func writeJSONStringWith(buf *bytes.Buffer, str string, opts DumpOptions) error {
+
+	buf.WriteByte('"')
+
+	for i := 0; i < len(str); {
+
+		r, size := utf8.DecodeRuneInString(str[i:])
+
+		if r == utf8.RuneError && size == 1 {
+			switch opts.UTF8 {
+			case UTF8Reject:
+				return fmt.Errorf("Invalid UTF-8: offset %d", i)
+			case UTF8PassThrough:
+				buf.WriteByte(str[i])
+			default:
+				if opts.EscapeUnicode {
+					writeEscapeU(buf, utf8.RuneError)
+				} else {
+					buf.WriteString("\ufffd")
+				}
+			}
+
+			i += size
+			continue
+		}
+
+		i += size
+
+		switch r {
+		case '"', '\\':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+			continue
+		case '\n':
+			buf.WriteString(`\n`)
+			continue
+		case '\r':
+			buf.WriteString(`\r`)
+			continue
+		case '\t':
+			buf.WriteString(`\t`)
+			continue
+		case '\b':
+			buf.WriteString(`\b`)
+			continue
+		case '\f':
+			buf.WriteString(`\f`)
+			continue
+		case '<', '>', '&', '\u2028', '\u2029':
+			if opts.EscapeHTML {
+				writeEscapeU(buf, r)
+				continue
+			}
+		}
+
+		switch {
+		case r < 0x20:
+			writeEscapeU(buf, r)
+		case r < utf8.RuneSelf || !opts.EscapeUnicode:
+			buf.WriteRune(r)
+		case r > 0xffff:
+			r1, r2 := utf16.EncodeRune(r)
+			writeEscapeU(buf, r1)
+			writeEscapeU(buf, r2)
+		default:
+			writeEscapeU(buf, r)
+		}
+	}
+
+	buf.WriteByte('"')
+
+	return nil
+}
+
+type encoder struct {
+	buf   *bytes.Buffer
+	opts  DumpOptions
+	less  func(string, string) bool
+	order *keyOrderState
+	// lenient writes what can not be encoded with %v instead of failing (Dump has no error).
+	lenient bool
+	// warn reports what lenient mode wrote in place of a value, may be nil.
+	warn func(string, ...interface{})
+}
+
+// writeString ... func
+// in lenient mode UTF8Reject falls back to U+FFFD.
+func (me *encoder) writeString(path []interface{}, str string) error {
+
+	opts := me.opts
+	if me.lenient && opts.UTF8 == UTF8Reject {
+		opts.UTF8 = UTF8Replace
+	}
+
+	if err := writeJSONStringWith(me.buf, str, opts); err != nil {
+		return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	return nil
+}
+
+func (me *encoder) newline(depth int) {
+
+	if me.opts.Indent == "" && me.opts.Prefix == "" {
+		return
+	}
+
+	me.buf.WriteByte('\n')
+	me.buf.WriteString(me.opts.Prefix)
+
+	for i := 0; i < depth; i++ {
+		me.buf.WriteString(me.opts.Indent)
+	}
+}
+
+func (me *encoder) separator() {
+
+	if me.opts.Indent == "" && me.opts.Prefix == "" {
+		me.buf.WriteString(", ")
+		return
+	}
+
+	me.buf.WriteByte(',')
+}
+
+func (me *encoder) encode(path []interface{}, raw interface{}, depth int) error {
+
+	switch typed := raw.(type) {
+	case nil:
+		me.buf.WriteString("null")
+	case bool:
+		me.buf.WriteString(strconv.FormatBool(typed))
+	case string:
+		if err := me.writeString(path, typed); err != nil {
+			return err
+		}
+	case int:
+		me.buf.WriteString(strconv.Itoa(typed))
+	case float64:
+		if math.IsNaN(typed) || math.IsInf(typed, 0) {
+			if !me.lenient {
+				return fmt.Errorf("%s: Unsupported Value: %v", Path2Pointer(path), typed)
+			}
+
+			// JSON has no NaN or Inf
+			if me.warn != nil {
+				me.warn("%s: %v: Written As null", Path2Pointer(path), typed)
+			}
+
+			me.buf.WriteString("null")
+			return nil
+		}
+		me.buf.WriteString(fmt.Sprintf("%v", typed))
+
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+		}
+		return me.encode(path, obj, depth)
+
+	case []interface{}, *[]interface{}:
+		arr := asSlice(typed)
+		if len(arr) == 0 {
+			me.buf.WriteString("[]")
+			return nil
+		}
+
+		me.buf.WriteByte('[')
+
+		for i, v := range arr {
+
+			if i > 0 {
+				me.separator()
+			}
+
+			me.newline(depth + 1)
+
+			err := me.encode(appendParents(path, i), v, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		me.newline(depth)
+		me.buf.WriteByte(']')
+
+	case map[string]interface{}:
+		if len(typed) == 0 {
+			me.buf.WriteString("{}")
+			return nil
+		}
+
+		me.buf.WriteByte('{')
+
+		for i, k := range me.order.keys(typed, me.less) {
+
+			if i > 0 {
+				me.separator()
+			}
+
+			me.newline(depth + 1)
+			if err := me.writeString(appendParents(path, k), k); err != nil {
+				return err
+			}
+			me.buf.WriteString(": ")
+
+			err := me.encode(appendParents(path, k), typed[k], depth+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		me.newline(depth)
+		me.buf.WriteByte('}')
+
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			if me.lenient {
+				me.buf.WriteString(fmt.Sprintf("%v", typed))
+				return nil
+			}
+			return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+		}
+		me.buf.Write(data)
+	}
+
+	return nil
+}
+
+// Marshal ... func
+// serializes me as RFC 8259 JSON formatted by opts.
+func (me *JSONElement) Marshal(opts DumpOptions) ([]byte, error) {
+
+	less, order := me.KeyLess, me.order
+	if opts.SortKeys || opts.KeyLess != nil {
+		less, order = opts.KeyLess, nil
+		if less == nil {
+			less = LexicalLess
+		}
+	}
+
+	enc := &encoder{
+		buf:   &bytes.Buffer{},
+		opts:  opts,
+		less:  less,
+		order: order,
+	}
+
+	err := enc.encode([]interface{}{}, me.raw, 0)
+	if err != nil {
+		return nil, me.Errorf("Marshal: %w", err)
+	}
+
+	if opts.TrailingNewline {
+		enc.buf.WriteByte('\n')
+	}
+
+	return enc.buf.Bytes(), nil
+}
+
+// StringIndent ... func
+// multi-line String(), e.g. StringIndent("", "  ") for human-editable files.
+func (me *JSONElement) StringIndent(prefix, indent string) string {
+
+	data, err := me.Marshal(DumpOptions{Prefix: prefix, Indent: indent})
+	if err != nil {
+		me.Warn("StringIndent: %v", err)
+		return ""
+	}
+
+	return string(data)
+}
+
+// MarshalJSON ... func
+// implements json.Marshaler, map keys follow KeyLess.
+func (me *JSONElement) MarshalJSON() ([]byte, error) {
+	return me.Marshal(DumpOptions{})
+}
@@ -0,0 +1,144 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshal(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"b": [1, 2.5, {"c": null}], "a": "x\n\"y\"\u0001", "e": {}, "f": [], "t": true}`)
+	assert.Nil(err)
+
+	data, err := root.Marshal(DumpOptions{SortKeys: true})
+	assert.Nil(err)
+	assert.Equal(`{"a": "x\n\"y\"\u0001", "b": [1, 2.5, {"c": null}], "e": {}, "f": [], "t": true}`, string(data))
+
+	data, err = root.Marshal(DumpOptions{Indent: "  ", SortKeys: true, TrailingNewline: true})
+	assert.Nil(err)
+	assert.Equal(`{
+  "a": "x\n\"y\"\u0001",
+  "b": [
+    1,
+    2.5,
+    {
+      "c": null
+    }
+  ],
+  "e": {},
+  "f": [],
+  "t": true
+}
+`, string(data))
+
+	small, err := NewByString(`{"a": [1], "b": 2}`)
+	assert.Nil(err)
+	small.KeyLess = func(a, b string) bool { return a > b }
+	assert.Equal("{\n# \t\"b\": 2,\n# \t\"a\": [\n# \t\t1\n# \t]\n# }", small.StringIndent("# ", "\t"))
+
+	assert.Nil(root.Put("nan", math.NaN()))
+	_, err = root.Marshal(DumpOptions{})
+	assert.NotNil(err)
+	assert.Equal("", root.StringIndent("", " "))
+
+	// String() writes null with a warning
+	warned := []string{}
+	root.WarnHandler = func(me *JSONElement, message string, where string, line int) {
+		warned = append(warned, message)
+	}
+
+	assert.Nil(root.Put("inf", []interface{}{math.Inf(1), math.Inf(-1)}))
+	assert.Contains(root.String(), `"nan": null`)
+	assert.Contains(root.String(), `"inf": [null, null]`)
+	assert.Nil(json.Unmarshal([]byte(root.String()), &map[string]interface{}{}))
+	assert.Contains(warned, "/inf/0: +Inf: Written As null")
+	assert.Contains(warned, "/nan: NaN: Written As null")
+}
+
+func TestDumpSpec(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := NewAsMap()
+	assert.Nil(root.Put("s", "a\tb\u0000"))
+	assert.Nil(root.Put("n", nil))
+	root.KeyLess = LexicalLess
+
+	assert.Equal(`{"n": null, "s": "a\tb\u0000"}`, root.String())
+	assert.Nil(root.ValidateOutput())
+
+	data, err := json.Marshal(map[string]interface{}{"doc": root})
+	assert.Nil(err)
+	assert.Equal(`{"doc":{"n":null,"s":"a\tb\u0000"}}`, string(data))
+
+	LegacyDump = true
+	defer func() { LegacyDump = false }()
+
+	assert.Equal("{\"n\": <nil>, \"s\": \"a\tb\x00\"}", root.String())
+}
+
+func TestMarshalEscape(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// \uXXXX written out
+	u := func(hex string) string {
+		return `\u` + hex
+	}
+
+	root := New(map[string]interface{}{"s": "<a>&</a>" + string(rune(0x2028)) + string(rune(0xe9)) + string(rune(0x1f600)) + "\x01"})
+
+	data, err := root.Marshal(DumpOptions{})
+	assert.Nil(err)
+	assert.Equal(`{"s": "<a>&</a>`+string(rune(0x2028))+string(rune(0xe9))+string(rune(0x1f600))+u("0001")+`"}`, string(data))
+
+	data, err = root.Marshal(DumpOptions{EscapeHTML: true})
+	assert.Nil(err)
+	assert.Equal(`{"s": "`+u("003c")+"a"+u("003e")+u("0026")+u("003c")+"/a"+u("003e")+u("2028")+string(rune(0xe9))+string(rune(0x1f600))+u("0001")+`"}`, string(data))
+
+	data, err = root.Marshal(DumpOptions{EscapeUnicode: true})
+	assert.Nil(err)
+	assert.Equal(`{"s": "<a>&</a>`+u("2028")+u("00e9")+u("d83d")+u("de00")+u("0001")+`"}`, string(data))
+
+	// read back the same
+	var v interface{}
+	assert.Nil(json.Unmarshal(data, &v))
+	assert.Equal(root.Raw(), v)
+
+	key := New(map[string]interface{}{string(rune(0xfc)): 1})
+	data, err = key.Marshal(DumpOptions{EscapeUnicode: true})
+	assert.Nil(err)
+	assert.Equal(`{"`+u("00fc")+`": 1}`, string(data))
+}
+
+func TestMarshalUTF8Policy(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := New(map[string]interface{}{"s": "a\xffb"})
+	replaced := "{\"s\": \"a" + string(rune(0xfffd)) + "b\"}"
+
+	data, err := root.Marshal(DumpOptions{})
+	assert.Nil(err)
+	assert.Equal(replaced, string(data))
+
+	data, err = root.Marshal(DumpOptions{UTF8: UTF8PassThrough})
+	assert.Nil(err)
+	assert.Equal("{\"s\": \"a\xffb\"}", string(data))
+
+	data, err = root.Marshal(DumpOptions{EscapeUnicode: true})
+	assert.Nil(err)
+	assert.Equal(`{"s": "a\ufffdb"}`, string(data))
+
+	_, err = root.Marshal(DumpOptions{UTF8: UTF8Reject})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "/s: Invalid UTF-8: offset 1")
+
+	// String has no error
+	assert.Equal(replaced, root.String())
+}
@@ -0,0 +1,162 @@
+package dynajson
+
+// MergeStrategy ... type
+// decides conflicts (both sides have the key and they are not both maps).
+type MergeStrategy int
+
+const (
+	// MergeOverwrite ... the value of other wins
+	MergeOverwrite MergeStrategy = iota
+	// MergeKeepExisting ... the value of me wins
+	MergeKeepExisting
+)
+
+// ArrayMergeStrategy ... type
+type ArrayMergeStrategy int
+
+const (
+	// ArrayReplace ... arrays are conflicts like scalars
+	ArrayReplace ArrayMergeStrategy = iota
+	// ArrayAppend ... elements of other are appended
+	ArrayAppend
+	// ArrayUnion ... elements of other not equal to an existing one are appended
+	ArrayUnion
+)
+
+// MergeOptions ... struct
+type MergeOptions struct {
+	Strategy MergeStrategy
+	Arrays   ArrayMergeStrategy
+	// OnConflict, when set, replaces Strategy: returns the value to keep at path
+	// (relative to me), an error aborts the merge leaving me unchanged.
+	OnConflict func(path []interface{}, existing, incoming *JSONElement) (interface{}, error)
+}
+
+// mergeRaw ... func
+// merges src into dst, maps are merged recursively, anything else in src replaces dst.
+func mergeRaw(dst, src interface{}) interface{} {
+
+	ret, _ := mergeRawWith([]interface{}{}, dst, src, &MergeOptions{})
+
+	return ret
+}
+
+func mergeRawWith(path []interface{}, dst, src interface{}, opts *MergeOptions) (interface{}, error) {
+
+	dstMap, okDst := dst.(map[string]interface{})
+	srcMap, okSrc := src.(map[string]interface{})
+
+	if okDst && okSrc {
+
+		for k, v := range srcMap {
+
+			cur, ok := dstMap[k]
+			if !ok {
+				dstMap[k] = v
+				continue
+			}
+
+			merged, err := mergeRawWith(appendParents(path, k), cur, v, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			dstMap[k] = merged
+		}
+
+		return dstMap, nil
+	}
+
+	dstArr, srcArr := asSlice(dst), asSlice(src)
+
+	if dstArr != nil && srcArr != nil && opts.Arrays != ArrayReplace {
+
+		arr := append([]interface{}{}, dstArr...)
+
+		for _, v := range srcArr {
+
+			if opts.Arrays == ArrayUnion {
+
+				found := false
+				for _, w := range arr {
+					if rawEqual(v, w) {
+						found = true
+						break
+					}
+				}
+
+				if found {
+					continue
+				}
+			}
+
+			arr = append(arr, v)
+		}
+
+		if _, ok := dst.(*[]interface{}); ok {
+			return &arr, nil
+		}
+
+		return arr, nil
+	}
+
+	if opts.OnConflict != nil {
+
+		if rawEqual(dst, src) {
+			return dst, nil
+		}
+
+		ret, err := opts.OnConflict(path, New(dst), New(src))
+		if err != nil {
+			return nil, err
+		}
+
+		return elm2Raw(ret), nil
+	}
+
+	if opts.Strategy == MergeKeepExisting {
+		return dst, nil
+	}
+
+	return src, nil
+}
+
+// Merge ... func
+// deep merges other into me, maps are merged recursively and conflicts are
+// decided by opts. Values taken from other are copied.
+func (me *JSONElement) Merge(other *JSONElement, opts MergeOptions) error {
+
+	if me.isReadonly() {
+		return me.fail("Merge", ErrorReadonly, "")
+	}
+
+	if other == nil {
+		return me.Errorf("Merge: other is nil")
+	}
+
+	dst, err := resolveSpill(me.raw)
+	if err != nil {
+		return me.Errorf("Merge: %w", err)
+	}
+
+	dst, err = copyRawChecked(dst)
+	if err != nil {
+		return me.Errorf("Merge: %w", err)
+	}
+
+	src, err := copyRawChecked(other.Raw())
+	if err != nil {
+		return me.Errorf("Merge: %w", err)
+	}
+
+	merged, err := mergeRawWith([]interface{}{}, dst, src, &opts)
+	if err != nil {
+		return me.Errorf("Merge: %w", err)
+	}
+
+	if err := me.replaceRaw(merged); err != nil {
+		return me.Errorf("Merge: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package dynajson
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+
+	assert := assert.New(t)
+
+	load := func(str string) *JSONElement {
+		elm, err := NewByString(str)
+		assert.Nil(err)
+		elm.KeyLess = LexicalLess
+		return elm
+	}
+
+	base := `{"name": "a", "db": {"host": "h1", "port": 1}, "tags": ["x", "y"]}`
+	other := load(`{"name": "b", "db": {"port": 2, "user": "u"}, "tags": ["y", "z"], "new": true}`)
+
+	root := load(base)
+	assert.Nil(root.Merge(other, MergeOptions{}))
+	assert.Equal(`{"db": {"host": "h1", "port": 2, "user": "u"}, "name": "b", "new": true, "tags": ["y", "z"]}`, root.String())
+
+	root = load(base)
+	assert.Nil(root.Merge(other, MergeOptions{Strategy: MergeKeepExisting, Arrays: ArrayAppend}))
+	assert.Equal(`{"db": {"host": "h1", "port": 1, "user": "u"}, "name": "a", "new": true, "tags": ["x", "y", "y", "z"]}`, root.String())
+
+	root = load(base)
+	assert.Nil(root.Merge(other, MergeOptions{Arrays: ArrayUnion}))
+	assert.Equal(`["x", "y", "z"]`, root.Select("tags").String())
+
+	other.Select("db").Raw().(map[string]interface{})["user"] = "changed"
+	assert.Equal("u", root.Select("db", "user").AsString())
+
+	paths := []string{}
+	root = load(base)
+	assert.Nil(root.Merge(other, MergeOptions{
+		OnConflict: func(path []interface{}, existing, incoming *JSONElement) (interface{}, error) {
+			paths = append(paths, Path2Pointer(path))
+			return fmt.Sprintf("%v|%v", existing.Raw(), incoming.Raw()), nil
+		},
+	}))
+	assert.ElementsMatch([]string{"/name", "/db/port", "/tags"}, paths)
+	assert.Equal("a|b", root.Select("name").AsString())
+
+	root = load(base)
+	err := root.Merge(other, MergeOptions{
+		OnConflict: func(path []interface{}, existing, incoming *JSONElement) (interface{}, error) {
+			return nil, errors.New("conflict")
+		},
+	})
+	assert.NotNil(err)
+	assert.Equal("a", root.Select("name").AsString())
+
+	db := root.Select("db")
+	assert.Nil(db.Merge(load(`{"port": 3}`), MergeOptions{}))
+	assert.Equal(3, root.Select("db", "port").AsInt())
+}
@@ -0,0 +1,108 @@
+package dynajson
+
+func mergePatchRaw(target, patch interface{}) interface{} {
+
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return copyRaw(patch)
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	for k, v := range patchObj {
+
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+
+		targetObj[k] = mergePatchRaw(targetObj[k], v)
+	}
+
+	return targetObj
+}
+
+// MergePatch ... func
+// applies an RFC 7386 JSON Merge Patch, null members of patch delete keys of me.
+func (me *JSONElement) MergePatch(patch *JSONElement) error {
+
+	if me.isReadonly() {
+		return me.fail("MergePatch", ErrorReadonly, "")
+	}
+
+	if patch == nil {
+		return me.Errorf("MergePatch: patch is nil")
+	}
+
+	raw, err := copyRawChecked(patch.Raw())
+	if err != nil {
+		return me.Errorf("MergePatch: %w", err)
+	}
+
+	target, err := copyRawChecked(me.raw)
+	if err != nil {
+		return me.Errorf("MergePatch: %w", err)
+	}
+
+	patched := mergePatchRaw(target, raw)
+	if err := me.replaceRaw(patched); err != nil {
+		return me.Errorf("MergePatch: %w", err)
+	}
+
+	return nil
+}
+
+func createMergePatchRaw(src, dst interface{}) interface{} {
+
+	srcObj, okSrc := src.(map[string]interface{})
+	dstObj, okDst := dst.(map[string]interface{})
+
+	if !okSrc || !okDst {
+		return copyRaw(dst)
+	}
+
+	patch := map[string]interface{}{}
+
+	for k := range srcObj {
+		if _, ok := dstObj[k]; !ok {
+			patch[k] = nil
+		}
+	}
+
+	for k, v := range dstObj {
+
+		old, ok := srcObj[k]
+		if ok && rawEqual(old, v) {
+			continue
+		}
+
+		patch[k] = createMergePatchRaw(old, v)
+	}
+
+	return patch
+}
+
+// CreateMergePatch ... func
+// returns the RFC 7386 JSON Merge Patch that turns me into target.
+// Merge patches can not set null, null members of target are not reproduced.
+func (me *JSONElement) CreateMergePatch(target *JSONElement) (*JSONElement, error) {
+
+	if target == nil {
+		return nil, me.Errorf("CreateMergePatch: target is nil")
+	}
+
+	src, err := resolveSpill(me.Raw())
+	if err != nil {
+		return nil, me.Errorf("CreateMergePatch: %w", err)
+	}
+
+	dst, err := resolveSpill(target.Raw())
+	if err != nil {
+		return nil, me.Errorf("CreateMergePatch: %w", err)
+	}
+
+	return New(createMergePatchRaw(src, dst)), nil
+}
@@ -0,0 +1,58 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"title": "Goodbye!", "author": {"givenName": "John", "familyName": "Doe"}, "tags": ["example", "sample"], "content": "This will be unchanged"}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	patch, err := NewByString(`{"title": "Hello!", "phoneNumber": "+01-123-456-7890", "author": {"familyName": null}, "tags": ["example"]}`)
+	assert.Nil(err)
+
+	assert.Nil(root.MergePatch(patch))
+	assert.Equal(`{"author": {"givenName": "John"}, "content": "This will be unchanged", "phoneNumber": "+01-123-456-7890", "tags": ["example"], "title": "Hello!"}`, root.String())
+
+	patch.Select("tags").Raw().([]interface{})[0] = "changed"
+	assert.Equal("example", root.Select("tags", 0).AsString())
+
+	author := root.Select("author")
+	scalar, err := NewByString(`"gone"`)
+	assert.Nil(err)
+	assert.Nil(author.MergePatch(scalar))
+	assert.Equal("gone", root.Select("author").AsString())
+
+	nested, err := NewByString(`{"author": {"name": {"first": "A"}}}`)
+	assert.Nil(err)
+	assert.Nil(root.MergePatch(nested))
+	assert.Equal("A", root.Select("author", "name", "first").AsString())
+
+	root.Readonly = true
+	assert.NotNil(root.MergePatch(nested))
+}
+
+func TestCreateMergePatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src, err := NewByString(`{"a": "b", "c": {"d": "e", "f": "g"}, "same": [1], "n": 1}`)
+	assert.Nil(err)
+
+	dst, err := NewByString(`{"a": "z", "c": {"d": "e"}, "same": [1.0], "n": {"x": 1}}`)
+	assert.Nil(err)
+
+	patch, err := src.CreateMergePatch(dst)
+	assert.Nil(err)
+	patch.KeyLess = LexicalLess
+	assert.Equal(`{"a": "z", "c": {"f": null}, "n": {"x": 1}}`, patch.String())
+
+	assert.Nil(src.MergePatch(patch))
+	assert.True(rawEqual(src.Raw(), dst.Raw()))
+}
@@ -0,0 +1,462 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// MediaTypeMsgPack ... const
+const MediaTypeMsgPack = "application/msgpack"
+
+func init() {
+	RegisterDecoder(MediaTypeMsgPack, decodeMsgPack, ".msgpack")
+}
+
+func decodeMsgPack(data []byte) (interface{}, error) {
+	return msgpackDecode(data, nil)
+}
+
+type msgpackDecoder struct {
+	data  []byte
+	pos   int
+	order *keyOrderState
+}
+
+func msgpackDecode(data []byte, order *keyOrderState) (interface{}, error) {
+
+	dec := &msgpackDecoder{data: data, order: order}
+
+	obj, err := dec.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("MsgPack: offset %d: %w", dec.pos, err)
+	}
+
+	if dec.pos != len(data) {
+		return nil, fmt.Errorf("MsgPack: offset %d: Trailing Data", dec.pos)
+	}
+
+	return obj, nil
+}
+
+func (me *msgpackDecoder) read(n uint64) ([]byte, error) {
+
+	if n > uint64(len(me.data)-me.pos) {
+		return nil, fmt.Errorf("Unexpected End")
+	}
+
+	b := me.data[me.pos : me.pos+int(n)]
+	me.pos += int(n)
+
+	return b, nil
+}
+
+// uint ... func
+// big endian unsigned of n bytes.
+func (me *msgpackDecoder) uint(n uint64) (uint64, error) {
+
+	p, err := me.read(n)
+	if err != nil {
+		return 0, err
+	}
+
+	var v uint64
+	for _, b := range p {
+		v = v<<8 | uint64(b)
+	}
+
+	return v, nil
+}
+
+func (me *msgpackDecoder) str(n uint64) (interface{}, error) {
+
+	p, err := me.read(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utf8.Valid(p) {
+		return nil, fmt.Errorf("Invalid UTF-8")
+	}
+
+	return string(p), nil
+}
+
+func (me *msgpackDecoder) bin(n uint64) (interface{}, error) {
+
+	p, err := me.read(n)
+	if err != nil {
+		return nil, err
+	}
+
+	// as encoding/json does for []byte
+	return base64.StdEncoding.EncodeToString(p), nil
+}
+
+func (me *msgpackDecoder) array(n uint64, depth int) (interface{}, error) {
+
+	// every element takes at least one byte
+	if n > uint64(len(me.data)-me.pos) {
+		return nil, fmt.Errorf("Unexpected End")
+	}
+
+	arr := make([]interface{}, n)
+
+	for i := range arr {
+		v, err := me.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = v
+	}
+
+	return arr, nil
+}
+
+func (me *msgpackDecoder) mapping(n uint64, depth int) (interface{}, error) {
+
+	if n > uint64(len(me.data)-me.pos)/2 {
+		return nil, fmt.Errorf("Unexpected End")
+	}
+
+	obj := make(map[string]interface{}, n)
+	keys := []string{}
+
+	for i := uint64(0); i < n; i++ {
+
+		k, err := me.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := me.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		key, ok := k.(string)
+		if !ok {
+			key = fmt.Sprintf("%v", k)
+		}
+
+		if _, ok := obj[key]; !ok {
+			keys = append(keys, key)
+		}
+		obj[key] = v
+	}
+
+	if me.order != nil {
+		me.order.record(obj, keys)
+	}
+
+	return obj, nil
+}
+
+// ext ... func
+// the timestamp extension (-1) becomes an RFC 3339 string, others their base64 data.
+func (me *msgpackDecoder) ext(n uint64) (interface{}, error) {
+
+	p, err := me.read(n + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	typ, data := int8(p[0]), p[1:]
+
+	if typ != -1 {
+		return base64.StdEncoding.EncodeToString(data), nil
+	}
+
+	var sec int64
+	var nsec uint32
+
+	switch len(data) {
+	case 4:
+		sec = int64(binary.BigEndian.Uint32(data))
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		nsec, sec = uint32(v>>34), int64(v&0x3ffffffff)
+	case 12:
+		nsec, sec = binary.BigEndian.Uint32(data), int64(binary.BigEndian.Uint64(data[4:]))
+	default:
+		return nil, fmt.Errorf("Bad Timestamp Length: %d", len(data))
+	}
+
+	return time.Unix(sec, int64(nsec)).UTC().Format(time.RFC3339Nano), nil
+}
+
+func msgpackInt(v int64) interface{} {
+
+	if int64(int(v)) == v {
+		return int(v)
+	}
+
+	return float64(v)
+}
+
+func (me *msgpackDecoder) value(depth int) (interface{}, error) {
+
+	if depth > binaryMaxDepth {
+		return nil, fmt.Errorf("Too Deep")
+	}
+
+	p, err := me.read(1)
+	if err != nil {
+		return nil, err
+	}
+
+	b := p[0]
+
+	switch {
+	case b <= 0x7f:
+		return int(b), nil
+	case b >= 0xe0:
+		return int(int8(b)), nil
+	case b&0xf0 == 0x80:
+		return me.mapping(uint64(b&0x0f), depth)
+	case b&0xf0 == 0x90:
+		return me.array(uint64(b&0x0f), depth)
+	case b&0xe0 == 0xa0:
+		return me.str(uint64(b & 0x1f))
+	}
+
+	// sizes of the length / value that follows
+	sizes := map[byte]uint64{
+		0xc4: 1, 0xc5: 2, 0xc6: 4,
+		0xc7: 1, 0xc8: 2, 0xc9: 4,
+		0xcc: 1, 0xcd: 2, 0xce: 4, 0xcf: 8,
+		0xd0: 1, 0xd1: 2, 0xd2: 4, 0xd3: 8,
+		0xd9: 1, 0xda: 2, 0xdb: 4,
+		0xdc: 2, 0xdd: 4, 0xde: 2, 0xdf: 4,
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xca:
+		v, err := me.uint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := me.uint(8)
+		return math.Float64frombits(v), err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return me.ext(1 << (b - 0xd4))
+	}
+
+	size, ok := sizes[b]
+	if !ok {
+		return nil, fmt.Errorf("Bad Format: 0x%02x", b)
+	}
+
+	n, err := me.uint(size)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b {
+	case 0xc4, 0xc5, 0xc6:
+		return me.bin(n)
+	case 0xc7, 0xc8, 0xc9:
+		return me.ext(n)
+	case 0xcc, 0xcd, 0xce:
+		return int(n), nil
+	case 0xcf:
+		if n > math.MaxInt64 {
+			return float64(n), nil
+		}
+		return msgpackInt(int64(n)), nil
+	case 0xd0:
+		return int(int8(n)), nil
+	case 0xd1:
+		return int(int16(n)), nil
+	case 0xd2:
+		return int(int32(n)), nil
+	case 0xd3:
+		return msgpackInt(int64(n)), nil
+	case 0xd9, 0xda, 0xdb:
+		return me.str(n)
+	case 0xdc, 0xdd:
+		return me.array(n, depth)
+	}
+
+	return me.mapping(n, depth)
+}
+
+// NewByMsgPack ... func
+// decodes MessagePack, map keys keep the order of data. Binary becomes a
+// base64 string, timestamps RFC 3339 strings.
+func NewByMsgPack(data []byte) (*JSONElement, error) {
+
+	order := newKeyOrderState()
+
+	obj, err := msgpackDecode(data, order)
+	if err != nil {
+		return nil, fmt.Errorf("NewByMsgPack: %w", err)
+	}
+
+	elm := New(obj)
+	elm.order = order
+
+	return elm, nil
+}
+
+type msgpackEncoder struct {
+	buf  *bytes.Buffer
+	elm  *JSONElement
+	less func(string, string) bool
+}
+
+func (me *msgpackEncoder) write(b byte, v interface{}) {
+	me.buf.WriteByte(b)
+	binary.Write(me.buf, binary.BigEndian, v)
+}
+
+func (me *msgpackEncoder) int(v int64) {
+
+	switch {
+	case v >= 0 && v <= 0x7f, v < 0 && v >= -32:
+		me.buf.WriteByte(byte(v))
+	case v >= 0 && v <= math.MaxUint8:
+		me.write(0xcc, uint8(v))
+	case v >= 0 && v <= math.MaxUint16:
+		me.write(0xcd, uint16(v))
+	case v >= 0 && v <= math.MaxUint32:
+		me.write(0xce, uint32(v))
+	case v >= 0:
+		me.write(0xcf, uint64(v))
+	case v >= math.MinInt8:
+		me.write(0xd0, int8(v))
+	case v >= math.MinInt16:
+		me.write(0xd1, int16(v))
+	case v >= math.MinInt32:
+		me.write(0xd2, int32(v))
+	default:
+		me.write(0xd3, v)
+	}
+}
+
+func (me *msgpackEncoder) float(f float64) {
+
+	switch {
+	case f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64:
+		me.int(int64(f))
+	case float64(float32(f)) == f || math.IsNaN(f):
+		me.write(0xca, math.Float32bits(float32(f)))
+	default:
+		me.write(0xcb, math.Float64bits(f))
+	}
+}
+
+// length ... func
+// fix form when n fits in fixMax, then the 8 (if any), 16 and 32 bit forms.
+func (me *msgpackEncoder) length(n int, fix byte, fixMax int, forms ...byte) {
+
+	switch {
+	case n <= fixMax:
+		me.buf.WriteByte(fix | byte(n))
+	case len(forms) == 3 && n <= math.MaxUint8:
+		me.write(forms[0], uint8(n))
+	case n <= math.MaxUint16:
+		me.write(forms[len(forms)-2], uint16(n))
+	default:
+		me.write(forms[len(forms)-1], uint32(n))
+	}
+}
+
+func (me *msgpackEncoder) encode(raw interface{}) error {
+
+	switch typed := raw.(type) {
+	case nil:
+		me.buf.WriteByte(0xc0)
+	case bool:
+		if typed {
+			me.buf.WriteByte(0xc3)
+		} else {
+			me.buf.WriteByte(0xc2)
+		}
+	case string:
+		me.length(len(typed), 0xa0, 31, 0xd9, 0xda, 0xdb)
+		me.buf.WriteString(typed)
+	case int:
+		me.int(int64(typed))
+	case float64:
+		me.float(typed)
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			me.int(i)
+		} else if u, err := strconv.ParseUint(string(typed), 10, 64); err == nil {
+			me.write(0xcf, u)
+		} else {
+			f, err := typed.Float64()
+			if err != nil {
+				return err
+			}
+			me.float(f)
+		}
+
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return err
+		}
+		return me.encode(obj)
+
+	case []interface{}, *[]interface{}:
+		arr := asSlice(typed)
+		me.length(len(arr), 0x90, 15, 0xdc, 0xdd)
+		for _, v := range arr {
+			if err := me.encode(v); err != nil {
+				return err
+			}
+		}
+
+	case map[string]interface{}:
+		me.length(len(typed), 0x80, 15, 0xde, 0xdf)
+		for _, k := range me.elm.order.keys(typed, me.less) {
+			me.length(len(k), 0xa0, 31, 0xd9, 0xda, 0xdb)
+			me.buf.WriteString(k)
+			if err := me.encode(typed[k]); err != nil {
+				return err
+			}
+		}
+
+	default:
+		obj, err := plainRaw(typed)
+		if err != nil {
+			return err
+		}
+		return me.encode(obj)
+	}
+
+	return nil
+}
+
+// ToMsgPack ... func
+// encodes me as MessagePack, integral numbers as integers. Map keys are in
+// insertion order for ordered elements, otherwise by KeyLess (lexical when nil).
+func (me *JSONElement) ToMsgPack() ([]byte, error) {
+
+	less := me.KeyLess
+	if less == nil {
+		less = LexicalLess
+	}
+
+	enc := &msgpackEncoder{buf: &bytes.Buffer{}, elm: me, less: less}
+
+	err := enc.encode(me.raw)
+	if err != nil {
+		return nil, me.Errorf("ToMsgPack: %w", err)
+	}
+
+	return enc.buf.Bytes(), nil
+}
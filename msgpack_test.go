@@ -0,0 +1,81 @@
+package dynajson
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByMsgPack(t *testing.T) {
+
+	assert := assert.New(t)
+
+	vectors := map[string]string{
+		"00":                 "0",
+		"7f":                 "127",
+		"ff":                 "-1",
+		"e0":                 "-32",
+		"cc80":               "128",
+		"cd0100":             "256",
+		"d080":               "-128",
+		"d1ff7f":             "-129",
+		"d3fffffffffffffffe": "-2",
+		"cb3ff199999999999a": "1.1",
+		"ca3fc00000":         "1.5",
+		"c0":                 "null",
+		"c2":                 "false",
+		"c3":                 "true",
+		"a3616263":           `"abc"`,
+		"d903616263":         `"abc"`,
+		"c403010203":         `"AQID"`,
+		"93010203":           "[1, 2, 3]",
+		"dc0002c0c3":         "[null, true]",
+		"82a17a01a16192c2c3": `{"z": 1, "a": [false, true]}`,
+		"d6ff00000000":       `"1970-01-01T00:00:00Z"`,
+	}
+
+	for in, want := range vectors {
+
+		data, _ := hex.DecodeString(in)
+
+		elm, err := NewByMsgPack(data)
+		assert.Nil(err, in)
+
+		got, _ := elm.MarshalJSON()
+		assert.Equal(want, string(got), in)
+	}
+
+	for _, bad := range []string{"", "c1", "a3616263" + "00", "a2ff", "dcffff", "93", "cc", "a1c3"[:2] + "ff"} {
+		data, _ := hex.DecodeString(bad)
+		_, err := NewByMsgPack(data)
+		assert.NotNil(err, bad)
+	}
+}
+
+func TestToMsgPack(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, _ := NewByString(`{"b": [1, -1, 200, -200, 70000, 1.5, 1.1, null], "a": "x"}`)
+
+	data, err := elm.ToMsgPack()
+	assert.Nil(err)
+	assert.Equal("82"+"a161"+"a178"+"a162"+"98"+"01"+"ff"+"ccc8"+"d1ff38"+"ce00011170"+"ca3fc00000"+"cb3ff199999999999a"+"c0", hex.EncodeToString(data))
+
+	back, err := NewByMsgPack(data)
+	assert.Nil(err)
+	assert.True(rawEqual(elm.Raw(), back.Raw()))
+
+	long := make([]interface{}, 20)
+	data, err = New(&long).ToMsgPack()
+	assert.Nil(err)
+	assert.Equal("dc0014", hex.EncodeToString(data[:3]))
+
+	ordered, _ := NewByBytesOrdered([]byte(`{"z": 1, "a": {"y": 2, "b": 3}}`))
+	data, err = ordered.ToMsgPack()
+	assert.Nil(err)
+
+	back, _ = NewByMsgPack(data)
+	assert.Equal(`{"z": 1, "a": {"y": 2, "b": 3}}`, back.String())
+}
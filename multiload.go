@@ -0,0 +1,122 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MultiLoadOptions ... struct
+type MultiLoadOptions struct {
+	// Concurrency bounds parallel fetches, 0 means 4.
+	Concurrency int
+	// ContinueOnError merges the sources that could be loaded even if others failed.
+	ContinueOnError bool
+	// Merge decides how each source is merged into the ones before it, as in Merge.
+	Merge MergeOptions
+}
+
+// SourceError ... struct
+type SourceError struct {
+	Path string
+	Err  error
+}
+
+func (me *SourceError) Error() string {
+	return fmt.Sprintf("%s: %v", me.Path, me.Err)
+}
+
+func (me *SourceError) Unwrap() error {
+	return me.Err
+}
+
+// MultiLoadError ... struct
+// collects the per-source failures of NewByPaths in argument order.
+type MultiLoadError struct {
+	Errors []*SourceError
+}
+
+func (me *MultiLoadError) Error() string {
+
+	msgs := make([]string, len(me.Errors))
+	for i, v := range me.Errors {
+		msgs[i] = v.Error()
+	}
+
+	return fmt.Sprintf("%d source(s) failed: %s", len(me.Errors), strings.Join(msgs, "; "))
+}
+
+// NewByPaths ... func
+// loads every path (file or URL) concurrently and merges them in argument order
+// by opts.Merge, by default later sources win on conflicts.
+// Load failures are reported as *MultiLoadError.
+func NewByPaths(ctx context.Context, paths []string, opts MultiLoadOptions) (*JSONElement, error) {
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+
+	elms := make([]*JSONElement, len(paths))
+	errs := make([]error, len(paths))
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for i, v := range paths {
+		wg.Add(1)
+
+		go func(i int, argPath string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			elms[i], errs[i] = loadByPath(ctx, argPath, loadConfig{})
+		}(i, v)
+	}
+
+	wg.Wait()
+
+	multiErr := &MultiLoadError{}
+	for i, err := range errs {
+
+		if err != nil {
+			multiErr.Errors = append(multiErr.Errors, &SourceError{Path: paths[i], Err: err})
+		}
+	}
+
+	if len(multiErr.Errors) > 0 && !opts.ContinueOnError {
+		return nil, multiErr
+	}
+
+	var merged interface{}
+	for _, elm := range elms {
+
+		if elm == nil {
+			continue
+		}
+
+		if merged == nil {
+			merged = elm.Raw()
+			continue
+		}
+
+		var err error
+		if merged, err = mergeRawWith([]interface{}{}, merged, elm.Raw(), &opts.Merge); err != nil {
+			return nil, fmt.Errorf("NewByPaths: merge: %w", err)
+		}
+	}
+
+	if len(multiErr.Errors) > 0 {
+		return New(merged), multiErr
+	}
+
+	return New(merged), nil
+}
@@ -0,0 +1,56 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByPaths(t *testing.T) {
+
+	assert := assert.New(t)
+
+	base := filepath.Join(currentDir(), "testdata", "multi", "base.json")
+	override := filepath.Join(currentDir(), "testdata", "multi", "override.json")
+	none := filepath.Join(currentDir(), "testdata", "multi", "none.json")
+
+	root, err := NewByPaths(context.Background(), []string{base, override}, MultiLoadOptions{Concurrency: 1})
+	assert.Nil(err)
+	assert.Equal("api", root.Select("service").AsString())
+	assert.Equal("localhost", root.Select("server", "host").AsString())
+	assert.Equal(9090, root.Select("server", "port").AsInt())
+	assert.True(root.Select("server", "tls").AsBool())
+	assert.Equal(1, root.Select("features").Count())
+
+	root, err = NewByPaths(context.Background(), []string{base, none, override}, MultiLoadOptions{})
+	assert.Nil(root)
+
+	var multiErr *MultiLoadError
+	assert.True(errors.As(err, &multiErr))
+	assert.Equal(1, len(multiErr.Errors))
+	assert.Equal(none, multiErr.Errors[0].Path)
+
+	root, err = NewByPaths(context.Background(), []string{base, none, override}, MultiLoadOptions{ContinueOnError: true})
+	assert.NotNil(err)
+	assert.Equal(9090, root.Select("server", "port").AsInt())
+
+	// merge options
+	root, err = NewByPaths(context.Background(), []string{base, override}, MultiLoadOptions{
+		Merge: MergeOptions{Strategy: MergeKeepExisting, Arrays: ArrayAppend},
+	})
+	assert.Nil(err)
+	assert.Equal(8080, root.Select("server", "port").AsInt())
+	assert.True(root.Select("server", "tls").AsBool())
+	assert.Equal(`["a", "b", "c"]`, root.Select("features").String())
+
+	_, err = NewByPaths(context.Background(), []string{base, override}, MultiLoadOptions{
+		Merge: MergeOptions{OnConflict: func(path []interface{}, existing, incoming *JSONElement) (interface{}, error) {
+			return nil, errors.New("conflict")
+		}},
+	})
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "conflict")
+}
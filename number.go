@@ -0,0 +1,160 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+func decodeNumbers(data []byte) (interface{}, error) {
+
+	var obj interface{}
+
+	// syntax errors (and trailing data) are reported the way Unmarshal does
+	if !json.Valid(data) {
+		return nil, json.Unmarshal(data, &obj)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	err := dec.Decode(&obj)
+
+	return obj, err
+}
+
+// NewByBytesUseNumber ... func
+// same as NewByBytes, numbers are kept as json.Number.
+func NewByBytesUseNumber(data []byte) (*JSONElement, error) {
+	return NewByBytesWithOptions(data, ParseOptions{UseNumber: true})
+}
+
+func numberFloat(raw interface{}) float64 {
+
+	switch typed := raw.(type) {
+	case json.Number:
+		f, _ := typed.Float64()
+		return f
+	case float64:
+		return typed
+	}
+
+	f, _ := jpNumber(raw)
+
+	return f
+}
+
+// AsNumber ... func
+// the number as written in the source with UseNumber, otherwise formatted.
+func (me *JSONElement) AsNumber() json.Number {
+
+	if me.IsNil() {
+		me.Warn("AsNumber: Null Object")
+		return ""
+	}
+
+	switch v := me.raw.(type) {
+	case json.Number:
+		return v
+	case int:
+		return json.Number(strconv.Itoa(v))
+	case int64:
+		return json.Number(strconv.FormatInt(v, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(v, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+
+	me.Warn("AsNumber: Cast: %T", me.raw)
+
+	return ""
+}
+
+// AsInt64 ... func
+// exact for json.Number integers, fractions are truncated.
+func (me *JSONElement) AsInt64() int64 {
+
+	if me.IsNil() {
+		me.Warn("AsInt64: Null Object")
+		return 0
+	}
+
+	switch v := me.raw.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+
+		f, err := v.Float64()
+		if err != nil || f < math.MinInt64 || f >= math.MaxInt64 {
+			me.Warn("AsInt64: Out Of Range: %s", v)
+			return 0
+		}
+
+		return int64(f)
+	case int:
+		return int64(v)
+	case int64:
+		return v
+	case uint64:
+		if v > math.MaxInt64 {
+			me.Warn("AsInt64: Out Of Range: %d", v)
+			return 0
+		}
+		return int64(v)
+	case float64:
+		return int64(v)
+	}
+
+	me.Warn("AsInt64: Cast: %T", me.raw)
+
+	return 0
+}
+
+// AsUint64 ... func
+// same as AsInt64 for the unsigned range, negative numbers are 0 with a warning.
+func (me *JSONElement) AsUint64() uint64 {
+
+	if me.IsNil() {
+		me.Warn("AsUint64: Null Object")
+		return 0
+	}
+
+	switch v := me.raw.(type) {
+	case json.Number:
+		if u, err := strconv.ParseUint(string(v), 10, 64); err == nil {
+			return u
+		}
+
+		f, err := v.Float64()
+		if err != nil || f < 0 || f >= math.MaxUint64 {
+			me.Warn("AsUint64: Out Of Range: %s", v)
+			return 0
+		}
+
+		return uint64(f)
+	case uint64:
+		return v
+	case int:
+		if v >= 0 {
+			return uint64(v)
+		}
+	case int64:
+		if v >= 0 {
+			return uint64(v)
+		}
+	case float64:
+		if v >= 0 && v < math.MaxUint64 {
+			return uint64(v)
+		}
+	default:
+		me.Warn("AsUint64: Cast: %T", me.raw)
+		return 0
+	}
+
+	me.Warn("AsUint64: Out Of Range: %v", me.raw)
+
+	return 0
+}
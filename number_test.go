@@ -0,0 +1,66 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseNumber(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := `{"id": 9007199254740993, "big": 18446744073709551615, "neg": -5, "f": 1.5, "e": 1e3}`
+
+	elm, err := NewByBytesWithOptions([]byte(src), ParseOptions{UseNumber: true})
+	assert.Nil(err)
+
+	assert.Equal(json.Number("9007199254740993"), elm.Select("id").Raw())
+	assert.Equal(int64(9007199254740993), elm.Select("id").AsInt64())
+	assert.Equal(uint64(18446744073709551615), elm.Select("big").AsUint64())
+	assert.Equal(int64(-5), elm.Select("neg").AsInt64())
+	assert.Equal(int64(1), elm.Select("f").AsInt64())
+	assert.Equal(int64(1000), elm.Select("e").AsInt64())
+	assert.Equal(1.5, elm.Select("f").AsFloat())
+	assert.Equal(-5, elm.Select("neg").AsInt())
+	assert.Equal(KindNumber, elm.Select("id").Kind())
+
+	// numbers are written back as they were read
+	assert.Equal(`{"big": 18446744073709551615, "e": 1e3, "f": 1.5, "id": 9007199254740993, "neg": -5}`, func() string {
+		elm.KeyLess = LexicalLess
+		return elm.String()
+	}())
+
+	plain, _ := NewByString(src)
+	assert.NotEqual(int64(9007199254740993), plain.Select("id").AsInt64())
+
+	ordered, err := NewByBytesWithOptions([]byte(src), ParseOptions{UseNumber: true, PreserveOrder: true})
+	assert.Nil(err)
+	assert.Equal(src, ordered.String())
+
+	_, err = NewByBytesUseNumber([]byte(`{"a": 1} 2`))
+	assert.NotNil(err)
+}
+
+func TestAsNumber(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, _ := NewByString(`{"f": 1.5, "i": 3, "s": "x"}`)
+
+	assert.Equal(json.Number("1.5"), elm.Select("f").AsNumber())
+	assert.Equal(json.Number("3"), elm.Select("i").AsNumber())
+
+	warned := 0
+	elm.WarnHandler = func(*JSONElement, string, string, int) { warned++ }
+
+	assert.Equal(json.Number(""), elm.Select("s").AsNumber())
+	assert.Equal(uint64(0), New(-1).AsUint64())
+	assert.Equal(int64(0), elm.Select("s").AsInt64())
+	assert.Equal(2, warned)
+	assert.Equal(uint64(7), New(int64(7)).AsUint64())
+
+	other, _ := NewByBytesUseNumber([]byte(`{"f": 1.50, "i": 3.0, "s": "x"}`))
+	assert.True(rawEqual(elm.Raw(), other.Raw()))
+}
@@ -0,0 +1,414 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// keyOrderState ... struct
+// the insertion order of map keys of a document, by map address.
+// Maps are kept reachable so their addresses are not reused, until they are
+// replaced or removed from the document (see forget).
+type keyOrderState struct {
+	mu   sync.Mutex
+	seq  map[uintptr][]string
+	refs map[uintptr]map[string]interface{}
+}
+
+func newKeyOrderState() *keyOrderState {
+
+	return &keyOrderState{
+		seq:  map[uintptr][]string{},
+		refs: map[uintptr]map[string]interface{}{},
+	}
+}
+
+// keys ... func
+// recorded keys first, keys added behind our back follow in less (lexical) order.
+// A nil state or an unknown map falls back to mapKeys.
+func (me *keyOrderState) keys(obj map[string]interface{}, less func(string, string) bool) []string {
+
+	if me == nil {
+		return mapKeys(obj, less)
+	}
+
+	me.mu.Lock()
+	seq, ok := me.seq[rawAddr(obj)]
+	me.mu.Unlock()
+
+	if !ok {
+		return mapKeys(obj, less)
+	}
+
+	keys := make([]string, 0, len(obj))
+	known := make(map[string]bool, len(seq))
+
+	for _, k := range seq {
+		if _, ok := obj[k]; ok && !known[k] {
+			keys = append(keys, k)
+			known[k] = true
+		}
+	}
+
+	if len(keys) == len(obj) {
+		return keys
+	}
+
+	extra := []string{}
+	for k := range obj {
+		if !known[k] {
+			extra = append(extra, k)
+		}
+	}
+
+	if less == nil {
+		less = LexicalLess
+	}
+
+	sort.Slice(extra, func(i, j int) bool {
+		return less(extra[i], extra[j])
+	})
+
+	return append(keys, extra...)
+}
+
+func (me *keyOrderState) record(obj map[string]interface{}, keys []string) {
+
+	addr := rawAddr(obj)
+
+	me.mu.Lock()
+	me.seq[addr] = keys
+	me.refs[addr] = obj
+	me.mu.Unlock()
+}
+
+func (me *keyOrderState) known(obj map[string]interface{}) bool {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	_, ok := me.seq[rawAddr(obj)]
+
+	return ok
+}
+
+// adopt ... func
+// records the maps in raw that are not known yet, their keys in lexical order.
+func (me *keyOrderState) adopt(raw interface{}) {
+
+	if me == nil {
+		return
+	}
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		if !me.known(typed) {
+			me.record(typed, mapKeys(typed, LexicalLess))
+		}
+		for _, v := range typed {
+			me.adopt(v)
+		}
+	case []interface{}, *[]interface{}:
+		for _, v := range asSlice(typed) {
+			me.adopt(v)
+		}
+	}
+}
+
+// add ... func
+// key goes to the end, unless obj already has it.
+func (me *keyOrderState) add(obj map[string]interface{}, key string) {
+
+	if me == nil {
+		return
+	}
+
+	if _, ok := obj[key]; ok {
+		return
+	}
+
+	addr := rawAddr(obj)
+
+	me.mu.Lock()
+	seq, ok := me.seq[addr]
+	if ok {
+		me.seq[addr] = append(seq, key)
+	}
+	me.mu.Unlock()
+
+	if !ok {
+		me.record(obj, append(mapKeys(obj, LexicalLess), key))
+	}
+}
+
+// remove ... func
+// to be called before key is deleted from obj.
+func (me *keyOrderState) remove(obj map[string]interface{}, key string) {
+
+	if me == nil {
+		return
+	}
+
+	me.forget(obj[key])
+
+	addr := rawAddr(obj)
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	seq := me.seq[addr]
+
+	for i, k := range seq {
+		if k == key {
+			// a new array, keys may be reading the old one
+			me.seq[addr] = append(seq[:i:i], seq[i+1:]...)
+			break
+		}
+	}
+}
+
+// forget ... func
+// drops the maps in raw, which leaves the document. A subtree moved with
+// Select, Delete and Put comes back in lexical order (Move keeps it).
+func (me *keyOrderState) forget(raw interface{}) {
+
+	if me == nil {
+		return
+	}
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		me.drop(typed)
+		for _, v := range typed {
+			me.forget(v)
+		}
+	case []interface{}, *[]interface{}:
+		for _, v := range asSlice(typed) {
+			me.forget(v)
+		}
+	}
+}
+
+// drop ... func
+// forgets obj only, its values stay.
+func (me *keyOrderState) drop(obj map[string]interface{}) {
+
+	if me == nil {
+		return
+	}
+
+	addr := rawAddr(obj)
+
+	me.mu.Lock()
+	delete(me.seq, addr)
+	delete(me.refs, addr)
+	me.mu.Unlock()
+}
+
+// replace ... func
+// old is replaced by new in the document.
+func (me *keyOrderState) replace(old, new interface{}) {
+
+	if me == nil {
+		return
+	}
+
+	if addr := rawAddr(old); addr == 0 || addr != rawAddr(new) {
+		me.forget(old)
+	}
+
+	me.adopt(new)
+}
+
+// rename ... func
+// newKey takes the place of oldKey, to be called before obj is changed.
+func (me *keyOrderState) rename(obj map[string]interface{}, oldKey, newKey string) {
+
+	if me == nil || !me.known(obj) {
+		return
+	}
+
+	keys := []string{}
+
+	for _, k := range me.keys(obj, LexicalLess) {
+		switch k {
+		case oldKey:
+			keys = append(keys, newKey)
+		case newKey:
+		default:
+			keys = append(keys, k)
+		}
+	}
+
+	me.record(obj, keys)
+}
+
+// copyTo ... func
+// records in dst the order of src for cp, a deep copy of src.
+func (me *keyOrderState) copyTo(dst *keyOrderState, src, cp interface{}) error {
+
+	src, err := resolveSpill(src)
+	if err != nil {
+		return err
+	}
+
+	switch typed := src.(type) {
+	case map[string]interface{}:
+		obj, ok := cp.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		keys := me.keys(typed, LexicalLess)
+		dst.record(obj, keys)
+		for _, k := range keys {
+			if err := me.copyTo(dst, typed[k], obj[k]); err != nil {
+				return err
+			}
+		}
+	case []interface{}, *[]interface{}:
+		arrSrc, arrCp := asSlice(typed), asSlice(cp)
+		for i := 0; i < len(arrSrc) && i < len(arrCp); i++ {
+			if err := me.copyTo(dst, arrSrc[i], arrCp[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// unmark ... func
+// the UTF8PassThrough counterpart of unmarkRaw for the recorded keys.
+func (me *keyOrderState) unmark() {
+
+	if me == nil {
+		return
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	for addr, keys := range me.seq {
+		for i, k := range keys {
+			keys[i] = unmarkString(k)
+		}
+		me.seq[addr] = keys
+	}
+}
+
+// orderedDecoder ... struct
+// builds the same values as json.Unmarshal while recording the key order.
+type orderedDecoder struct {
+	dec   *json.Decoder
+	order *keyOrderState
+}
+
+func (me *orderedDecoder) value() (interface{}, error) {
+
+	tok, err := me.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := map[string]interface{}{}
+		keys := []string{}
+
+		for me.dec.More() {
+
+			tok, err := me.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key := tok.(string)
+
+			v, err := me.value()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := obj[key]; !ok {
+				keys = append(keys, key)
+			}
+			obj[key] = v
+		}
+
+		if _, err := me.dec.Token(); err != nil {
+			return nil, err
+		}
+
+		me.order.record(obj, keys)
+
+		return obj, nil
+
+	case json.Delim('['):
+		arr := []interface{}{}
+
+		for me.dec.More() {
+
+			v, err := me.value()
+			if err != nil {
+				return nil, err
+			}
+
+			arr = append(arr, v)
+		}
+
+		if _, err := me.dec.Token(); err != nil {
+			return nil, err
+		}
+
+		return arr, nil
+	}
+
+	return tok, nil
+}
+
+func decodeOrdered(data []byte, order *keyOrderState, useNumber bool) (interface{}, error) {
+
+	// syntax errors (and trailing data) are reported the way Unmarshal does
+	if !json.Valid(data) {
+		var obj interface{}
+		return nil, json.Unmarshal(data, &obj)
+	}
+
+	od := &orderedDecoder{
+		dec:   json.NewDecoder(bytes.NewReader(data)),
+		order: order,
+	}
+
+	if useNumber {
+		od.dec.UseNumber()
+	}
+
+	return od.value()
+}
+
+// NewAsOrderedMap ... func
+// empty map whose keys (and those of the maps put into it) keep insertion order
+// in String, Marshal, Keys and EachMap.
+func NewAsOrderedMap() *JSONElement {
+
+	elm := NewAsMap()
+
+	elm.order = newKeyOrderState()
+	elm.order.adopt(elm.raw)
+
+	return elm
+}
+
+// NewByBytesOrdered ... func
+// same as NewByBytes, map keys keep the order of data.
+func NewByBytesOrdered(data []byte) (*JSONElement, error) {
+	return NewByBytesWithOptions(data, ParseOptions{PreserveOrder: true})
+}
+
+// IsOrdered ... func
+// true when map keys of me keep insertion order.
+func (me *JSONElement) IsOrdered() bool {
+	return me.order != nil
+}
@@ -0,0 +1,154 @@
+package dynajson
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreserveOrderParse(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := `{"openapi": "3.0.0", "info": {"title": "t", "version": "1"}, "paths": {"/z": 1, "/a": 2, "/m": 3}}`
+
+	elm, err := NewByBytesOrdered([]byte(src))
+	assert.Nil(err)
+	assert.True(elm.IsOrdered())
+
+	assert.Equal(src, elm.String())
+	assert.Equal([]string{"openapi", "info", "paths"}, elm.Keys())
+	assert.Equal([]string{"/z", "/a", "/m"}, elm.Select("paths").Keys())
+
+	keys := []string{}
+	elm.Select("paths").EachMap(func(k string, v *JSONElement) (bool, error) {
+		keys = append(keys, k)
+		return true, nil
+	})
+	assert.Equal([]string{"/z", "/a", "/m"}, keys)
+
+	data, err := elm.Marshal(DumpOptions{Indent: " "})
+	assert.Nil(err)
+	assert.Equal("{\n \"openapi\": \"3.0.0\",\n \"info\": {\n  \"title\": \"t\",\n  \"version\": \"1\"\n },\n \"paths\": {\n  \"/z\": 1,\n  \"/a\": 2,\n  \"/m\": 3\n }\n}", string(data))
+
+	data, err = elm.Marshal(DumpOptions{SortKeys: true})
+	assert.Nil(err)
+	assert.Equal(`{"info": {"title": "t", "version": "1"}, "openapi": "3.0.0", "paths": {"/a": 2, "/m": 3, "/z": 1}}`, string(data))
+
+	_, err = NewByBytesOrdered([]byte(`{"a": 1} x`))
+	assert.NotNil(err)
+
+	_, err = NewByBytesOrdered([]byte(`{"a": }`))
+	assert.NotNil(err)
+}
+
+func TestPreserveOrderEdit(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm := NewAsOrderedMap()
+	assert.True(elm.IsOrdered())
+
+	assert.Nil(elm.Put("z", 1))
+	assert.Nil(elm.Put("a", map[string]interface{}{"y": 1, "b": 2}))
+	assert.Nil(elm.Put("m", 3))
+	assert.Equal(`{"z": 1, "a": {"b": 2, "y": 1}, "m": 3}`, elm.String())
+
+	// replace keeps the position, delete and put again moves to the end
+	assert.Nil(elm.Put("z", 9))
+	assert.Equal(`{"z": 9, "a": {"b": 2, "y": 1}, "m": 3}`, elm.String())
+
+	assert.Nil(elm.DeleteByKey("z"))
+	assert.Nil(elm.Put("z", 1))
+	assert.Nil(elm.Select("a").Put("c", 3))
+	assert.Equal(`{"a": {"b": 2, "y": 1, "c": 3}, "m": 3, "z": 1}`, elm.String())
+
+	clone := elm.Clone()
+	assert.True(clone.IsOrdered())
+	assert.Equal(elm.String(), clone.String())
+
+	patch, _ := NewByString(`[{"op": "replace", "path": "/m", "value": 4}]`)
+	assert.Nil(elm.ApplyPatch(patch))
+	assert.Equal(`{"a": {"b": 2, "y": 1, "c": 3}, "m": 4, "z": 1}`, elm.String())
+
+	// keys added outside of Put follow in lexical order
+	obj := elm.Raw().(map[string]interface{})
+	obj["d"] = 1
+	assert.Equal([]string{"a", "m", "z", "d"}, elm.Keys())
+
+	plain, _ := NewByString(`{"b": 1, "a": 2}`)
+	assert.False(plain.IsOrdered())
+}
+
+func TestPreserveOrderPassThrough(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, err := NewByBytesWithOptions([]byte("{\"z\xff\": 1, \"a\": 2}"), ParseOptions{UTF8: UTF8PassThrough, PreserveOrder: true})
+	assert.Nil(err)
+	assert.Equal([]string{"z\xff", "a"}, elm.Keys())
+}
+
+func TestPreserveOrderForget(t *testing.T) {
+
+	assert := assert.New(t)
+
+	entries := func(elm *JSONElement) int {
+		elm.order.mu.Lock()
+		defer elm.order.mu.Unlock()
+		assert.Equal(len(elm.order.seq), len(elm.order.refs))
+		return len(elm.order.seq)
+	}
+
+	root, err := NewByBytesOrdered([]byte(`{"z": {"y": {"x": 1}}, "a": [{"k": 1}, {"k": 2}], "m": {}}`))
+	assert.Nil(err)
+	assert.Equal(6, entries(root))
+
+	// replaced and removed maps are dropped
+	for i := 0; i < 100; i++ {
+		assert.Nil(root.Put("z", map[string]interface{}{"b": 1, "a": 2}))
+	}
+	assert.Equal(5, entries(root))
+
+	assert.Nil(root.DeleteByKey("z"))
+	assert.Equal(4, entries(root))
+
+	assert.Nil(root.Select("a").DeleteRange(0, 1))
+	assert.Equal(3, entries(root))
+
+	assert.Nil(root.Select("a").SetByPos(0, "x"))
+	assert.Equal(2, entries(root))
+
+	assert.Nil(root.ApplyPatch(mustParse(`[{"op": "add", "path": "/m/q", "value": 1}, {"op": "add", "path": "/m/p", "value": 2}]`)))
+	assert.Equal(2, entries(root))
+	assert.Equal(`["x"]`, root.Select("a").String())
+	assert.Equal(2, root.Select("m").Count())
+
+	// a failed patch leaves nothing behind
+	assert.NotNil(root.ApplyPatch(mustParse(`[{"op": "remove", "path": "/none"}]`)))
+	assert.Equal(2, entries(root))
+
+	// keys are appended, not copied: building a large map stays linear
+	big := NewAsOrderedMap()
+	for i := 0; i < 20000; i++ {
+		assert.Nil(big.Put(fmt.Sprintf("k%05d", 20000-i), i))
+	}
+	keys := big.Keys()
+	assert.Equal("k20000", keys[0])
+	assert.Equal("k00001", keys[len(keys)-1])
+
+	assert.Nil(big.DeleteByKey("k10000"))
+	assert.Nil(big.Put("k10000", 0))
+	assert.Equal("k10000", big.Keys()[19999])
+}
+
+func mustParse(data string) *JSONElement {
+
+	elm, err := NewByString(data)
+	if err != nil {
+		panic(err)
+	}
+
+	return elm
+}
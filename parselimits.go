@@ -0,0 +1,150 @@
+package dynajson
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"unicode/utf8"
+)
+
+// ParseLimits ... struct
+// bounds checked on the input before it is decoded, 0 means unlimited.
+// A *LimitError (errors.Is ErrLimitExceeded) is returned when one is exceeded.
+type ParseLimits struct {
+	// MaxDepth is the nesting of maps and arrays, "[[1]]" is 2.
+	MaxDepth int
+	// MaxBytes is the size of the input.
+	MaxBytes int
+	// MaxKeys is the number of keys of any map.
+	MaxKeys int
+	// MaxStringLen is in runes (escapes unquoted), applies to values and keys.
+	MaxStringLen int
+}
+
+func (me ParseLimits) isZero() bool {
+	return me == ParseLimits{}
+}
+
+type parseFrame struct {
+	isMap bool
+	keys  int
+}
+
+// scanString ... func
+// the rune count of the JSON string starting at data[i] (the quote) and the
+// offset after its closing quote.
+func scanString(data []byte, i int) (int, int) {
+
+	n := 0
+
+	for i++; i < len(data); i++ {
+
+		switch c := data[i]; {
+		case c == '"':
+			return n, i + 1
+		case c == '\\':
+			if i+1 < len(data) && data[i+1] == 'u' {
+				// a high surrogate is counted with the low one following it
+				if r, ok := parseEscapeU(data, i); !ok || !isSurrogate(r, 0xD800, 0xDBFF) {
+					n++
+				}
+				i += 5
+				continue
+			}
+			n++
+			i++
+		case utf8.RuneStart(c):
+			n++
+		}
+	}
+
+	return n, i
+}
+
+// checkParseLimits ... func
+// scans data without decoding it, syntax errors are left to the decoder.
+func checkParseLimits(data []byte, limits ParseLimits) error {
+
+	if err := checkLimit("MaxBytes", limits.MaxBytes, len(data)); err != nil {
+		return err
+	}
+
+	if limits.MaxDepth <= 0 && limits.MaxKeys <= 0 && limits.MaxStringLen <= 0 {
+		return nil
+	}
+
+	stack := []parseFrame{}
+	expectKey := false
+
+	for i := 0; i < len(data); {
+
+		switch data[i] {
+		case '"':
+			n, next := scanString(data, i)
+
+			if err := checkLimit("MaxStringLen", limits.MaxStringLen, n); err != nil {
+				return err
+			}
+
+			if expectKey {
+				top := &stack[len(stack)-1]
+				top.keys++
+
+				if err := checkLimit("MaxKeys", limits.MaxKeys, top.keys); err != nil {
+					return err
+				}
+
+				expectKey = false
+			}
+
+			i = next
+			continue
+
+		case '{', '[':
+			stack = append(stack, parseFrame{isMap: data[i] == '{'})
+
+			if err := checkLimit("MaxDepth", limits.MaxDepth, len(stack)); err != nil {
+				return err
+			}
+
+			expectKey = data[i] == '{'
+
+		case '}', ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+
+			expectKey = false
+
+		case ',':
+			expectKey = len(stack) > 0 && stack[len(stack)-1].isMap
+		}
+
+		i++
+	}
+
+	return nil
+}
+
+// NewByBytesLimited ... func
+// NewByBytes refusing input beyond limits, e.g. user-supplied documents.
+func NewByBytesLimited(data []byte, limits ParseLimits) (*JSONElement, error) {
+
+	return NewByBytesWithOptions(data, ParseOptions{Limits: limits})
+}
+
+// NewByReaderWithOptions ... func
+// reads no more than Limits.MaxBytes (+1 to detect the excess) of r.
+func NewByReaderWithOptions(r io.Reader, opts ParseOptions) (*JSONElement, error) {
+
+	if opts.Limits.MaxBytes > 0 {
+		r = io.LimitReader(r, int64(opts.Limits.MaxBytes)+1)
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("NewByReaderWithOptions: %w", err)
+	}
+
+	return NewByBytesWithOptions(data, opts)
+}
@@ -0,0 +1,99 @@
+package dynajson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimits(t *testing.T) {
+
+	assert := assert.New(t)
+
+	limitOf := func(err error) *LimitError {
+		var limitErr *LimitError
+		if !errors.As(err, &limitErr) {
+			return nil
+		}
+		return limitErr
+	}
+
+	data := []byte(`{"a": [1, {"b": "xyz"}], "c": "{[\"", "d": {}}`)
+
+	root, err := NewByBytesLimited(data, ParseLimits{MaxDepth: 3, MaxBytes: len(data), MaxKeys: 3, MaxStringLen: 3})
+	assert.Nil(err)
+	assert.Equal("xyz", root.Select("a", 1, "b").AsString())
+
+	// brackets and commas in strings are not structure
+	_, err = NewByBytesLimited(data, ParseLimits{MaxDepth: 2})
+	assert.True(errors.Is(err, ErrLimitExceeded))
+	assert.Equal(&LimitError{Limit: "MaxDepth", Max: 2, Actual: 3}, limitOf(err))
+
+	_, err = NewByBytesLimited(data, ParseLimits{MaxBytes: len(data) - 1})
+	assert.Equal(&LimitError{Limit: "MaxBytes", Max: len(data) - 1, Actual: len(data)}, limitOf(err))
+
+	_, err = NewByBytesLimited(data, ParseLimits{MaxKeys: 2})
+	assert.Equal(&LimitError{Limit: "MaxKeys", Max: 2, Actual: 3}, limitOf(err))
+
+	_, err = NewByBytesLimited(data, ParseLimits{MaxStringLen: 2})
+	assert.Equal(&LimitError{Limit: "MaxStringLen", Max: 2, Actual: 3}, limitOf(err))
+
+	// escapes and multibyte runes count as one
+	_, err = NewByBytesLimited([]byte(`["é\n😀`+"é"+`"]`), ParseLimits{MaxStringLen: 4})
+	assert.Nil(err)
+
+	_, err = NewByBytesLimited([]byte(`["é\n😀`+"é"+`"]`), ParseLimits{MaxStringLen: 3})
+	assert.Equal(&LimitError{Limit: "MaxStringLen", Max: 3, Actual: 4}, limitOf(err))
+
+	// a surrogate pair is one rune
+	escaped := strings.Replace(`["Xu00e9Xud83dXude00X/"]`, "X", string(rune(92)), -1)
+	_, err = NewByBytesLimited([]byte(escaped), ParseLimits{MaxStringLen: 3})
+	assert.Nil(err)
+
+	_, err = NewByBytesLimited([]byte(escaped), ParseLimits{MaxStringLen: 2})
+	assert.Equal(&LimitError{Limit: "MaxStringLen", Max: 2, Actual: 3}, limitOf(err))
+
+	// keys are counted per map
+	_, err = NewByBytesLimited([]byte(`{"a": {"x": 1, "y": 2}, "b": {"x": 1, "y": 2}}`), ParseLimits{MaxKeys: 2})
+	assert.Nil(err)
+
+	// deeply nested input fails before it is decoded
+	deep := strings.Repeat("[", 100000) + strings.Repeat("]", 100000)
+	_, err = NewByBytesLimited([]byte(deep), ParseLimits{MaxDepth: 64})
+	assert.Equal(&LimitError{Limit: "MaxDepth", Max: 64, Actual: 65}, limitOf(err))
+
+	// with other options
+	_, err = NewByBytesWithOptions([]byte("{a: [[1]], // ]]]]\n}"), ParseOptions{Lenient: true, Limits: ParseLimits{MaxDepth: 3}})
+	assert.Nil(err)
+
+	_, err = NewByBytesWithOptions([]byte("{a: [[1]]}"), ParseOptions{Lenient: true, Limits: ParseLimits{MaxDepth: 2}})
+	assert.True(errors.Is(err, ErrLimitExceeded))
+
+	_, err = NewByBytesWithOptions([]byte("{a: 1}"), ParseOptions{Lenient: true, Limits: ParseLimits{MaxBytes: 5}})
+	assert.True(errors.Is(err, ErrLimitExceeded))
+
+	root, err = NewByBytesWithOptions([]byte(`{"b": 1, "a": 2}`), ParseOptions{PreserveOrder: true, Limits: ParseLimits{MaxKeys: 2}})
+	assert.Nil(err)
+	assert.Equal(`{"b": 1, "a": 2}`, root.String())
+}
+
+func TestNewByReaderWithOptions(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByReaderWithOptions(strings.NewReader(`{"a": 1}`), ParseOptions{Limits: ParseLimits{MaxBytes: 8}})
+	assert.Nil(err)
+	assert.Equal(1, root.Select("a").AsInt())
+
+	_, err = NewByReaderWithOptions(strings.NewReader(`{"a": 10}`+strings.Repeat(" ", 1<<20)), ParseOptions{Limits: ParseLimits{MaxBytes: 8}})
+	assert.True(errors.Is(err, ErrLimitExceeded))
+
+	var limitErr *LimitError
+	assert.True(errors.As(err, &limitErr))
+	assert.Equal(9, limitErr.Actual)
+
+	_, err = NewByReaderWithOptions(strings.NewReader(`[`), ParseOptions{})
+	assert.NotNil(err)
+}
@@ -0,0 +1,598 @@
+package dynajson
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// editableCopy ... func
+// deep copy where every array becomes editable (*[]interface{}), spilled values are read.
+func editableCopy(raw interface{}) (interface{}, error) {
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			cp, err := editableCopy(v)
+			if err != nil {
+				return nil, err
+			}
+			obj[k] = cp
+		}
+		return obj, nil
+	case []interface{}, *[]interface{}:
+		src := asSlice(typed)
+		arr := make([]interface{}, len(src))
+		for i, v := range src {
+			cp, err := editableCopy(v)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = cp
+		}
+		return &arr, nil
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return nil, err
+		}
+		return editableCopy(obj)
+	}
+
+	return raw, nil
+}
+
+// rawEqual ... func
+// JSON equality, int and float64 compare by value and both array forms are alike.
+func rawEqual(a, b interface{}) bool {
+	return rawEqualBy(a, b, true)
+}
+
+// rawEqualBy ... func
+// numbers are compared by value when canonical, otherwise by the text they are written as.
+// A spilled value that can not be read is unequal, see equalRawBy.
+func rawEqualBy(a, b interface{}, canonical bool) bool {
+
+	eq, err := equalRawBy(a, b, canonical)
+
+	return err == nil && eq
+}
+
+// equalRawBy ... func
+// rawEqualBy failing when a spilled value can not be read.
+func equalRawBy(a, b interface{}, canonical bool) (bool, error) {
+
+	a, err := resolveSpill(a)
+	if err != nil {
+		return false, err
+	}
+
+	b, err = resolveSpill(b)
+	if err != nil {
+		return false, err
+	}
+
+	if fa, ok := jpNumber(a); ok {
+		fb, ok := jpNumber(b)
+		if !canonical {
+			return ok && numberText(a) == numberText(b), nil
+		}
+		return ok && fa == fb, nil
+	}
+
+	if arrA := asSlice(a); arrA != nil {
+
+		arrB := asSlice(b)
+		if arrB == nil || len(arrA) != len(arrB) {
+			return false, nil
+		}
+
+		for i := range arrA {
+			if eq, err := equalRawBy(arrA[i], arrB[i], canonical); !eq || err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	if objA, ok := a.(map[string]interface{}); ok {
+
+		objB, ok := b.(map[string]interface{})
+		if !ok || len(objA) != len(objB) {
+			return false, nil
+		}
+
+		for k, v := range objA {
+			w, ok := objB[k]
+			if !ok {
+				return false, nil
+			}
+			if eq, err := equalRawBy(v, w, canonical); !eq || err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	if asSlice(b) != nil {
+		return false, nil
+	}
+
+	if _, ok := b.(map[string]interface{}); ok {
+		return false, nil
+	}
+
+	return a == b, nil
+}
+
+type patchDoc struct {
+	root interface{}
+}
+
+func (me *patchDoc) get(tokens []string) (interface{}, bool) {
+
+	raw := me.root
+
+	for _, v := range tokens {
+
+		switch typed := raw.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if raw, ok = typed[v]; !ok {
+				return nil, false
+			}
+		case *[]interface{}:
+			pos, err := strconv.Atoi(v)
+			if err != nil || pos < 0 || pos >= len(*typed) || strconv.Itoa(pos) != v {
+				return nil, false
+			}
+			raw = (*typed)[pos]
+		default:
+			return nil, false
+		}
+	}
+
+	return raw, true
+}
+
+func (me *patchDoc) parent(tokens []string) (interface{}, string, error) {
+
+	parent, ok := me.get(tokens[:len(tokens)-1])
+	if !ok {
+		return nil, "", errPatch("Parent Not Found")
+	}
+
+	return parent, tokens[len(tokens)-1], nil
+}
+
+type errPatch string
+
+func (me errPatch) Error() string {
+	return string(me)
+}
+
+func arrayPos(arr []interface{}, last string, allowEnd bool) (int, error) {
+
+	if allowEnd && last == "-" {
+		return len(arr), nil
+	}
+
+	pos, err := strconv.Atoi(last)
+	if err != nil || pos < 0 || strconv.Itoa(pos) != last {
+		return 0, errPatch("Bad Index: " + last)
+	}
+
+	max := len(arr) - 1
+	if allowEnd {
+		max = len(arr)
+	}
+
+	if pos > max {
+		return 0, errPatch("Index Out Of Range: " + last)
+	}
+
+	return pos, nil
+}
+
+func (me *patchDoc) add(tokens []string, val interface{}) error {
+
+	if len(tokens) == 0 {
+		me.root = val
+		return nil
+	}
+
+	parent, last, err := me.parent(tokens)
+	if err != nil {
+		return err
+	}
+
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		typed[last] = val
+		return nil
+	case *[]interface{}:
+		pos, err := arrayPos(*typed, last, true)
+		if err != nil {
+			return err
+		}
+
+		*typed = append(*typed, nil)
+		copy((*typed)[pos+1:], (*typed)[pos:])
+		(*typed)[pos] = val
+
+		return nil
+	}
+
+	return errPatch("Parent Not Container")
+}
+
+func (me *patchDoc) remove(tokens []string) (interface{}, error) {
+
+	if len(tokens) == 0 {
+		return nil, errPatch("Root Can Not Be Removed")
+	}
+
+	parent, last, err := me.parent(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typed := parent.(type) {
+	case map[string]interface{}:
+		val, ok := typed[last]
+		if !ok {
+			return nil, errPatch("No Key: " + last)
+		}
+
+		delete(typed, last)
+
+		return val, nil
+	case *[]interface{}:
+		pos, err := arrayPos(*typed, last, false)
+		if err != nil {
+			return nil, err
+		}
+
+		val := (*typed)[pos]
+		*typed = remove(*typed, pos)
+
+		return val, nil
+	}
+
+	return nil, errPatch("Parent Not Container")
+}
+
+func (me *patchDoc) apply(op *JSONElement) error {
+
+	name, _ := op.Select("op").Raw().(string)
+
+	pathStr, ok := op.Select("path").Raw().(string)
+	if !ok {
+		return errPatch("No path")
+	}
+
+	tokens, err := ParsePointer(pathStr)
+	if err != nil || strings.HasPrefix(pathStr, "#") {
+		return errPatch("Bad path: " + pathStr)
+	}
+
+	value := func() (interface{}, error) {
+
+		obj, _ := op.Raw().(map[string]interface{})
+
+		v, ok := obj["value"]
+		if !ok {
+			return nil, errPatch("No value")
+		}
+
+		return editableCopy(v)
+	}
+
+	from := func() ([]string, error) {
+
+		fromStr, ok := op.Select("from").Raw().(string)
+		if !ok {
+			return nil, errPatch("No from")
+		}
+
+		return ParsePointer(fromStr)
+	}
+
+	switch name {
+	case "add":
+		v, err := value()
+		if err != nil {
+			return err
+		}
+
+		return me.add(tokens, v)
+
+	case "remove":
+		_, err := me.remove(tokens)
+		return err
+
+	case "replace":
+		v, err := value()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := me.get(tokens); !ok {
+			return errPatch("Not Found: " + pathStr)
+		}
+
+		if len(tokens) > 0 {
+			if _, err := me.remove(tokens); err != nil {
+				return err
+			}
+		}
+
+		return me.add(tokens, v)
+
+	case "move":
+		fromTokens, err := from()
+		if err != nil {
+			return err
+		}
+
+		if len(tokens) > len(fromTokens) && strings.Join(tokens[:len(fromTokens)], "/") == strings.Join(fromTokens, "/") {
+			return errPatch("Move Into Itself")
+		}
+
+		v, err := me.remove(fromTokens)
+		if err != nil {
+			return err
+		}
+
+		return me.add(tokens, v)
+
+	case "copy":
+		fromTokens, err := from()
+		if err != nil {
+			return err
+		}
+
+		v, ok := me.get(fromTokens)
+		if !ok {
+			return errPatch("from Not Found")
+		}
+
+		cp, err := editableCopy(v)
+		if err != nil {
+			return err
+		}
+
+		return me.add(tokens, cp)
+
+	case "test":
+		v, err := value()
+		if err != nil {
+			return err
+		}
+
+		cur, ok := me.get(tokens)
+		if !ok || !rawEqual(cur, v) {
+			return errPatch("Test Failed: " + pathStr)
+		}
+
+		return nil
+	}
+
+	return errPatch("Bad op: " + name)
+}
+
+// ApplyPatch ... func
+// applies an RFC 6902 JSON Patch (add, remove, replace, move, copy, test) to me.
+// The patch is atomic, on error me is left unchanged. Arrays of me become editable.
+func (me *JSONElement) ApplyPatch(patch *JSONElement) error {
+
+	if me.isReadonly() {
+		return me.fail("ApplyPatch", ErrorReadonly, "")
+	}
+
+	if !patch.IsArray() {
+		return me.Errorf("ApplyPatch: Patch Not Array: %T", patch.Raw())
+	}
+
+	if i, err := me.applyOps(patch.AsArray()); err != nil {
+		return me.Errorf("ApplyPatch: pos=[%d]: %w", i, err)
+	}
+
+	return nil
+}
+
+// applyOps ... func
+// applies ops to a copy of me which then replaces it, the position of the
+// failed op on error.
+func (me *JSONElement) applyOps(ops []*JSONElement) (int, error) {
+
+	root, err := editableCopy(me.raw)
+	if err != nil {
+		return 0, err
+	}
+
+	doc := &patchDoc{root: root}
+
+	if me.order != nil {
+		if err := me.order.copyTo(me.order, me.raw, doc.root); err != nil {
+			me.order.forget(doc.root)
+			return 0, err
+		}
+	}
+
+	for i, op := range ops {
+
+		if err := doc.apply(op); err != nil {
+			me.order.forget(doc.root)
+			return i, err
+		}
+	}
+
+	old := me.raw
+	if err := me.replaceRaw(doc.root); err != nil {
+		me.order.forget(doc.root)
+		return len(ops) - 1, err
+	}
+
+	me.order.forget(old)
+
+	return 0, nil
+}
+
+// Move ... func
+// moves the value at the JSON Pointer from to to, same as the move operation of
+// JSON Patch: to is added (replaced in maps, inserted in arrays) and from may
+// not be a parent of to.
+func (me *JSONElement) Move(from, to string) error {
+
+	if me.isReadonly() {
+		return me.fail("Move", ErrorReadonly, "")
+	}
+
+	op := New(map[string]interface{}{"op": "move", "from": from, "path": to})
+
+	if _, err := me.applyOps([]*JSONElement{op}); err != nil {
+		return me.Errorf("Move: %s -> %s: %w", from, to, err)
+	}
+
+	return nil
+}
+
+// Copy ... func
+// copies the value at the JSON Pointer from to to, same as the copy operation
+// of JSON Patch.
+func (me *JSONElement) Copy(from, to string) error {
+
+	if me.isReadonly() {
+		return me.fail("Copy", ErrorReadonly, "")
+	}
+
+	op := New(map[string]interface{}{"op": "copy", "from": from, "path": to})
+
+	if _, err := me.applyOps([]*JSONElement{op}); err != nil {
+		return me.Errorf("Copy: %s -> %s: %w", from, to, err)
+	}
+
+	return nil
+}
+
+func diffRaw(path []interface{}, a, b interface{}, ops []interface{}) ([]interface{}, error) {
+
+	a, err := resolveSpill(a)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	b, err = resolveSpill(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	eq, err := equalRawBy(a, b, true)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	if eq {
+		return ops, nil
+	}
+
+	op := func(name string, path []interface{}, val interface{}, withValue bool) map[string]interface{} {
+
+		ret := map[string]interface{}{
+			"op":   name,
+			"path": Path2Pointer(path),
+		}
+
+		if withValue {
+			ret["value"] = copyRaw(val)
+		}
+
+		return ret
+	}
+
+	objA, okA := a.(map[string]interface{})
+	objB, okB := b.(map[string]interface{})
+
+	if okA && okB {
+
+		keys := []string{}
+		for k := range objA {
+			keys = append(keys, k)
+		}
+		for k := range objB {
+			if _, ok := objA[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+
+			va, inA := objA[k]
+			vb, inB := objB[k]
+			sub := appendParents(path, k)
+
+			switch {
+			case !inB:
+				ops = append(ops, op("remove", sub, nil, false))
+			case !inA:
+				ops = append(ops, op("add", sub, vb, true))
+			default:
+				if ops, err = diffRaw(sub, va, vb, ops); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		return ops, nil
+	}
+
+	arrA, arrB := asSlice(a), asSlice(b)
+
+	if arrA != nil && arrB != nil {
+
+		common := len(arrA)
+		if len(arrB) < common {
+			common = len(arrB)
+		}
+
+		for i := 0; i < common; i++ {
+			if ops, err = diffRaw(appendParents(path, i), arrA[i], arrB[i], ops); err != nil {
+				return nil, err
+			}
+		}
+
+		for i := len(arrA) - 1; i >= common; i-- {
+			ops = append(ops, op("remove", appendParents(path, i), nil, false))
+		}
+
+		for i := common; i < len(arrB); i++ {
+			ops = append(ops, op("add", appendParents(path, i), arrB[i], true))
+		}
+
+		return ops, nil
+	}
+
+	return append(ops, op("replace", path, b, true)), nil
+}
+
+// DiffAsPatch ... func
+// returns an RFC 6902 JSON Patch that turns me into other (map keys in lexical order).
+func (me *JSONElement) DiffAsPatch(other *JSONElement) (*JSONElement, error) {
+
+	if other == nil {
+		return nil, me.Errorf("DiffAsPatch: other is nil")
+	}
+
+	ops, err := diffRaw([]interface{}{}, me.Raw(), other.Raw(), []interface{}{})
+	if err != nil {
+		return nil, me.Errorf("DiffAsPatch: %w", err)
+	}
+
+	return New(&ops), nil
+}
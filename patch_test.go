@@ -0,0 +1,120 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"baz": "qux", "foo": "bar", "arr": [1, 2, 3], "obj": {"a": {"b": 1}}}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	patch, err := NewByString(`[
+		{"op": "test", "path": "/arr/1", "value": 2.0},
+		{"op": "replace", "path": "/baz", "value": "boo"},
+		{"op": "add", "path": "/hello", "value": ["world"]},
+		{"op": "remove", "path": "/foo"},
+		{"op": "add", "path": "/arr/1", "value": 9},
+		{"op": "add", "path": "/arr/-", "value": 4},
+		{"op": "move", "from": "/obj/a", "path": "/moved"},
+		{"op": "copy", "from": "/moved", "path": "/obj/copied"},
+		{"op": "add", "path": "/hello/-", "value": null}
+	]`)
+	assert.Nil(err)
+
+	assert.Nil(root.ApplyPatch(patch))
+	assert.Equal(`{"arr": [1, 9, 2, 3, 4], "baz": "boo", "hello": ["world", null], "moved": {"b": 1}, "obj": {"copied": {"b": 1}}}`, root.String())
+
+	assert.Nil(root.Select("hello").Append("more"))
+
+	before := root.String()
+	for _, bad := range []string{
+		`[{"op": "test", "path": "/baz", "value": "nope"}]`,
+		`[{"op": "remove", "path": "/none"}]`,
+		`[{"op": "add", "path": "/arr/9", "value": 1}]`,
+		`[{"op": "add", "path": "/x/y", "value": 1}]`,
+		`[{"op": "replace", "path": "/none", "value": 1}]`,
+		`[{"op": "move", "from": "/obj", "path": "/obj/x"}]`,
+		`[{"op": "add", "path": "/ok", "value": 1}, {"op": "bad", "path": "/"}]`,
+		`[{"op": "add", "path": "/nvalue"}]`,
+		`{"op": "add"}`,
+	} {
+		patch, err := NewByString(bad)
+		assert.Nil(err)
+		assert.NotNil(root.ApplyPatch(patch), bad)
+	}
+	assert.Equal(before, root.String())
+
+	child := root.Select("obj")
+	patch, err = NewByString(`[{"op": "add", "path": "/n", "value": 1}]`)
+	assert.Nil(err)
+	assert.Nil(child.ApplyPatch(patch))
+	assert.Equal(1, root.Select("obj", "n").AsInt())
+
+	patch, err = NewByString(`[{"op": "replace", "path": "", "value": {"new": true}}]`)
+	assert.Nil(err)
+	assert.Nil(root.ApplyPatch(patch))
+	assert.True(root.Select("new").AsBool())
+}
+
+func TestDiffAsPatch(t *testing.T) {
+
+	assert := assert.New(t)
+
+	a, err := NewByString(`{"same": 1, "gone": true, "obj": {"x": 1, "y": [1, 2, 3]}, "arr": [1, 2], "type": "s"}`)
+	assert.Nil(err)
+
+	b, err := NewByString(`{"same": 1.0, "new": null, "obj": {"x": 2, "y": [1]}, "arr": [1, 2, {"z": 0}], "type": {"k": 1}}`)
+	assert.Nil(err)
+
+	patch, err := a.DiffAsPatch(b)
+	assert.Nil(err)
+	patch.KeyLess = LexicalLess
+
+	assert.Equal(`[{"op": "add", "path": "/arr/2", "value": {"z": 0}}, {"op": "remove", "path": "/gone"}, {"op": "add", "path": "/new", "value": null}, {"op": "replace", "path": "/obj/x", "value": 2}, {"op": "remove", "path": "/obj/y/2"}, {"op": "remove", "path": "/obj/y/1"}, {"op": "replace", "path": "/type", "value": {"k": 1}}]`, patch.String())
+
+	assert.Nil(a.ApplyPatch(patch))
+	assert.True(rawEqual(a.Raw(), b.Raw()))
+
+	patch, err = a.DiffAsPatch(b)
+	assert.Nil(err)
+	assert.Equal(0, patch.Count())
+}
+
+func TestMoveCopy(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": 1, "c": [1, 2]}, "d": []}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	// renaming a key
+	assert.Nil(root.Move("/a/b", "/a/x"))
+	assert.Equal(`{"a": {"c": [1, 2], "x": 1}, "d": []}`, root.String())
+
+	assert.Nil(root.Move("/a/c/1", "/d/-"))
+	assert.Equal(`{"a": {"c": [1], "x": 1}, "d": [2]}`, root.String())
+
+	assert.Nil(root.Copy("/a", "/d/0"))
+	assert.Equal(`{"a": {"c": [1], "x": 1}, "d": [{"c": [1], "x": 1}, 2]}`, root.String())
+
+	// copied, not shared
+	assert.Nil(root.Select("d", 0).Put("x", 2))
+	assert.Equal(1, root.Select("a", "x").AsInt())
+
+	assert.NotNil(root.Move("/a", "/a/y"))
+	assert.NotNil(root.Move("/q", "/r"))
+	assert.NotNil(root.Copy("/q", "/r"))
+	assert.Equal(`{"a": {"c": [1], "x": 1}, "d": [{"c": [1], "x": 2}, 2]}`, root.String())
+
+	root.Readonly = true
+	assert.True(errors.Is(root.Move("/a", "/b"), ErrReadonly))
+	assert.True(errors.Is(root.Copy("/a", "/b"), ErrReadonly))
+}
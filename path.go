@@ -0,0 +1,269 @@
+package dynajson
+
+// SlashPath2Keys ... func
+// converts a "/" separated path into Select keys, numeric segments become
+// indexes where the element on the way is an array.
+func (me *JSONElement) SlashPath2Keys(path string) []interface{} {
+
+	return me.pointerKeys(splitStreamPath(path))
+}
+
+func asSlice(arg interface{}) []interface{} {
+
+	switch v := arg.(type) {
+	case []interface{}:
+		return v
+	case *[]interface{}:
+		return *v
+	}
+
+	return nil
+}
+
+// setChild ... func
+// replaces the value of key (map) or pos (array, in range) of me.
+func (me *JSONElement) setChild(key interface{}, val interface{}) error {
+
+	if me.IsNil() {
+		return me.fail("Set", ErrorNilObject, "key=[%v]", key)
+	}
+
+	if me.isReadonly() {
+		return me.fail("Set", ErrorReadonly, "key=[%v]", key)
+	}
+
+	switch typed := key.(type) {
+	case string:
+		return me.Put(typed, val)
+	case int:
+		me.unshare()
+
+		arr := asSlice(me.raw)
+		if arr == nil {
+			return me.fail("Set", ErrorNotArray, "pos=[%d]: %T", typed, me.raw)
+		}
+
+		idx := typed
+		if idx < 0 {
+			// -1 is the last element
+			idx += len(arr)
+		}
+
+		if idx < 0 || idx >= len(arr) {
+			return me.fail("Set", ErrorOverflow, "pos=[%d]: length %d", typed, len(arr))
+		}
+
+		newRaw := elm2Raw(val)
+		if err := me.checkLimits(arr[idx], newRaw); err != nil {
+			return me.Errorf("pos=[%d]: %w", typed, err)
+		}
+
+		me.order.replace(arr[idx], newRaw)
+
+		arr[idx] = newRaw
+		me.notify("replace", idx, newRaw)
+
+		return nil
+	}
+
+	return me.fail("Set", ErrorBadArgument, "%T", key)
+}
+
+// selectParent ... func
+// returns the parent of the node at keys and the last key.
+func (me *JSONElement) selectParent(keys []interface{}) (*JSONElement, interface{}, error) {
+
+	if len(keys) == 0 {
+		return nil, nil, me.Errorf("No key")
+	}
+
+	parent := me
+	if len(keys) > 1 {
+		parent = me.Select(keys[:len(keys)-1])
+	}
+
+	if parent.IsNil() {
+		return nil, nil, me.Errorf("%s: Parent Not Found", FullPath2Str(keys, "/"))
+	}
+
+	return parent, keys[len(keys)-1], nil
+}
+
+// replaceRaw ... func
+// swaps the whole value of me, in the container of its parent too, within the limits.
+func (me *JSONElement) replaceRaw(raw interface{}) error {
+
+	if err := me.checkReplaceLimits(raw); err != nil {
+		return err
+	}
+
+	me.setRaw(raw)
+	me.notifyReplaced()
+
+	return nil
+}
+
+// setRaw ... func
+// replaceRaw without a notification, for raw of the same value.
+func (me *JSONElement) setRaw(raw interface{}) {
+
+	if me.parent != nil {
+		me.parent.unshare()
+
+		if _, ok := containerRaw(me.parent.raw, me.key); ok {
+			setContainerRaw(me.parent.raw, me.key, raw)
+		}
+	}
+
+	me.raw = raw
+}
+
+// makeEditable ... func
+// turns a parsed array of me into an editable one, in its parent too.
+func (me *JSONElement) makeEditable() {
+
+	if arr, ok := me.raw.([]interface{}); ok {
+		// the value is the same, subscribers are told by the change that follows
+		editable := append([]interface{}{}, arr...)
+		me.setRaw(&editable)
+	}
+}
+
+// growArray ... func
+// pads the array of me with null up to n elements, a parsed array becomes editable.
+func (me *JSONElement) growArray(n int) error {
+
+	arr := asSlice(me.raw)
+	if arr == nil {
+		return me.fail("PutPath", ErrorNotArray, "%T", me.raw)
+	}
+
+	if len(arr) >= n {
+		return nil
+	}
+
+	me.makeEditable()
+
+	pad := make([]interface{}, n-len(arr))
+
+	return me.Append(pad[0], pad[1:]...)
+}
+
+// PutPath ... func
+// sets val at keys (string for maps, int for arrays), creating the maps and
+// arrays on the way like mkdir -p. Arrays are padded with null up to an index.
+// Existing values of another type are not replaced.
+func (me *JSONElement) PutPath(val interface{}, keys ...interface{}) error {
+
+	if len(keys) == 0 {
+		return me.fail("PutPath", ErrorBadArgument, "no key")
+	}
+
+	if me.IsNil() {
+		return me.fail("PutPath", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return me.fail("PutPath", ErrorReadonly, "")
+	}
+
+	cur := me
+
+	for i, key := range keys {
+
+		last := i == len(keys)-1
+
+		var fresh interface{}
+		if !last {
+			switch keys[i+1].(type) {
+			case string:
+				fresh = map[string]interface{}{}
+			case int:
+				fresh = &[]interface{}{}
+			default:
+				return me.fail("PutPath", ErrorBadArgument, "%T", keys[i+1])
+			}
+		}
+
+		switch typed := key.(type) {
+		case string:
+			obj, ok := cur.raw.(map[string]interface{})
+			if !ok {
+				return me.fail("PutPath", ErrorNotMap, "%s: %T", FullPath2Str(keys[:i], "/"), cur.raw)
+			}
+
+			if last {
+				return cur.Put(typed, val)
+			}
+
+			if obj[typed] == nil {
+				if err := cur.Put(typed, fresh); err != nil {
+					return me.Errorf("PutPath: %w", err)
+				}
+			}
+
+			cur = cur.SelectByKey(typed)
+
+		case int:
+			if typed < 0 {
+				return me.fail("PutPath", ErrorOverflow, "pos=[%d]: negative index", typed)
+			}
+
+			if err := cur.growArray(typed + 1); err != nil {
+				return me.Errorf("PutPath: %s: %w", FullPath2Str(keys[:i], "/"), err)
+			}
+
+			if last {
+				return cur.setChild(typed, val)
+			}
+
+			if asSlice(cur.raw)[typed] == nil {
+				if err := cur.setChild(typed, fresh); err != nil {
+					return me.Errorf("PutPath: %w", err)
+				}
+			}
+
+			cur = cur.SelectByPos(typed)
+
+		default:
+			return me.fail("PutPath", ErrorBadArgument, "%T", key)
+		}
+	}
+
+	return nil
+}
+
+// Has ... func
+// reports whether keys (string for maps, int for arrays) lead to a value in me,
+// null included, without warnings or allocating children.
+func (me *JSONElement) Has(keys ...interface{}) bool {
+
+	if len(keys) == 0 {
+		return false
+	}
+
+	raw := me.Raw()
+
+	for _, key := range keys {
+
+		container, err := resolveSpill(raw)
+		if err != nil {
+			return false
+		}
+
+		v, ok := containerRaw(container, key)
+		if !ok {
+			return false
+		}
+
+		raw = v
+	}
+
+	return true
+}
+
+// Exists ... func
+// reports whether me is a map holding key.
+func (me *JSONElement) Exists(key string) bool {
+	return me.Has(key)
+}
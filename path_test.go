@@ -0,0 +1,74 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPutPath(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := NewAsMap()
+	root.KeyLess = LexicalLess
+
+	assert.Nil(root.PutPath(42, "a", "b", 3, "c"))
+	assert.Equal(`{"a": {"b": [null, null, null, {"c": 42}]}}`, root.String())
+
+	// existing containers are kept
+	assert.Nil(root.PutPath("x", "a", "b", 0))
+	assert.Nil(root.PutPath(true, "a", "d"))
+	assert.Equal(`{"a": {"b": ["x", null, null, {"c": 42}], "d": true}}`, root.String())
+
+	// parsed arrays grow too
+	doc, err := NewByString(`{"list": [1]}`)
+	assert.Nil(err)
+	assert.Nil(doc.PutPath("v", "list", 2, "k"))
+	assert.Equal(`{"list": [1, null, {"k": "v"}]}`, doc.String())
+
+	// not replaced, nothing created
+	assert.NotNil(root.PutPath(1, "a", "d", "e"))
+	assert.NotNil(root.PutPath(1, "a", "b", "e"))
+	assert.NotNil(root.PutPath(1, "a", -1))
+	assert.NotNil(root.PutPath(1))
+	assert.NotNil(root.PutPath(1, "a", 1.5))
+	assert.Equal(`{"a": {"b": ["x", null, null, {"c": 42}], "d": true}}`, root.String())
+
+	root.Readonly = true
+	assert.NotNil(root.PutPath(1, "z"))
+}
+
+func TestHas(t *testing.T) {
+
+	assert := assert.New(t)
+
+	warned := 0
+
+	root, err := NewByString(`{"a": {"b": [1, null]}, "n": null}`)
+	assert.Nil(err)
+	root.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		warned++
+	}
+
+	assert.True(root.Has("a"))
+	assert.True(root.Has("a", "b", 1))
+	assert.True(root.Has("n"))
+	assert.False(root.Has("a", "b", 2))
+	assert.False(root.Has("a", "b", -1))
+	assert.False(root.Has("a", "x"))
+	assert.False(root.Has("a", "b", "c"))
+	assert.False(root.Has("n", "x"))
+	assert.False(root.Has("a", 1.5))
+	assert.False(root.Has())
+
+	assert.True(root.Exists("n"))
+	assert.False(root.Exists("x"))
+	assert.True(root.Select("a").Exists("b"))
+	assert.False(root.Select("a", "b").Exists("0"))
+
+	var nilElm *JSONElement
+	assert.False(nilElm.Has("a"))
+
+	assert.Equal(0, warned)
+}
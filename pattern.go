@@ -0,0 +1,131 @@
+package dynajson
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+func splitPattern(pattern string) []string {
+
+	return splitStreamPath(pattern)
+}
+
+func matchSegments(patterns []string, keys []interface{}) bool {
+
+	if len(patterns) == 0 {
+		return len(keys) == 0
+	}
+
+	if patterns[0] == "**" {
+
+		for i := 0; i <= len(keys); i++ {
+			if matchSegments(patterns[1:], keys[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(keys) == 0 {
+		return false
+	}
+
+	ok, err := path.Match(patterns[0], fmt.Sprintf("%v", keys[0]))
+	if err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(patterns[1:], keys[1:])
+}
+
+// MatchPath ... func
+// pattern is "/" separated, each segment is a path.Match glob against a key or index,
+// "**" matches any number of segments. e.g. "users/*/email", "**/password"
+func MatchPath(pattern string, keys []interface{}) bool {
+
+	return matchSegments(splitPattern(pattern), keys)
+}
+
+func matchAnyPath(patterns []string, keys []interface{}) bool {
+
+	for _, v := range patterns {
+		if MatchPath(v, keys) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPathPattern ... func
+func IsPathPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// SelectAll ... func
+// Select with wildcard segments: "*" is any member or element, "**" any number
+// of levels (zero included). Keys and indexes that do not exist match nothing,
+// without warnings, negative indexes count from the end. Results are in document
+// order (maps by KeyLess) without duplicates.
+func (me *JSONElement) SelectAll(keys ...interface{}) []*JSONElement {
+
+	nodes := []*JSONElement{me}
+
+	for _, key := range keys {
+
+		next := []*JSONElement{}
+		seen := map[string]bool{}
+
+		add := func(elms ...*JSONElement) {
+			for _, v := range elms {
+				if ptr := v.PathString(); !seen[ptr] {
+					seen[ptr] = true
+					next = append(next, v)
+				}
+			}
+		}
+
+		for _, elm := range nodes {
+
+			switch key {
+			case "*":
+				add(jpChildren(elm)...)
+				continue
+			case "**":
+				add(jpDescendants(elm)...)
+				continue
+			}
+
+			raw, err := resolveSpill(elm.raw)
+			if err != nil {
+				continue
+			}
+
+			switch typed := key.(type) {
+			case string:
+				if obj, ok := raw.(map[string]interface{}); ok {
+					if _, exists := obj[typed]; exists {
+						add(elm.child(typed, obj[typed]))
+					}
+				}
+			case int:
+				arr := asSlice(raw)
+
+				idx := typed
+				if idx < 0 {
+					idx += len(arr)
+				}
+
+				if idx >= 0 && idx < len(arr) {
+					add(elm.child(idx, arr[idx]))
+				}
+			}
+		}
+
+		nodes = next
+	}
+
+	return nodes
+}
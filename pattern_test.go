@@ -0,0 +1,66 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPath(t *testing.T) {
+
+	assert := assert.New(t)
+
+	assert.True(MatchPath("users/*/email", []interface{}{"users", 3, "email"}))
+	assert.True(MatchPath("/users/*/email", []interface{}{"users", "x", "email"}))
+	assert.False(MatchPath("users/*/email", []interface{}{"users", "email"}))
+	assert.True(MatchPath("**/password", []interface{}{"password"}))
+	assert.True(MatchPath("**/password", []interface{}{"a", 0, "password"}))
+	assert.False(MatchPath("**/password", []interface{}{"a", "password", "x"}))
+	assert.True(MatchPath("a/**", []interface{}{"a", "b", "c"}))
+	assert.True(MatchPath("*_at", []interface{}{"created_at"}))
+	assert.False(MatchPath("a", []interface{}{"a", "b"}))
+
+	assert.True(IsPathPattern("a/*"))
+	assert.False(IsPathPattern("a/b"))
+}
+
+func TestSelectAll(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{
+		"paths": {
+			"/users": {"get": {"operationId": "listUsers"}, "post": {"operationId": "createUser"}},
+			"/items": {"get": {"operationId": "listItems"}, "parameters": []}
+		},
+		"x": [{"operationId": "a"}, {"operationId": "b", "n": {"operationId": "c"}}]
+	}`)
+	assert.Nil(err)
+
+	values := func(elms []*JSONElement) []string {
+		ret := []string{}
+		for _, v := range elms {
+			ret = append(ret, v.AsString())
+		}
+		return ret
+	}
+
+	ids := root.SelectAll("paths", "*", "get", "operationId")
+	assert.Equal([]string{"listItems", "listUsers"}, values(ids))
+	assert.Equal("/paths/~1items/get/operationId", ids[0].PathString())
+
+	assert.Equal([]string{"listItems", "listUsers", "createUser", "a", "b", "c"}, values(root.SelectAll("**", "operationId")))
+	assert.Equal([]string{"b"}, values(root.SelectAll("x", 1, "operationId")))
+	assert.Equal([]string{"a", "b"}, values(root.SelectAll("x", "*", "operationId")))
+	assert.Equal([]string{"c"}, values(root.SelectAll("x", "**", "n", "**", "operationId")))
+
+	// no duplicates from overlapping deep wildcards
+	assert.Equal(6, len(root.SelectAll("**", "**", "operationId")))
+
+	assert.Equal(0, len(root.SelectAll("paths", "*", "delete")))
+	assert.Equal(0, len(root.SelectAll("x", 5)))
+	assert.Equal([]string{"b"}, values(root.SelectAll("x", -1, "operationId")))
+	assert.Equal("/x/1/operationId", root.SelectAll("x", -1, "operationId")[0].PathString())
+	assert.Equal(0, len(root.SelectAll("x", -5)))
+	assert.Equal([]*JSONElement{root}, root.SelectAll())
+}
@@ -0,0 +1,181 @@
+package dynajson
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Path2Pointer ... func
+// converts a path (as FullPath returns) to an RFC 6901 JSON Pointer.
+func Path2Pointer(path []interface{}) string {
+
+	b := strings.Builder{}
+
+	for _, v := range path {
+		b.WriteString("/")
+		b.WriteString(pointerEscaper.Replace(fmt.Sprintf("%v", v)))
+	}
+
+	return b.String()
+}
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// ParsePointer ... func
+// splits an RFC 6901 JSON Pointer ("" or "/a/b~1c") into unescaped reference tokens,
+// a leading "#" (URI fragment form) is allowed.
+func ParsePointer(pointer string) ([]string, error) {
+
+	pointer = strings.TrimPrefix(pointer, "#")
+
+	if pointer == "" {
+		return []string{}, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("ParsePointer: %s: Not Start With /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, v := range tokens {
+		tokens[i] = pointerUnescaper.Replace(v)
+	}
+
+	return tokens, nil
+}
+
+// pointerKeys ... func
+// converts reference tokens to Select keys, tokens on arrays become indexes.
+func (me *JSONElement) pointerKeys(tokens []string) []interface{} {
+
+	keys := make([]interface{}, len(tokens))
+	raw := me.Raw()
+
+	for i, v := range tokens {
+
+		keys[i] = v
+		raw, _ = resolveSpill(raw)
+
+		switch typed := raw.(type) {
+		case map[string]interface{}:
+			raw = typed[v]
+		case []interface{}, *[]interface{}:
+			raw = nil
+			if pos, err := strconv.Atoi(v); err == nil {
+				keys[i] = pos
+				if arr := asSlice(typed); pos >= 0 && pos < len(arr) {
+					raw = arr[pos]
+				}
+			}
+		default:
+			raw = nil
+		}
+	}
+
+	return keys
+}
+
+// selectTokens ... func
+// same as Select with reference tokens, no tokens selects me.
+func (me *JSONElement) selectTokens(tokens []string) *JSONElement {
+
+	if len(tokens) == 0 {
+		return me
+	}
+
+	return me.Select(me.pointerKeys(tokens))
+}
+
+// SelectByPointer ... func
+// selects the node at an RFC 6901 JSON Pointer ("/a/0/b~1c", "#/a" is allowed), "" is me.
+func (me *JSONElement) SelectByPointer(pointer string) *JSONElement {
+
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		me.Warn("SelectByPointer(%s): %v", pointer, err)
+		return me.child(pointer, nil)
+	}
+
+	return me.selectTokens(tokens)
+}
+
+// PutByPointer ... func
+// sets val at an RFC 6901 JSON Pointer. The parent must exist; on arrays the
+// last token is an index in range (replaced) or "-" / the length (appended).
+func (me *JSONElement) PutByPointer(pointer string, val interface{}) error {
+
+	tokens, err := ParsePointer(pointer)
+	if err != nil {
+		return me.Errorf("PutByPointer: %w", err)
+	}
+
+	if len(tokens) == 0 {
+		return me.Errorf("PutByPointer: %s: Root Can Not Be Replaced", pointer)
+	}
+
+	parent := me.selectTokens(tokens[:len(tokens)-1])
+	last := tokens[len(tokens)-1]
+
+	if parent.IsMap() {
+
+		err := parent.Put(last, val)
+		if err != nil {
+			return me.Errorf("PutByPointer: %s: %w", pointer, err)
+		}
+
+		return nil
+	}
+
+	arr := asSlice(parent.Raw())
+	if arr == nil {
+		return me.Errorf("PutByPointer: %s: Parent Not Container: %T", pointer, parent.Raw())
+	}
+
+	pos := len(arr)
+	if last != "-" {
+		pos, err = strconv.Atoi(last)
+		if err != nil || pos < 0 || pos > len(arr) {
+			return me.Errorf("PutByPointer: %s: Bad Index: %s", pointer, last)
+		}
+	}
+
+	switch {
+	case pos < len(arr):
+		err = parent.setChild(pos, val)
+	default:
+		err = parent.appendUpgrading(val)
+	}
+	if err != nil {
+		return me.Errorf("PutByPointer: %s: %w", pointer, err)
+	}
+
+	return nil
+}
+
+// appendUpgrading ... func
+// Append that first turns a parsed (not editable) array into an editable one.
+func (me *JSONElement) appendUpgrading(val interface{}) error {
+
+	if arr, ok := me.raw.([]interface{}); ok {
+
+		if me.isReadonly() {
+			return me.fail("Append", ErrorReadonly, "")
+		}
+
+		copied := append([]interface{}{}, arr...)
+
+		if me.parent != nil {
+			err := me.parent.setChild(me.key, &copied)
+			if err != nil {
+				return err
+			}
+		}
+
+		me.raw = &copied
+	}
+
+	return me.Append(val)
+}
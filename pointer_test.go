@@ -0,0 +1,46 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointer(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a/b": {"m~n": [10, {"x": 1}]}, "": 0, "arr": []}`)
+	assert.Nil(err)
+
+	assert.Equal(10, root.SelectByPointer("/a~1b/m~0n/0").AsInt())
+	assert.Equal(1, root.SelectByPointer("#/a~1b/m~0n/1/x").AsInt())
+	assert.Equal(0, root.SelectByPointer("/").AsInt())
+	assert.True(root.SelectByPointer("").IsMap())
+	assert.True(root.SelectByPointer("/a~1b/m~0n/5").IsNil())
+	assert.True(root.SelectByPointer("bad").IsNil())
+
+	assert.Equal("/a~1b/m~0n/1/x", Path2Pointer(root.SelectByPointer("/a~1b/m~0n/1/x").FullPath()))
+
+	tokens, err := ParsePointer("/a~01/b~10")
+	assert.Nil(err)
+	assert.Equal([]string{"a~1", "b/0"}, tokens)
+
+	assert.Nil(root.PutByPointer("/a~1b/m~0n/1/y", "new"))
+	assert.Nil(root.PutByPointer("/a~1b/m~0n/0", 11))
+	assert.Nil(root.PutByPointer("/a~1b/m~0n/-", 12))
+	assert.Nil(root.PutByPointer("/arr/0", "first"))
+	assert.Nil(root.PutByPointer("/arr/-", "second"))
+
+	assert.Equal("new", root.SelectByPointer("/a~1b/m~0n/1/y").AsString())
+	mn := root.SelectByPointer("/a~1b/m~0n")
+	mn.KeyLess = LexicalLess
+	assert.Equal(`[11, {"x": 1, "y": "new"}, 12]`, mn.String())
+	assert.Equal(`["first", "second"]`, root.SelectByPointer("/arr").String())
+
+	assert.NotNil(root.PutByPointer("", 1))
+	assert.NotNil(root.PutByPointer("/none/x", 1))
+	assert.NotNil(root.PutByPointer("/arr/5", 1))
+	assert.NotNil(root.PutByPointer("/arr/x", 1))
+	assert.NotNil(root.PutByPointer("x", 1))
+}
@@ -0,0 +1,108 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Progress ... struct
+type Progress struct {
+	// Bytes read or written so far.
+	Bytes int64
+	// Total bytes expected, -1 when unknown.
+	Total int64
+	// Elements processed so far (streaming operations only).
+	Elements int
+}
+
+// ProgressFunc ... type
+type ProgressFunc func(Progress)
+
+type progressReader struct {
+	r        io.Reader
+	progress Progress
+	callback ProgressFunc
+}
+
+// NewProgressReader ... func
+// reports every Read to callback, total is -1 when unknown.
+func NewProgressReader(r io.Reader, total int64, callback ProgressFunc) io.Reader {
+
+	return &progressReader{
+		r:        r,
+		progress: Progress{Total: total},
+		callback: callback,
+	}
+}
+
+func (me *progressReader) Read(p []byte) (int, error) {
+
+	n, err := me.r.Read(p)
+
+	if n > 0 {
+		me.progress.Bytes += int64(n)
+		me.callback(me.progress)
+	}
+
+	return n, err
+}
+
+type progressWriter struct {
+	w        io.Writer
+	progress Progress
+	callback ProgressFunc
+}
+
+// NewProgressWriter ... func
+// reports every Write to callback, e.g. NewArrayWriter(NewProgressWriter(w, fn), n).
+func NewProgressWriter(w io.Writer, callback ProgressFunc) io.Writer {
+
+	return &progressWriter{
+		w:        w,
+		progress: Progress{Total: -1},
+		callback: callback,
+	}
+}
+
+func (me *progressWriter) Write(p []byte) (int, error) {
+
+	n, err := me.w.Write(p)
+
+	if n > 0 {
+		me.progress.Bytes += int64(n)
+		me.callback(me.progress)
+	}
+
+	return n, err
+}
+
+// NewByPathWithProgress ... func
+func NewByPathWithProgress(argPath string, callback ProgressFunc) (*JSONElement, error) {
+
+	return loadByPath(context.Background(), argPath, loadConfig{progress: callback})
+}
+
+// EachArrayFromReaderWithProgress ... func
+// same as EachArrayFromReader, callback is also called after each element.
+func EachArrayFromReaderWithProgress(r io.Reader, total int64, path string, progress ProgressFunc, callback func(int, *JSONElement) (bool, error)) error {
+
+	pr := &progressReader{
+		r:        r,
+		progress: Progress{Total: total},
+		callback: progress,
+	}
+
+	err := EachArrayFromReader(pr, path, func(i int, elm *JSONElement) (bool, error) {
+
+		pr.progress.Elements = i + 1
+		progress(pr.progress)
+
+		return callback(i, elm)
+	})
+	if err != nil {
+		return fmt.Errorf("EachArrayFromReaderWithProgress: %w", err)
+	}
+
+	return nil
+}
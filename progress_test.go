@@ -0,0 +1,48 @@
+package dynajson
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+
+	assert := assert.New(t)
+
+	jsonPath := filepath.Join(currentDir(), "testdata", "read2.json")
+	info, err := os.Stat(jsonPath)
+	assert.Nil(err)
+
+	last := Progress{}
+	root, err := NewByPathWithProgress(jsonPath, func(p Progress) {
+		last = p
+	})
+	assert.Nil(err)
+	assert.NotNil(root)
+	assert.Equal(info.Size(), last.Bytes)
+	assert.Equal(info.Size(), last.Total)
+
+	data := `[1, 2, 3]`
+	elements := 0
+	err = EachArrayFromReaderWithProgress(strings.NewReader(data), int64(len(data)), "", func(p Progress) {
+		elements = p.Elements
+	}, func(i int, elm *JSONElement) (bool, error) {
+		return true, nil
+	})
+	assert.Nil(err)
+	assert.Equal(3, elements)
+
+	written := int64(0)
+	buf := &bytes.Buffer{}
+	aw := NewArrayWriter(NewProgressWriter(buf, func(p Progress) {
+		written = p.Bytes
+	}), 1)
+	aw.Append("a", "b")
+	aw.Close()
+	assert.Equal(int64(buf.Len()), written)
+}
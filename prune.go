@@ -0,0 +1,134 @@
+package dynajson
+
+// PruneOptions ... struct
+type PruneOptions struct {
+	RemoveNulls           bool
+	RemoveEmptyContainers bool
+	// RemoveZeroScalars removes false, 0 and "".
+	RemoveZeroScalars bool
+	// KeepPaths (patterns, see MatchPath) are neither removed nor pruned inside.
+	KeepPaths []string
+}
+
+func (me PruneOptions) removable(raw interface{}) bool {
+
+	switch kindOf(raw) {
+	case KindNull:
+		return me.RemoveNulls
+	case KindMap, KindArray:
+		return me.RemoveEmptyContainers && len(walkKeys(raw)) == 0
+	case KindBool:
+		return me.RemoveZeroScalars && raw == false
+	case KindString:
+		return me.RemoveZeroScalars && raw == ""
+	case KindNumber:
+		f, _ := coerceValue(raw, KindNumber)
+		return me.RemoveZeroScalars && (f == 0.0 || raw == 0)
+	}
+
+	return false
+}
+
+func walkKeys(raw interface{}) []interface{} {
+
+	entries, _ := walkEntries(nil, raw)
+
+	keys := make([]interface{}, len(entries))
+	for i, v := range entries {
+		keys[i] = v.key
+	}
+
+	return keys
+}
+
+// prune ... func
+// returns the pruned value (a new slice for arrays) and the number of nodes removed.
+func (me PruneOptions) prune(raw interface{}, rel []interface{}) (interface{}, int) {
+
+	count := 0
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		for k, sub := range typed {
+
+			subRel := appendParents(rel, k)
+			if matchAnyPath(me.KeepPaths, subRel) {
+				continue
+			}
+
+			sub, n := me.prune(sub, subRel)
+			count += n
+
+			if me.removable(sub) {
+				delete(typed, k)
+				count++
+				continue
+			}
+
+			typed[k] = sub
+		}
+
+		return typed, count
+
+	case *[]interface{}:
+		arr, n := me.prune(*typed, rel)
+		*typed = arr.([]interface{})
+		return typed, n
+
+	case []interface{}:
+		arr := typed[:0]
+		for i, sub := range typed {
+
+			subRel := appendParents(rel, i)
+			if matchAnyPath(me.KeepPaths, subRel) {
+				arr = append(arr, sub)
+				continue
+			}
+
+			sub, n := me.prune(sub, subRel)
+			count += n
+
+			if me.removable(sub) {
+				count++
+				continue
+			}
+
+			arr = append(arr, sub)
+		}
+
+		return arr, count
+	}
+
+	return raw, 0
+}
+
+// Prune ... func
+// deletes nulls, empty containers and/or zero scalars recursively (bottom-up,
+// so containers emptied by pruning go too). me itself is never removed.
+// Returns the number of nodes removed.
+func (me *JSONElement) Prune(opts PruneOptions) (int, error) {
+
+	if me.IsNil() {
+		return 0, me.fail("Prune", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return 0, me.fail("Prune", ErrorReadonly, "")
+	}
+
+	me.unshareAll()
+
+	raw, err := resolveSpill(me.raw)
+	if err != nil {
+		return 0, me.Errorf("Prune: %w", err)
+	}
+
+	raw, count := opts.prune(raw, []interface{}{})
+	me.raw = raw
+
+	if count > 0 {
+		me.notifyReplaced()
+	}
+
+	return count, nil
+}
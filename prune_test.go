@@ -0,0 +1,48 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrune(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data := `{
+		"a": null,
+		"b": {"c": null, "d": []},
+		"e": [null, 1, {}, 0, "", false],
+		"f": {"g": 0, "h": "x"},
+		"keep": {"n": null, "e": {}}
+	}`
+
+	root, err := NewByString(data)
+	assert.Nil(err)
+
+	count, err := root.Prune(PruneOptions{RemoveNulls: true, RemoveEmptyContainers: true, KeepPaths: []string{"keep"}})
+	assert.Nil(err)
+	assert.Equal(6, count)
+	assert.ElementsMatch([]string{"e", "f", "keep"}, root.Keys())
+	assert.Equal(`[1, 0, "", false]`, root.Select("e").String())
+	assert.Equal(2, root.Select("keep").Count())
+
+	root, err = NewByString(data)
+	assert.Nil(err)
+
+	count, err = root.Prune(PruneOptions{RemoveZeroScalars: true, RemoveEmptyContainers: true})
+	assert.Nil(err)
+	assert.Equal(2, root.Select("e").Count())
+	assert.True(root.Select("e", 0).IsNil())
+	assert.Equal([]string{"h"}, root.Select("f").Keys())
+	assert.Equal([]string{"c"}, root.Select("b").Keys())
+
+	arr := NewAsArray()
+	arr.Append(nil, 1, nil)
+	_, err = arr.Prune(PruneOptions{RemoveNulls: true})
+	assert.Nil(err)
+	assert.Equal(1, arr.Count())
+	assert.Nil(arr.Append(2))
+	assert.Equal(`[1, 2]`, arr.String())
+}
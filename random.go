@@ -0,0 +1,142 @@
+package dynajson
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// RandomOptions ... struct
+type RandomOptions struct {
+	// MaxDepth of nested containers, 0 means 4.
+	MaxDepth int
+	// MaxFanout is the maximum entries per container, 0 means 5.
+	MaxFanout int
+	// Kinds allowed for values, empty means all.
+	Kinds []Kind
+	// Seed makes the output reproducible.
+	Seed int64
+}
+
+// the runes of random strings, with ones that need escaping and multibyte ones.
+var randomRunes = []rune(`abcxyzABC019 _-"\/éあ漢😀`)
+
+type randomGenerator struct {
+	rnd        *rand.Rand
+	opts       RandomOptions
+	scalars    []Kind
+	containers []Kind
+}
+
+func (me *randomGenerator) string() string {
+
+	b := strings.Builder{}
+
+	n := me.rnd.Intn(8)
+	for i := 0; i < n; i++ {
+		b.WriteRune(randomRunes[me.rnd.Intn(len(randomRunes))])
+	}
+
+	return b.String()
+}
+
+func (me *randomGenerator) scalar(kind Kind) interface{} {
+
+	switch kind {
+	case KindBool:
+		return me.rnd.Intn(2) == 0
+	case KindNumber:
+		if me.rnd.Intn(2) == 0 {
+			return float64(me.rnd.Intn(2000) - 1000)
+		}
+		return float64(me.rnd.Intn(200000)-100000) / 100
+	case KindString:
+		return me.string()
+	}
+
+	return nil
+}
+
+func (me *randomGenerator) container(kind Kind, depth int) interface{} {
+
+	n := me.rnd.Intn(me.opts.MaxFanout + 1)
+
+	if kind == KindMap {
+		obj := map[string]interface{}{}
+		for i := 0; i < n; i++ {
+			obj[me.string()] = me.value(depth + 1)
+		}
+		return obj
+	}
+
+	arr := make([]interface{}, n)
+	for i := range arr {
+		arr[i] = me.value(depth + 1)
+	}
+
+	return arr
+}
+
+func (me *randomGenerator) value(depth int) interface{} {
+
+	kinds := me.scalars
+	if depth < me.opts.MaxDepth {
+		kinds = append(append([]Kind{}, me.scalars...), me.containers...)
+	}
+
+	// no scalars allowed, empty containers end the nesting
+	if len(kinds) == 0 {
+		if me.containers[me.rnd.Intn(len(me.containers))] == KindMap {
+			return map[string]interface{}{}
+		}
+		return []interface{}{}
+	}
+
+	kind := kinds[me.rnd.Intn(len(kinds))]
+
+	if kind == KindArray || kind == KindMap {
+		return me.container(kind, depth)
+	}
+
+	return me.scalar(kind)
+}
+
+// GenerateRandom ... func
+// returns an arbitrary document with the shape bounded by opts, for fuzzing and property tests.
+// Arrays are []interface{} (as parsed), numbers are float64.
+func GenerateRandom(opts RandomOptions) *JSONElement {
+
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 4
+	}
+
+	if opts.MaxFanout <= 0 {
+		opts.MaxFanout = 5
+	}
+
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = []Kind{KindNull, KindBool, KindNumber, KindString, KindArray, KindMap}
+	}
+
+	me := &randomGenerator{
+		rnd:  rand.New(rand.NewSource(opts.Seed)),
+		opts: opts,
+	}
+
+	for _, v := range kinds {
+		switch v {
+		case KindNull, KindBool, KindNumber, KindString:
+			me.scalars = append(me.scalars, v)
+		case KindArray, KindMap:
+			me.containers = append(me.containers, v)
+		}
+	}
+
+	if len(me.containers) == 0 {
+		return New(me.scalar(me.scalars[me.rnd.Intn(len(me.scalars))]))
+	}
+
+	root := me.containers[me.rnd.Intn(len(me.containers))]
+
+	return New(me.container(root, 1))
+}
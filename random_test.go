@@ -0,0 +1,76 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func depthOf(arg interface{}) int {
+
+	max := 0
+
+	switch v := arg.(type) {
+	case []interface{}:
+		for _, sub := range v {
+			if d := depthOf(sub); d > max {
+				max = d
+			}
+		}
+		return max + 1
+	case map[string]interface{}:
+		for _, sub := range v {
+			if d := depthOf(sub); d > max {
+				max = d
+			}
+		}
+		return max + 1
+	}
+
+	return 0
+}
+
+func TestGenerateRandom(t *testing.T) {
+
+	assert := assert.New(t)
+
+	for seed := int64(0); seed < 50; seed++ {
+
+		elm := GenerateRandom(RandomOptions{Seed: seed, MaxDepth: 3})
+		assert.True(elm.IsMap() || elm.IsArray())
+		assert.True(depthOf(elm.Raw()) <= 3)
+
+		again := GenerateRandom(RandomOptions{Seed: seed, MaxDepth: 3})
+		assert.True(reflect.DeepEqual(elm.Raw(), again.Raw()))
+
+		data, err := json.Marshal(elm.Raw())
+		assert.Nil(err)
+
+		parsed, err := NewByBytes(data)
+		assert.Nil(err)
+		assert.True(reflect.DeepEqual(elm.Raw(), parsed.Raw()))
+
+		noNull := GenerateRandom(RandomOptions{Seed: seed, Kinds: []Kind{KindString, KindNumber, KindBool, KindMap}})
+		assert.True(noNull.IsMap())
+		assert.Nil(noNull.ValidateOutput())
+
+		dumped, err := NewByString(noNull.String())
+		assert.Nil(err)
+		assert.True(reflect.DeepEqual(noNull.Raw(), dumped.Raw()))
+	}
+
+	assert.Equal(KindString, GenerateRandom(RandomOptions{Kinds: []Kind{KindString}}).Kind())
+
+	// containers only, empty ones at MaxDepth
+	for seed := int64(0); seed < 20; seed++ {
+
+		elm := GenerateRandom(RandomOptions{Seed: seed, MaxDepth: 3, Kinds: []Kind{KindMap}})
+		assert.True(elm.IsMap())
+		assert.True(depthOf(elm.Raw()) <= 3)
+
+		elm = GenerateRandom(RandomOptions{Seed: seed, Kinds: []Kind{KindArray, KindMap}})
+		assert.True(depthOf(elm.Raw()) <= 4)
+	}
+}
@@ -0,0 +1,125 @@
+package dynajson
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// RecordingFetcher ... struct
+// saves remote documents under Dir on first fetch and replays them afterwards,
+// the content type in a ".content-type" file beside the fixture.
+// Local paths are passed to Upstream as is.
+type RecordingFetcher struct {
+	Dir string
+	// Refresh fetches again and overwrites the recorded fixtures.
+	Refresh bool
+	// Upstream nil means &PathFetcher{}.
+	Upstream Fetcher
+}
+
+var unsafeFixtureChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// FixturePath ... func
+// returns the file the document of argURL is recorded in.
+func (me *RecordingFetcher) FixturePath(argURL string) string {
+
+	name := argURL
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+3:]
+	}
+
+	name = strings.Trim(unsafeFixtureChars.ReplaceAllString(name, "_"), "_")
+	if len(name) > 80 {
+		name = name[:80]
+	}
+
+	sum := sha256.Sum256([]byte(argURL))
+
+	return filepath.Join(me.Dir, fmt.Sprintf("%s-%x.json", name, sum[:4]))
+}
+
+func (me *RecordingFetcher) upstream() Fetcher {
+
+	if me.Upstream == nil {
+		return &PathFetcher{}
+	}
+
+	return me.Upstream
+}
+
+// Fetch ... func
+func (me *RecordingFetcher) Fetch(ctx context.Context, argPath string) (*FetchResult, error) {
+
+	if !strings.HasPrefix(argPath, "http://") && !strings.HasPrefix(argPath, "https://") {
+		return me.upstream().Fetch(ctx, argPath)
+	}
+
+	fixturePath := me.FixturePath(argPath)
+
+	if !me.Refresh {
+
+		data, err := ioutil.ReadFile(fixturePath)
+		if err == nil {
+			contentType, err := ioutil.ReadFile(fixturePath + ".content-type")
+			if err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("RecordingFetcher: ReadFile: %s.content-type: %w", fixturePath, err)
+			}
+
+			return &FetchResult{
+				Body:        ioutil.NopCloser(bytes.NewReader(data)),
+				Size:        int64(len(data)),
+				ContentType: string(contentType),
+			}, nil
+		}
+
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("RecordingFetcher: ReadFile: %s: %w", fixturePath, err)
+		}
+	}
+
+	res, err := me.upstream().Fetch(ctx, argPath)
+	if err != nil {
+		return nil, fmt.Errorf("RecordingFetcher: %w", err)
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("RecordingFetcher: ReadAll: %s: %w", argPath, err)
+	}
+
+	err = os.MkdirAll(me.Dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("RecordingFetcher: MkdirAll: %s: %w", me.Dir, err)
+	}
+
+	err = ioutil.WriteFile(fixturePath, data, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("RecordingFetcher: WriteFile: %s: %w", fixturePath, err)
+	}
+
+	if res.ContentType != "" {
+		err = ioutil.WriteFile(fixturePath+".content-type", []byte(res.ContentType), 0644)
+	} else {
+		err = os.Remove(fixturePath + ".content-type")
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("RecordingFetcher: %s.content-type: %w", fixturePath, err)
+	}
+
+	return &FetchResult{
+		Body:        ioutil.NopCloser(bytes.NewReader(data)),
+		Size:        int64(len(data)),
+		ContentType: res.ContentType,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package dynajson
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingFetcher(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "dynajson")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	calls := 0
+	upstream := FetcherFunc(func(ctx context.Context, argPath string) (*FetchResult, error) {
+		calls++
+		return MapFetcher{argPath: []byte(`{"n": 1}`)}.Fetch(ctx, argPath)
+	})
+
+	url := "https://example.com/api/doc.json?x=1"
+	recorder := &RecordingFetcher{Dir: dir, Upstream: upstream}
+
+	for i := 0; i < 3; i++ {
+		root, err := NewByPathWithFetcher(url, recorder)
+		assert.Nil(err)
+		assert.Equal(1, root.Select("n").AsInt())
+	}
+	assert.Equal(1, calls)
+
+	assert.Equal(dir, filepath.Dir(recorder.FixturePath(url)))
+	_, err = os.Stat(recorder.FixturePath(url))
+	assert.Nil(err)
+
+	recorder.Refresh = true
+	_, err = NewByPathWithFetcher(url, recorder)
+	assert.Nil(err)
+	assert.Equal(2, calls)
+
+	_, err = NewByPathWithFetcher("local.json", recorder)
+	assert.Nil(err)
+	assert.Equal(3, calls)
+
+	// the content type is replayed
+	yamlURL := "https://example.com/api/config"
+	yamlFetcher := FetcherFunc(func(ctx context.Context, argPath string) (*FetchResult, error) {
+		calls++
+		res, err := MapFetcher{argPath: []byte("n: 2\n")}.Fetch(ctx, argPath)
+		if err == nil {
+			res.ContentType = MediaTypeYAML
+		}
+		return res, err
+	})
+
+	recorder = &RecordingFetcher{Dir: dir, Upstream: yamlFetcher}
+
+	for i := 0; i < 2; i++ {
+		root, err := NewByPathWithFetcher(yamlURL, recorder)
+		assert.Nil(err)
+		assert.Equal(2, root.Select("n").AsInt())
+	}
+	assert.Equal(4, calls)
+}
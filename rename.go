@@ -0,0 +1,57 @@
+package dynajson
+
+// RenameKey ... func
+// moves the value of oldKey of the map me to newKey, which keeps the position
+// of oldKey in the key order. An existing newKey is replaced only with overwrite.
+func (me *JSONElement) RenameKey(oldKey, newKey string, overwrite bool) error {
+
+	if me.IsNil() {
+		return me.fail("RenameKey", ErrorNilObject, "key=[%s]", oldKey)
+	}
+
+	if me.isReadonly() {
+		return me.fail("RenameKey", ErrorReadonly, "key=[%s]", oldKey)
+	}
+
+	typedObj, ok := me.raw.(map[string]interface{})
+	if !ok {
+		return me.fail("RenameKey", ErrorNotMap, "key=[%s]: %T", oldKey, me.raw)
+	}
+
+	val, ok := typedObj[oldKey]
+	if !ok {
+		return me.fail("RenameKey", ErrorNotFound, "key=[%s]", oldKey)
+	}
+
+	if oldKey == newKey {
+		return nil
+	}
+
+	if err := me.checkKeyLimits(newKey); err != nil {
+		return me.Errorf("key=[%s]: %w", newKey, err)
+	}
+
+	op := "add"
+	if _, ok := typedObj[newKey]; ok {
+		if !overwrite {
+			return me.fail("RenameKey", ErrorKeyExists, "key=[%s]", newKey)
+		}
+
+		op = "replace"
+	}
+
+	me.unshare()
+	typedObj = me.raw.(map[string]interface{})
+
+	me.order.rename(typedObj, oldKey, newKey)
+
+	delete(typedObj, oldKey)
+	typedObj[newKey] = val
+
+	end := me.batch()
+	me.notify("remove", oldKey, nil)
+	me.notify(op, newKey, val)
+	end()
+
+	return nil
+}
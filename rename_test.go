@@ -0,0 +1,48 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenameKey(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"x": 1, "y": 2, "z": 3}, "s": "v"}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	a := root.Select("a")
+
+	assert.Nil(a.RenameKey("x", "w", false))
+	assert.Equal(`{"w": 1, "y": 2, "z": 3}`, a.String())
+
+	assert.True(errors.Is(a.RenameKey("w", "y", false), ErrKeyExists))
+	assert.True(errors.Is(a.RenameKey("q", "r", false), ErrNotFound))
+	assert.True(errors.Is(root.Select("s").RenameKey("a", "b", false), ErrNotMap))
+
+	assert.Nil(a.RenameKey("w", "y", true))
+	assert.Equal(`{"y": 1, "z": 3}`, a.String())
+
+	assert.Nil(a.RenameKey("y", "y", false))
+
+	root.Readonly = true
+	assert.True(errors.Is(root.RenameKey("s", "t", false), ErrReadonly))
+}
+
+func TestRenameKeyOrder(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesOrdered([]byte(`{"c": 1, "b": 2, "a": 3}`))
+	assert.Nil(err)
+
+	assert.Nil(root.RenameKey("b", "z", false))
+	assert.Equal(`{"c": 1, "z": 2, "a": 3}`, root.String())
+
+	assert.Nil(root.RenameKey("a", "c", true))
+	assert.Equal(`{"z": 2, "c": 3}`, root.String())
+}
@@ -0,0 +1,75 @@
+package dynajson
+
+import (
+	"regexp"
+	"strings"
+)
+
+// RenderOptions ... struct
+type RenderOptions struct {
+	// Strict makes a placeholder without value nor default an error (the tree
+	// is left unchanged), otherwise it renders as "" (or null when it is the whole string).
+	Strict bool
+}
+
+var renderSyntax = &placeholderSyntax{
+	pattern: regexp.MustCompile(`\{\{\s*([^{}|]+?)\s*(?:\|\s*default:\s*([^{}]*?)\s*)?\}\}`),
+	name:    1,
+	def:     2,
+	missing: "{{%s}}: No Value",
+}
+
+// Render ... func
+// substitutes {{a.b.0}} placeholders in string leaves with values of context,
+// {{a.b | default: 8080}} supplies a fallback (JSON literal or plain text).
+func (me *JSONElement) Render(context *JSONElement) error {
+
+	return me.RenderWithOptions(context, RenderOptions{})
+}
+
+// RenderWithOptions ... func
+func (me *JSONElement) RenderWithOptions(context *JSONElement, opts RenderOptions) error {
+
+	if me.IsNil() {
+		return me.fail("Render", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return me.fail("Render", ErrorReadonly, "")
+	}
+
+	x := &expander{
+		syntax: renderSyntax,
+		opts: ExpandOptions{
+			Missing: MissingVarEmpty,
+			Lookup: func(expr string) (interface{}, bool) {
+				elm := context.Select(context.SlashPath2Keys(strings.ReplaceAll(expr, ".", "/")))
+				return elm.Raw(), !elm.IsNil()
+			},
+		},
+	}
+
+	if opts.Strict {
+		x.opts.Missing = MissingVarError
+	}
+
+	cp, err := me.expandCopy(x)
+	if err != nil {
+		return me.Errorf("Render: %w", err)
+	}
+
+	if x.err != nil {
+		me.order.forget(cp)
+		return me.Errorf("Render: %w", x.err)
+	}
+
+	old := me.raw
+	if err := me.replaceRaw(cp); err != nil {
+		me.order.forget(cp)
+		return me.Errorf("Render: %w", err)
+	}
+
+	me.order.forget(old)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+
+	assert := assert.New(t)
+
+	context, err := NewByString(`{"app": {"name": "api", "port": 8080, "hosts": ["a", "b"]}, "debug": true}`)
+	assert.Nil(err)
+
+	tmpl := `{
+		"image": "registry/{{ app.name }}:{{version | default: latest}}",
+		"port": "{{app.port}}",
+		"debug": "{{debug}}",
+		"hosts": "{{app.hosts}}",
+		"first": "{{app.hosts.0}}",
+		"replicas": "{{replicas | default: 3}}",
+		"note": "missing [{{nothing}}]",
+		"list": ["{{app.name}}-{{app.port}}"]
+	}`
+
+	root, err := NewByString(tmpl)
+	assert.Nil(err)
+
+	assert.Nil(root.Render(context))
+	assert.Equal("registry/api:latest", root.Select("image").AsString())
+	assert.Equal(8080, root.Select("port").AsInt())
+	assert.Equal(KindNumber, root.Select("port").Kind())
+	assert.True(root.Select("debug").AsBool())
+	assert.Equal(2, root.Select("hosts").Count())
+	assert.Equal("a", root.Select("first").AsString())
+	assert.Equal(3, root.Select("replicas").AsInt())
+	assert.Equal("missing []", root.Select("note").AsString())
+	assert.Equal("api-8080", root.Select("list", 0).AsString())
+
+	root, err = NewByString(tmpl)
+	assert.Nil(err)
+
+	err = root.RenderWithOptions(context, RenderOptions{Strict: true})
+	assert.NotNil(err)
+
+	// nothing is rendered on error
+	assert.Equal("{{app.port}}", root.Select("port").AsString())
+	assert.Equal("registry/{{ app.name }}:{{version | default: latest}}", root.Select("image").AsString())
+
+	// a whole string
+	str := New("{{app.port}}")
+	assert.Nil(str.Render(context))
+	assert.Equal(8080, str.AsInt())
+}
@@ -0,0 +1,69 @@
+package dynajson
+
+// rewrite ... func
+// visits every node below me (pre-order); when fn returns true the node is
+// replaced with the returned value and not descended into. Returns the replaced paths.
+func (me *JSONElement) rewrite(name string, fn func(elm *JSONElement, rel []interface{}) (interface{}, bool)) ([][]interface{}, error) {
+
+	if me.IsNil() {
+		return nil, me.fail(name, ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return nil, me.fail(name, ErrorReadonly, "")
+	}
+
+	changed := [][]interface{}{}
+
+	defer me.batch()()
+
+	var visit func(elm *JSONElement, rel []interface{})
+	visit = func(elm *JSONElement, rel []interface{}) {
+
+		entries, err := walkEntries(rel, elm.raw)
+		if err != nil {
+			me.Warn("%s: %v", name, err)
+			return
+		}
+
+		for _, v := range entries {
+
+			sub := elm.child(v.key, v.val)
+			subRel := appendParents(rel, v.key)
+
+			if val, ok := fn(sub, subRel); ok {
+				elm.unshare()
+				setContainerRaw(elm.raw, v.key, elm2Raw(val))
+				elm.notify("replace", v.key, elm2Raw(val))
+				changed = append(changed, sub.FullPath())
+				continue
+			}
+
+			visit(sub, subRel)
+		}
+	}
+
+	visit(me, []interface{}{})
+
+	return changed, nil
+}
+
+// ReplaceValues ... func
+// replaces every node for which match returns true with replacement(node),
+// replaced subtrees are not descended into. Returns the paths (as FullPath) changed.
+func (me *JSONElement) ReplaceValues(match func(*JSONElement) bool, replacement func(*JSONElement) interface{}) (int, [][]interface{}, error) {
+
+	changed, err := me.rewrite("ReplaceValues", func(elm *JSONElement, rel []interface{}) (interface{}, bool) {
+
+		if !match(elm) {
+			return nil, false
+		}
+
+		return replacement(elm), true
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(changed), changed, nil
+}
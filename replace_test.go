@@ -0,0 +1,37 @@
+package dynajson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplaceValues(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"home": "http://a.example", "links": ["http://b.example", "https://c.example", 1], "sub": {"u": "http://d"}}`)
+	assert.Nil(err)
+
+	count, paths, err := root.ReplaceValues(func(elm *JSONElement) bool {
+		return strings.HasPrefix(elm.AsString(), "http://")
+	}, func(elm *JSONElement) interface{} {
+		return "https://" + strings.TrimPrefix(elm.AsString(), "http://")
+	})
+	assert.Nil(err)
+	assert.Equal(3, count)
+	assert.ElementsMatch([][]interface{}{{"home"}, {"links", 0}, {"sub", "u"}}, paths)
+	assert.Equal("https://a.example", root.Select("home").AsString())
+	assert.Equal("https://b.example", root.Select("links", 0).AsString())
+	assert.Equal("https://d", root.Select("sub", "u").AsString())
+
+	count, _, err = root.ReplaceValues(func(elm *JSONElement) bool {
+		return elm.IsMap()
+	}, func(elm *JSONElement) interface{} {
+		return elm.Count()
+	})
+	assert.Nil(err)
+	assert.Equal(1, count)
+	assert.Equal(1, root.Select("sub").AsInt())
+}
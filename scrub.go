@@ -0,0 +1,99 @@
+package dynajson
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+)
+
+// ScrubHash ... func
+// replaces strings (and other scalars, stringified) with their SHA-256 hex digest.
+func ScrubHash(elm *JSONElement) interface{} {
+
+	if elm.IsMap() || elm.IsArray() || elm.IsNil() {
+		return elm.Raw()
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%v", elm.Raw()))))
+}
+
+// ScrubZero ... func
+// replaces the value with the zero value of its kind.
+func ScrubZero(elm *JSONElement) interface{} {
+
+	switch elm.Kind() {
+	case KindBool:
+		return false
+	case KindNumber:
+		return 0
+	case KindString:
+		return ""
+	case KindArray:
+		return &[]interface{}{}
+	case KindMap:
+		return map[string]interface{}{}
+	}
+
+	return nil
+}
+
+// ScrubTruncate ... func
+// keeps the first n runes of strings.
+func ScrubTruncate(n int) func(*JSONElement) interface{} {
+
+	return func(elm *JSONElement) interface{} {
+
+		str, ok := elm.Raw().(string)
+		if !ok {
+			return elm.Raw()
+		}
+
+		runes := []rune(str)
+		if len(runes) > n {
+			runes = runes[:n]
+		}
+
+		return string(runes)
+	}
+}
+
+func setContainerRaw(container, key, val interface{}) {
+
+	switch v := container.(type) {
+	case map[string]interface{}:
+		v[key.(string)] = val
+	case []interface{}:
+		v[key.(int)] = val
+	case *[]interface{}:
+		(*v)[key.(int)] = val
+	}
+}
+
+// Scrub ... func
+// rewrites every value whose path (relative to me) matches a pattern (see MatchPath)
+// with the result of its function. Replaced subtrees are not descended into.
+// When several patterns match, the first in sorted order wins.
+func (me *JSONElement) Scrub(rules map[string]func(*JSONElement) interface{}) (int, error) {
+
+	patterns := make([]string, 0, len(rules))
+	for k := range rules {
+		patterns = append(patterns, k)
+	}
+	sort.Strings(patterns)
+
+	changed, err := me.rewrite("Scrub", func(elm *JSONElement, rel []interface{}) (interface{}, bool) {
+
+		for _, pattern := range patterns {
+			if MatchPath(pattern, rel) {
+				return rules[pattern](elm), true
+			}
+		}
+
+		return nil, false
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(changed), nil
+}
@@ -0,0 +1,44 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrub(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{
+		"users": [
+			{"name": "alice", "email": "a@example.com", "token": "abcdefgh", "age": 30},
+			{"name": "bob", "email": "b@example.com", "token": "12345678", "age": 40}
+		],
+		"meta": {"password": "secret", "nested": {"password": {"x": 1}}}
+	}`)
+	assert.Nil(err)
+
+	count, err := root.Scrub(map[string]func(*JSONElement) interface{}{
+		"users/*/email": ScrubHash,
+		"users/*/name":  ScrubZero,
+		"users/*/token": ScrubTruncate(3),
+		"**/password": func(elm *JSONElement) interface{} {
+			return "***"
+		},
+	})
+	assert.Nil(err)
+	assert.Equal(8, count)
+
+	assert.Equal(64, len(root.Select("users", 0, "email").AsString()))
+	assert.NotEqual(root.Select("users", 0, "email").AsString(), root.Select("users", 1, "email").AsString())
+	assert.Equal("", root.Select("users", 1, "name").AsString())
+	assert.Equal("123", root.Select("users", 1, "token").AsString())
+	assert.Equal(40, root.Select("users", 1, "age").AsInt())
+	assert.Equal("***", root.Select("meta", "password").AsString())
+	assert.Equal("***", root.Select("meta", "nested", "password").AsString())
+
+	root.Readonly = true
+	_, err = root.Scrub(nil)
+	assert.NotNil(err)
+}
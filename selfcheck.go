@@ -0,0 +1,128 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// OutputIssue ... struct
+// a node whose String()/Dump serialization is not valid RFC 8259 JSON.
+type OutputIssue struct {
+	Path   []interface{}
+	Reason string
+}
+
+func (me OutputIssue) String() string {
+	return fmt.Sprintf("%s: %s", FullPath2Str(me.Path, "/"), me.Reason)
+}
+
+// OutputCheckHandler ... var
+// when set (e.g. from tests), String() runs SelfCheck and reports issues found.
+var OutputCheckHandler func(*JSONElement, []OutputIssue)
+
+func checkOutputString(arg string) string {
+
+	if !utf8.ValidString(arg) {
+		return "Invalid UTF-8"
+	}
+
+	for _, r := range arg {
+
+		if r < 0x20 && LegacyDump {
+			return fmt.Sprintf("Control Character: %U", r)
+		}
+	}
+
+	return ""
+}
+
+func checkOutput(path []interface{}, arg interface{}, issues []OutputIssue) []OutputIssue {
+
+	add := func(format string, a ...interface{}) {
+		issues = append(issues, OutputIssue{
+			Path:   path,
+			Reason: fmt.Sprintf(format, a...),
+		})
+	}
+
+	switch v := arg.(type) {
+	case nil:
+		if LegacyDump {
+			add("Null Value")
+		}
+	case *spillRef:
+		obj, err := resolveSpill(v)
+		if err != nil {
+			add("Spill: %v", err)
+			break
+		}
+		return checkOutput(path, obj, issues)
+	case *[]interface{}:
+		return checkOutput(path, *v, issues)
+	case []interface{}:
+		for i, sub := range v {
+			issues = checkOutput(appendParents(path, i), sub, issues)
+		}
+	case map[string]interface{}:
+		for k, sub := range v {
+			subPath := appendParents(path, k)
+
+			if reason := checkOutputString(k); reason != "" {
+				issues = append(issues, OutputIssue{Path: subPath, Reason: "Key: " + reason})
+			}
+
+			issues = checkOutput(subPath, sub, issues)
+		}
+	case string:
+		if reason := checkOutputString(v); reason != "" {
+			add(reason)
+		}
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			add("Not Finite: %v", v)
+		}
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			add("Not Finite: %v", v)
+		}
+	case json.Number:
+		// encoding/json refuses what is not a JSON number
+		if _, err := json.Marshal(v); err != nil {
+			add("Not JSON Number: %q", string(v))
+		}
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+	default:
+		add("Unsupported Type: %T", v)
+	}
+
+	return issues
+}
+
+// SelfCheck ... func
+// reports every node whose serialization by String()/Dump would be invalid JSON.
+func (me *JSONElement) SelfCheck() []OutputIssue {
+
+	return checkOutput([]interface{}{}, me.Raw(), []OutputIssue{})
+}
+
+// ValidateOutput ... func
+// re-parses String() with encoding/json, an error lists the offending nodes.
+func (me *JSONElement) ValidateOutput() error {
+
+	issues := me.SelfCheck()
+
+	if len(issues) == 0 {
+		var obj interface{}
+
+		err := json.Unmarshal([]byte(me.String()), &obj)
+		if err != nil {
+			return fmt.Errorf("ValidateOutput: Unmarshal: %w", err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("ValidateOutput: %d issue(s): first %s", len(issues), issues[0])
+}
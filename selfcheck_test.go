@@ -0,0 +1,69 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfCheck(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [1, "x", true], "b": {"c": "d"}}`)
+	assert.Nil(err)
+	assert.Equal(0, len(root.SelfCheck()))
+	assert.Nil(root.ValidateOutput())
+
+	root.Put("ctrl", "line\nbreak")
+	root.Put("nan", math.NaN())
+	root.Put("utf8", string([]byte{0xff}))
+	root.Select("b").Put("nil", nil)
+
+	issues := root.SelfCheck()
+	assert.Equal(2, len(issues))
+
+	LegacyDump = true
+	defer func() { LegacyDump = false }()
+
+	issues = root.SelfCheck()
+	assert.Equal(4, len(issues))
+
+	reasons := map[string]string{}
+	for _, v := range issues {
+		reasons[FullPath2Str(v.Path, "/")] = v.Reason
+	}
+
+	assert.Equal("Control Character: U+000A", reasons["/ctrl"])
+	assert.Equal("Not Finite: NaN", reasons["/nan"])
+	assert.Equal("Invalid UTF-8", reasons["/utf8"])
+	assert.Equal("Null Value", reasons["/b/nil"])
+	assert.NotNil(root.ValidateOutput())
+
+	reported := 0
+	OutputCheckHandler = func(me *JSONElement, issues []OutputIssue) {
+		reported = len(issues)
+	}
+	defer func() { OutputCheckHandler = nil }()
+
+	_ = root.String()
+	assert.Equal(4, reported)
+}
+
+func TestSelfCheckUseNumber(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesUseNumber([]byte(`{"a": 1, "b": [-0.5, 1e300, 12345678901234567890]}`))
+	assert.Nil(err)
+	assert.Equal(0, len(root.SelfCheck()))
+	assert.Nil(root.ValidateOutput())
+
+	assert.Nil(root.Put("bad", json.Number("0x10")))
+
+	issues := root.SelfCheck()
+	assert.Equal(1, len(issues))
+	assert.Equal(`/bad: Not JSON Number: "0x10"`, issues[0].String())
+}
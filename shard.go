@@ -0,0 +1,202 @@
+package dynajson
+
+import (
+	"bytes"
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// HashShard ... func
+// returns a byKey function for Shard that assigns by FNV-1a hash of the key.
+func HashShard() func(string) int {
+
+	return func(key string) int {
+
+		h := fnv.New32a()
+		h.Write([]byte(key))
+
+		return int(h.Sum32() & 0x7fffffff)
+	}
+}
+
+func dumpLen(raw interface{}) int {
+
+	buf := &bytes.Buffer{}
+	Dump(&raw, buf)
+
+	return buf.Len()
+}
+
+func (me *JSONElement) shardEntries() ([]string, []interface{}, bool) {
+
+	switch v := me.raw.(type) {
+	case map[string]interface{}:
+		keys := me.Keys()
+		sort.Strings(keys)
+
+		vals := make([]interface{}, len(keys))
+		for i, k := range keys {
+			vals[i] = v[k]
+		}
+
+		return keys, vals, true
+
+	case []interface{}, *[]interface{}:
+		arr := me.AsArray()
+
+		keys := make([]string, len(arr))
+		vals := make([]interface{}, len(arr))
+		for i, elm := range arr {
+			keys[i] = strconv.Itoa(i)
+			vals[i] = elm.raw
+		}
+
+		return keys, vals, true
+	}
+
+	return nil, nil, false
+}
+
+type shardBuilder struct {
+	isMap bool
+	obj   map[string]interface{}
+	arr   []interface{}
+}
+
+func (me *shardBuilder) add(key string, val interface{}) {
+
+	if me.isMap {
+		me.obj[key] = val
+	} else {
+		me.arr = append(me.arr, val)
+	}
+}
+
+func (me *shardBuilder) len() int {
+
+	if me.isMap {
+		return len(me.obj)
+	}
+
+	return len(me.arr)
+}
+
+func (me *shardBuilder) element() *JSONElement {
+
+	if me.isMap {
+		return New(me.obj)
+	}
+
+	arr := me.arr
+	return New(&arr)
+}
+
+func newShardBuilder(isMap bool) *shardBuilder {
+
+	return &shardBuilder{
+		isMap: isMap,
+		obj:   map[string]interface{}{},
+		arr:   []interface{}{},
+	}
+}
+
+// Shard ... func
+// partitions a map (by key) or an array (by decimal index) into n documents,
+// the entry goes to shard byKey(key) % n. Values are shared, not copied.
+// byKey nil means HashShard().
+func (me *JSONElement) Shard(byKey func(string) int, n int) ([]*JSONElement, error) {
+
+	if me.IsNil() {
+		return nil, me.fail("Shard", ErrorNilObject, "")
+	}
+
+	if n < 1 {
+		return nil, me.Errorf("Shard: Bad Count: %d", n)
+	}
+
+	if byKey == nil {
+		byKey = HashShard()
+	}
+
+	keys, vals, ok := me.shardEntries()
+	if !ok {
+		return nil, me.Errorf("Shard: Not Container: %T", me.raw)
+	}
+
+	builders := make([]*shardBuilder, n)
+	for i := range builders {
+		builders[i] = newShardBuilder(me.IsMap())
+	}
+
+	for i, k := range keys {
+
+		pos := byKey(k) % n
+		if pos < 0 {
+			pos += n
+		}
+
+		builders[pos].add(k, vals[i])
+	}
+
+	shards := make([]*JSONElement, n)
+	for i, v := range builders {
+		shards[i] = v.element()
+	}
+
+	return shards, nil
+}
+
+// SplitBySize ... func
+// splits a map (in key order) or an array (in index order) into documents
+// whose String() is at most maxBytes. An entry larger than maxBytes gets a document of its own.
+func (me *JSONElement) SplitBySize(maxBytes int) ([]*JSONElement, error) {
+
+	if me.IsNil() {
+		return nil, me.fail("SplitBySize", ErrorNilObject, "")
+	}
+
+	keys, vals, ok := me.shardEntries()
+	if !ok {
+		return nil, me.Errorf("SplitBySize: Not Container: %T", me.raw)
+	}
+
+	isMap := me.IsMap()
+
+	shards := []*JSONElement{}
+	current := newShardBuilder(isMap)
+	size := 2 // "{}" or "[]"
+
+	for i, k := range keys {
+
+		entry := dumpLen(vals[i])
+		if isMap {
+			entry += dumpLen(k) + 2 // ": "
+		}
+
+		if current.len() > 0 {
+			entry += 2 // ", "
+		}
+
+		if current.len() > 0 && size+entry > maxBytes {
+			shards = append(shards, current.element())
+
+			current = newShardBuilder(isMap)
+			size = 2
+			entry -= 2
+		}
+
+		if size+entry > maxBytes {
+			me.Warn("SplitBySize: key=[%s]: Too Large: %d", k, size+entry)
+		}
+
+		current.add(k, vals[i])
+		size += entry
+	}
+
+	if current.len() > 0 || len(shards) == 0 {
+		shards = append(shards, current.element())
+	}
+
+	return shards, nil
+}
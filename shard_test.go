@@ -0,0 +1,74 @@
+package dynajson
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShard(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root := NewAsMap()
+	for i := 0; i < 50; i++ {
+		root.Put(fmt.Sprintf("key%d", i), i)
+	}
+
+	shards, err := root.Shard(nil, 4)
+	assert.Nil(err)
+	assert.Equal(4, len(shards))
+
+	again, err := root.Shard(nil, 4)
+	assert.Nil(err)
+
+	total := 0
+	for i, v := range shards {
+		assert.True(v.IsMap())
+		assert.ElementsMatch(v.Keys(), again[i].Keys())
+		total += v.Count()
+	}
+	assert.Equal(50, total)
+
+	arr := NewAsArray()
+	arr.Append(0, 1, 2, 3, 4, 5)
+
+	shards, err = arr.Shard(func(key string) int { return len(key) + int(key[0]) }, 2)
+	assert.Nil(err)
+	assert.Equal(`[1, 3, 5]`, shards[0].String())
+	assert.Equal(`[0, 2, 4]`, shards[1].String())
+
+	_, err = New("str").Shard(nil, 2)
+	assert.NotNil(err)
+}
+
+func TestSplitBySize(t *testing.T) {
+
+	assert := assert.New(t)
+
+	arr := NewAsArray()
+	arr.Append("aaaa", "bbbb", "cccc", "dddddddddddddddd", "e")
+
+	shards, err := arr.SplitBySize(16)
+	assert.Nil(err)
+
+	strs := []string{}
+	for _, v := range shards {
+		strs = append(strs, v.String())
+	}
+	assert.Equal([]string{`["aaaa", "bbbb"]`, `["cccc"]`, `["dddddddddddddddd"]`, `["e"]`}, strs)
+
+	root, err := NewByString(`{"b": 2, "a": 1, "c": 3}`)
+	assert.Nil(err)
+
+	shards, err = root.SplitBySize(16)
+	assert.Nil(err)
+	assert.Equal(2, len(shards))
+	assert.ElementsMatch([]string{"a", "b"}, shards[0].Keys())
+	assert.Equal(`{"c": 3}`, shards[1].String())
+
+	shards, err = NewAsMap().SplitBySize(10)
+	assert.Nil(err)
+	assert.Equal(1, len(shards))
+}
@@ -0,0 +1,46 @@
+package dynajson
+
+// share ... func
+// marks every container reachable from the document of me as shared, so the
+// next change to each is made on a copy.
+func (me *JSONElement) share() {
+
+	cs := me.cowOf()
+	if cs == nil {
+		cs = &cowState{}
+	}
+
+	cs.mu.Lock()
+	cs.owned = map[uintptr]interface{}{}
+	cs.mu.Unlock()
+
+	for elm := me; elm != nil; elm = elm.parent {
+		elm.cow = cs
+	}
+}
+
+// Snapshot ... func
+// returns a read-only view of me as it is now in O(1), sharing memory with me.
+// Later edits through me or any element selected from its document copy the
+// containers they change, so the snapshot never sees them.
+// The key order of an ordered document is copied (O(n)).
+func (me *JSONElement) Snapshot() *JSONElement {
+
+	me.share()
+
+	snap := New(me.raw)
+	snap.Readonly = true
+	snap.KeyLess = me.KeyLess
+	snap.WarnHandler = me.WarnHandler
+	snap.FatalHandler = me.FatalHandler
+
+	if me.order != nil {
+		snap.order = newKeyOrderState()
+		if err := me.order.copyTo(snap.order, me.raw, me.raw); err != nil {
+			me.Warn("Snapshot: %v", err)
+		}
+	}
+	snap.cow = &cowState{owned: map[uintptr]interface{}{}}
+
+	return snap
+}
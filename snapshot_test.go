@@ -0,0 +1,96 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": 1, "c": [1, 2]}, "d": "x"}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	snap := root.Snapshot()
+	assert.True(snap.Readonly)
+	assert.NotNil(snap.Put("d", "y"))
+
+	assert.Nil(root.Select("a").Put("b", 2))
+	assert.Nil(root.Select("a").Put("c", 1, 2, 3))
+	assert.Nil(root.Put("e", true))
+	assert.Nil(root.DeleteByKey("d"))
+
+	assert.Equal(`{"a": {"b": 2, "c": [1, 2, 3]}, "e": true}`, root.String())
+	assert.Equal(`{"a": {"b": 1, "c": [1, 2]}, "d": "x"}`, snap.String())
+
+	// a second snapshot sees the edits, the first one still does not
+	snap2 := root.Snapshot()
+	assert.Nil(root.Select("a").Put("b", 3))
+
+	assert.Equal(2, snap2.Select("a", "b").AsInt())
+	assert.Equal(1, snap.Select("a", "b").AsInt())
+	assert.Equal(3, root.Select("a", "b").AsInt())
+
+	// even when Readonly is cleared, the snapshot writes on its own copies
+	snap.Readonly = false
+	assert.Nil(snap.Put("d", "z"))
+	assert.Equal("z", snap.Select("d").AsString())
+	assert.Equal(2, snap2.Select("a", "b").AsInt())
+	assert.True(root.Select("d").IsNil())
+}
+
+func TestSnapshotSubtree(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": {"c": 1}}}`)
+	assert.Nil(err)
+
+	a := root.Select("a")
+	snap := a.Snapshot()
+
+	assert.Nil(a.Select("b").Put("c", 2))
+
+	assert.Equal(2, root.Select("a", "b", "c").AsInt())
+	assert.Equal(1, snap.Select("b", "c").AsInt())
+}
+
+func TestSnapshotOrdered(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesOrdered([]byte(`{"z": 1, "a": 2}`))
+	assert.Nil(err)
+
+	snap := root.Snapshot()
+	assert.Nil(root.Put("m", 3))
+
+	assert.Equal(`{"z": 1, "a": 2, "m": 3}`, root.String())
+	assert.Equal(`{"z": 1, "a": 2}`, snap.String())
+}
+
+func TestSnapshotSelectedBefore(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByBytesOrdered([]byte(`{"a": {"b": 1, "m": {"k": 1}}, "d": "x"}`))
+	assert.Nil(err)
+
+	// selected before the first Snapshot
+	a := root.Select("a")
+	m := a.Select("m")
+
+	snap := root.Snapshot()
+
+	assert.Nil(a.Put("b", 2))
+	assert.Nil(a.Put("z", 0))
+	assert.Nil(m.Put("k", 2))
+	assert.Nil(root.DeleteByKey("d"))
+
+	assert.Equal(`{"a": {"b": 2, "m": {"k": 2}, "z": 0}}`, root.String())
+	assert.Equal(`{"a": {"b": 1, "m": {"k": 1}}, "d": "x"}`, snap.String())
+	assert.Equal([]string{"a", "d"}, snap.Keys())
+}
@@ -0,0 +1,337 @@
+package dynajson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// spillRef ... struct
+// a subtree left on disk, [offset, offset+length) of path is its raw JSON.
+type spillRef struct {
+	path      string
+	offset    int64
+	length    int64
+	threshold int64
+	cache     *spillCache
+}
+
+// spillCacheSize ... const
+// the number of loaded subtrees kept per file.
+const spillCacheSize = 8
+
+// spillCache ... struct
+// the subtrees loaded last, most recent first, shared by the refs of a file.
+type spillCache struct {
+	mu      sync.Mutex
+	entries []spillCacheEntry
+}
+
+type spillCacheEntry struct {
+	ref *spillRef
+	obj interface{}
+}
+
+func (me *spillCache) get(ref *spillRef) (interface{}, bool) {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	for i, v := range me.entries {
+		if v.ref == ref {
+			copy(me.entries[1:i+1], me.entries[:i])
+			me.entries[0] = v
+			return v.obj, true
+		}
+	}
+
+	return nil, false
+}
+
+func (me *spillCache) put(ref *spillRef, obj interface{}) {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	if len(me.entries) < spillCacheSize {
+		me.entries = append(me.entries, spillCacheEntry{})
+	}
+
+	copy(me.entries[1:], me.entries)
+	me.entries[0] = spillCacheEntry{ref: ref, obj: obj}
+}
+
+func (me *spillRef) load() (interface{}, error) {
+
+	if me.cache != nil {
+		if obj, ok := me.cache.get(me); ok {
+			return obj, nil
+		}
+	}
+
+	f, err := os.Open(me.path)
+	if err != nil {
+		return nil, fmt.Errorf("Open: %s: %w", me.path, err)
+	}
+	defer f.Close()
+
+	builder := newSpillBuilder(f, me.path, me.offset, me.length, me.threshold)
+	builder.cache = me.cache
+
+	obj, err := builder.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("%s(%d): %w", me.path, me.offset, err)
+	}
+
+	if me.cache != nil {
+		me.cache.put(me, obj)
+	}
+
+	return obj, nil
+}
+
+// MarshalJSON ... func
+func (me *spillRef) MarshalJSON() ([]byte, error) {
+
+	f, err := os.Open(me.path)
+	if err != nil {
+		return nil, fmt.Errorf("Open: %s: %w", me.path, err)
+	}
+	defer f.Close()
+
+	data := make([]byte, me.length)
+
+	_, err = f.ReadAt(data, me.offset)
+	if err != nil {
+		return nil, fmt.Errorf("ReadAt: %s(%d): %w", me.path, me.offset, err)
+	}
+
+	return data, nil
+}
+
+func resolveSpill(arg interface{}) (interface{}, error) {
+
+	ref, ok := arg.(*spillRef)
+	if !ok {
+		return arg, nil
+	}
+
+	return ref.load()
+}
+
+type spillBuilder struct {
+	dec       *json.Decoder
+	section   *io.SectionReader
+	path      string
+	base      int64
+	threshold int64
+	rootBegin int64
+	cache     *spillCache
+}
+
+func newSpillBuilder(f *os.File, path string, base, length, threshold int64) *spillBuilder {
+
+	section := io.NewSectionReader(f, base, length)
+
+	return &spillBuilder{
+		dec:       json.NewDecoder(section),
+		section:   section,
+		path:      path,
+		base:      base,
+		threshold: threshold,
+	}
+}
+
+// start ... func
+// returns the offset of the next value, skipping separators the decoder has not consumed yet.
+func (me *spillBuilder) start() (int64, error) {
+
+	off := me.dec.InputOffset()
+	buf := make([]byte, 64)
+
+	for {
+		n, err := me.section.ReadAt(buf, off)
+
+		for i := 0; i < n; i++ {
+			switch buf[i] {
+			case ' ', '\t', '\r', '\n', ':', ',':
+			default:
+				return off + int64(i), nil
+			}
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("ReadAt(%d): %w", off, err)
+		}
+
+		off += int64(n)
+	}
+}
+
+func (me *spillBuilder) spill(begin int64) (interface{}, error) {
+
+	depth := 1
+
+	for depth > 0 {
+		tok, err := me.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("Token: %w", err)
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+
+	return me.ref(begin), nil
+}
+
+func (me *spillBuilder) ref(begin int64) *spillRef {
+
+	return &spillRef{
+		path:      me.path,
+		offset:    me.base + begin,
+		length:    me.dec.InputOffset() - begin,
+		threshold: me.threshold,
+		cache:     me.cache,
+	}
+}
+
+// overflow ... func
+// the value begun at begin goes to disk: it is larger than threshold, or it is
+// an element of a root larger than threshold (e.g. records of a top-level
+// array), so that the root holds the offsets of its elements.
+func (me *spillBuilder) overflow(level int, begin int64) bool {
+
+	off := me.dec.InputOffset()
+
+	if level == 1 && off-me.rootBegin > me.threshold {
+		return true
+	}
+
+	return level > 0 && off-begin > me.threshold
+}
+
+func (me *spillBuilder) value(level int) (interface{}, error) {
+
+	begin, err := me.start()
+	if err != nil {
+		return nil, err
+	}
+
+	if level == 0 {
+		me.rootBegin = begin
+	}
+
+	tok, err := me.dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("Token: %w", err)
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := map[string]interface{}{}
+
+		for me.dec.More() {
+
+			if me.overflow(level, begin) {
+				return me.spill(begin)
+			}
+
+			tok, err := me.dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("Token: %w", err)
+			}
+
+			key, _ := tok.(string)
+
+			obj[key], err = me.value(level + 1)
+			if err != nil {
+				return nil, fmt.Errorf("key=[%s]: %w", key, err)
+			}
+		}
+
+		_, err = me.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("Token: %w", err)
+		}
+
+		if me.overflow(level, begin) {
+			return me.ref(begin), nil
+		}
+
+		return obj, nil
+
+	case '[':
+		arr := []interface{}{}
+
+		for me.dec.More() {
+
+			if me.overflow(level, begin) {
+				return me.spill(begin)
+			}
+
+			v, err := me.value(level + 1)
+			if err != nil {
+				return nil, fmt.Errorf("pos=[%d]: %w", len(arr), err)
+			}
+
+			arr = append(arr, v)
+		}
+
+		_, err = me.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("Token: %w", err)
+		}
+
+		if me.overflow(level, begin) {
+			return me.ref(begin), nil
+		}
+
+		return arr, nil
+	}
+
+	return nil, fmt.Errorf("Unexpected Token: %v", tok)
+}
+
+// NewByPathSpill ... func
+// subtrees whose raw JSON is larger than threshold bytes stay in the file, as
+// do the container elements of a larger root or subtree. They are loaded when
+// selected (the last few are cached) and the selected elements are Readonly:
+// nothing is written back to the file, use Clone for an editable copy.
+// The file must not be modified while the element is in use.
+func NewByPathSpill(argPath string, threshold int64) (*JSONElement, error) {
+
+	f, err := os.Open(argPath)
+	if err != nil {
+		return nil, fmt.Errorf("Open: %s: %w", argPath, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("Stat: %s: %w", argPath, err)
+	}
+
+	builder := newSpillBuilder(f, argPath, 0, info.Size(), threshold)
+	builder.cache = &spillCache{}
+
+	obj, err := builder.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("NewByPathSpill: %s: %w", argPath, err)
+	}
+
+	return New(obj), nil
+}
@@ -0,0 +1,164 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByPathSpill(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "dynajson")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	data := `{"small": {"a": 1}, "big": {"arr": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10], "str": "0123456789abcdef", "m": {"k": [true, null]}}}`
+	jsonPath := filepath.Join(dir, "spill.json")
+	assert.Nil(ioutil.WriteFile(jsonPath, []byte(data), 0644))
+
+	root, err := NewByPathSpill(jsonPath, 20)
+	assert.Nil(err)
+
+	typedObj := root.Raw().(map[string]interface{})
+	_, ok := typedObj["big"].(*spillRef)
+	assert.True(ok)
+	_, ok = typedObj["small"].(map[string]interface{})
+	assert.True(ok)
+
+	big := root.Select("big")
+	assert.True(big.Readonly)
+	assert.Equal(10, big.Select("arr").Count())
+	assert.Equal(7, root.Select("big", "arr", 6).AsInt())
+	assert.Equal("0123456789abcdef", root.Select("big", "str").AsString())
+	assert.True(root.Select("big", "m", "k", 0).AsBool())
+	assert.NotNil(big.Put("x", 1))
+
+	orig, err := NewByString(data)
+	assert.Nil(err)
+
+	cnt1 := 0
+	root.Walk(func(parents []interface{}, key, val interface{}) (bool, error) {
+		cnt1++
+		return true, nil
+	})
+
+	cnt2 := 0
+	orig.Walk(func(parents []interface{}, key, val interface{}) (bool, error) {
+		cnt2++
+		return true, nil
+	})
+
+	assert.Equal(cnt2, cnt1)
+
+	bytes, err := typedObj["big"].(*spillRef).MarshalJSON()
+	assert.Nil(err)
+	assert.Equal(data[strings.Index(data, `{"arr"`):len(data)-1], string(bytes))
+}
+
+func TestNewByPathSpillRootArray(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "dynajson")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	records := []string{}
+	for i := 0; i < 2000; i++ {
+		records = append(records, fmt.Sprintf(`{"id": %d, "name": "rec%d"}`, i, i))
+	}
+
+	jsonPath := filepath.Join(dir, "records.json")
+	assert.Nil(ioutil.WriteFile(jsonPath, []byte("["+strings.Join(records, ", ")+"]"), 0644))
+
+	root, err := NewByPathSpill(jsonPath, 1024)
+	assert.Nil(err)
+
+	spilled := 0
+	for _, v := range root.Raw().([]interface{}) {
+		if _, ok := v.(*spillRef); ok {
+			spilled++
+		}
+	}
+
+	// only the records before the first 1024 bytes are in memory
+	assert.True(spilled > 1950, spilled)
+	assert.Equal(2000, root.Count())
+
+	rec := root.Select(1500)
+	assert.Equal(1500, rec.Select("id").AsInt())
+	assert.Equal("rec1500", rec.Select("name").AsString())
+	assert.True(rec.Readonly)
+
+	// an editable copy
+	cp := rec.Clone()
+	assert.False(cp.Readonly)
+	assert.Nil(cp.Put("name", "x"))
+	assert.Equal("rec1500", root.Select(1500, "name").AsString())
+
+	// loaded subtrees are cached
+	ref := root.Raw().([]interface{})[1500].(*spillRef)
+	obj1, err := ref.load()
+	assert.Nil(err)
+	obj2, err := ref.load()
+	assert.Nil(err)
+	assert.Equal(fmt.Sprintf("%p", obj1), fmt.Sprintf("%p", obj2))
+
+	sum := 0
+	root.EachArray(func(i int, elm *JSONElement) (bool, error) {
+		sum += elm.Select("id").AsInt()
+		return true, nil
+	})
+	assert.Equal(1999*2000/2, sum)
+}
+
+func TestSpillReadError(t *testing.T) {
+
+	assert := assert.New(t)
+
+	// a spilled value whose file is gone
+	lost := func() *JSONElement {
+		return New(map[string]interface{}{
+			"a": 1,
+			"b": &spillRef{path: filepath.Join(os.TempDir(), "dynajson-none.json"), length: 2},
+		})
+	}
+
+	other := New(map[string]interface{}{"a": 1, "b": nil})
+
+	_, err := lost().DiffContext(context.Background(), other)
+	assert.NotNil(err)
+	assert.Nil(lost().Diff(other))
+
+	_, err = lost().DiffAsPatch(other)
+	assert.NotNil(err)
+
+	warned := 0
+	elm := lost()
+	elm.WarnHandler = func(me *JSONElement, message string, where string, line int) {
+		warned++
+	}
+
+	assert.False(elm.Equals(other))
+	assert.True(elm.Clone().IsNil())
+	assert.Equal(2, warned)
+
+	patch, err := NewByString(`[{"op": "add", "path": "/c", "value": 1}]`)
+	assert.Nil(err)
+	elm = lost()
+	assert.NotNil(elm.ApplyPatch(patch))
+	assert.NotNil(elm.Expand(map[string]interface{}{}, ExpandOptions{}))
+	assert.NotNil(other.Merge(lost(), MergeOptions{}))
+	assert.Nil(other.Select("b").Raw())
+
+	var obj interface{}
+	assert.NotNil(lost().Unmarshal(&obj))
+}
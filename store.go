@@ -0,0 +1,169 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// StoreEvent ... struct
+// Element is nil when Deleted or Err is set.
+type StoreEvent struct {
+	Key     string
+	Element *JSONElement
+	Deleted bool
+	Err     error
+}
+
+// Store ... interface
+// persists documents by key, adapters for Redis, S3, etcd ... implement this.
+// Load of a missing key returns an error wrapping os.ErrNotExist.
+// Watch delivers changes made after it was called until ctx is done, then closes the channel.
+type Store interface {
+	Load(ctx context.Context, key string) (*JSONElement, error)
+	Save(ctx context.Context, key string, elm *JSONElement) error
+	Watch(ctx context.Context, key string) (<-chan StoreEvent, error)
+}
+
+// FileStore ... struct
+// keeps each document in Dir/<key>.json, "/" in keys are sub directories.
+// Save replaces the file atomically (write and rename), Watch polls.
+type FileStore struct {
+	Dir string
+	// Perm 0 means 0644.
+	Perm os.FileMode
+	// PollInterval 0 means one second.
+	PollInterval time.Duration
+}
+
+func (me *FileStore) filePath(key string) (string, error) {
+
+	clean := path.Clean("/" + key)
+	if key == "" || clean == "/" || clean != "/"+key {
+		return "", fmt.Errorf("Bad Key: %s", key)
+	}
+
+	return filepath.Join(me.Dir, filepath.FromSlash(clean[1:])+".json"), nil
+}
+
+// Load ... func
+func (me *FileStore) Load(ctx context.Context, key string) (*JSONElement, error) {
+
+	filePath, err := me.filePath(key)
+	if err != nil {
+		return nil, fmt.Errorf("FileStore.Load: %w", err)
+	}
+
+	root, err := loadByPath(ctx, filePath, loadConfig{fetcher: &PathFetcher{}})
+	if err != nil {
+		return nil, fmt.Errorf("FileStore.Load: %s: %w", key, err)
+	}
+
+	return root, nil
+}
+
+// Save ... func
+func (me *FileStore) Save(ctx context.Context, key string, elm *JSONElement) error {
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("FileStore.Save: %s: %w", key, err)
+	}
+
+	filePath, err := me.filePath(key)
+	if err != nil {
+		return fmt.Errorf("FileStore.Save: %w", err)
+	}
+
+	data, err := elm.MarshalLine()
+	if err != nil {
+		return fmt.Errorf("FileStore.Save: %s: %w", key, err)
+	}
+
+	perm := me.Perm
+	if perm == 0 {
+		perm = 0644
+	}
+
+	dir := filepath.Dir(filePath)
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("FileStore.Save: MkdirAll: %s: %w", dir, err)
+	}
+
+	err = writeFileAtomic(filePath, data, perm)
+	if err != nil {
+		return fmt.Errorf("FileStore.Save: %w", err)
+	}
+
+	return nil
+}
+
+// Watch ... func
+func (me *FileStore) Watch(ctx context.Context, key string) (<-chan StoreEvent, error) {
+
+	filePath, err := me.filePath(key)
+	if err != nil {
+		return nil, fmt.Errorf("FileStore.Watch: %w", err)
+	}
+
+	interval := me.PollInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	stat := func() (time.Time, int64, bool) {
+
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return time.Time{}, 0, false
+		}
+
+		return info.ModTime(), info.Size(), true
+	}
+
+	modTime, size, exists := stat()
+	ch := make(chan StoreEvent)
+
+	go func() {
+
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			curTime, curSize, curExists := stat()
+			if curExists == exists && curTime.Equal(modTime) && curSize == size {
+				continue
+			}
+
+			modTime, size, exists = curTime, curSize, curExists
+
+			ev := StoreEvent{Key: key, Deleted: !curExists}
+			if curExists {
+				ev.Element, ev.Err = me.Load(ctx, key)
+				if ev.Err != nil && ctx.Err() != nil {
+					return
+				}
+			}
+
+			select {
+			case ch <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
@@ -0,0 +1,62 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "store")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var store Store = &FileStore{Dir: dir, PollInterval: 5 * time.Millisecond}
+
+	_, err = store.Load(ctx, "app/config")
+	assert.True(errors.Is(err, os.ErrNotExist))
+
+	assert.NotNil(store.Save(ctx, "../escape", NewAsMap()))
+	assert.NotNil(store.Save(ctx, "", NewAsMap()))
+
+	events, err := store.Watch(ctx, "app/config")
+	assert.Nil(err)
+
+	doc, err := NewByString(`{"name": "a<b>", "n": null}`)
+	assert.Nil(err)
+	assert.Nil(store.Save(ctx, "app/config", doc))
+
+	_, err = os.Stat(filepath.Join(dir, "app", "config.json"))
+	assert.Nil(err)
+
+	ev := <-events
+	assert.Nil(ev.Err)
+	assert.False(ev.Deleted)
+	assert.Equal("a<b>", ev.Element.Select("name").AsString())
+
+	loaded, err := store.Load(ctx, "app/config")
+	assert.Nil(err)
+	assert.Equal("a<b>", loaded.Select("name").AsString())
+
+	assert.Nil(os.Remove(filepath.Join(dir, "app", "config.json")))
+
+	ev = <-events
+	assert.True(ev.Deleted)
+	assert.Nil(ev.Element)
+
+	cancel()
+	for range events {
+	}
+}
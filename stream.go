@@ -0,0 +1,294 @@
+package dynajson
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// splitStreamPath ... func
+// tokens are unescaped as in a JSON Pointer, "a~1b" is the key "a/b".
+func splitStreamPath(path string) []string {
+
+	keys := []string{}
+
+	for _, v := range strings.Split(path, "/") {
+
+		if v == "" {
+			continue
+		}
+
+		keys = append(keys, pointerUnescaper.Replace(v))
+	}
+
+	return keys
+}
+
+func skipStreamValue(dec *json.Decoder) error {
+
+	depth := 0
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("Token: %w", err)
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func seekStreamPath(dec *json.Decoder, keys []string) error {
+
+	for _, key := range keys {
+
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("key=[%s]: Token: %w", key, err)
+		}
+
+		delim, _ := tok.(json.Delim)
+
+		switch delim {
+		case '{':
+			found := false
+
+			for dec.More() {
+				tok, err := dec.Token()
+				if err != nil {
+					return fmt.Errorf("key=[%s]: Token: %w", key, err)
+				}
+
+				if tok == key {
+					found = true
+					break
+				}
+
+				err = skipStreamValue(dec)
+				if err != nil {
+					return fmt.Errorf("key=[%s]: skip: %w", key, err)
+				}
+			}
+
+			if !found {
+				return fmt.Errorf("key=[%s]: No Key", key)
+			}
+
+		case '[':
+			pos, err := strconv.Atoi(key)
+			if err != nil {
+				return fmt.Errorf("key=[%s]: Not Index: %w", key, err)
+			}
+
+			for i := 0; i < pos; i++ {
+
+				if !dec.More() {
+					return fmt.Errorf("pos=[%d]: Overflow: %d", pos, i)
+				}
+
+				err = skipStreamValue(dec)
+				if err != nil {
+					return fmt.Errorf("pos=[%d]: skip: %w", pos, err)
+				}
+			}
+
+			if !dec.More() {
+				return fmt.Errorf("pos=[%d]: Overflow: %d", pos, pos)
+			}
+
+		default:
+			return fmt.Errorf("key=[%s]: Not Container: %v", key, tok)
+		}
+	}
+
+	return nil
+}
+
+// EachArrayFromReader ... func
+// path is "/" separated keys (or indexes) of the target array, "" is the top level.
+// "~1" and "~0" in a key stand for "/" and "~" (RFC 6901).
+// Elements are decoded one at a time, the whole document is never materialized.
+func EachArrayFromReader(r io.Reader, path string, callback func(int, *JSONElement) (bool, error)) error {
+
+	dec := json.NewDecoder(r)
+
+	err := seekStreamPath(dec, splitStreamPath(path))
+	if err != nil {
+		return fmt.Errorf("EachArrayFromReader: %s: %w", path, err)
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("EachArrayFromReader: %s: Token: %w", path, err)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("EachArrayFromReader: %s: Not Array: %v", path, tok)
+	}
+
+	for i := 0; dec.More(); i++ {
+
+		var obj interface{}
+
+		err := dec.Decode(&obj)
+		if err != nil {
+			return fmt.Errorf("EachArrayFromReader: %s: Decode(%d): %w", path, i, err)
+		}
+
+		cont, err := callback(i, New(obj))
+		if err != nil {
+			return fmt.Errorf("callback: %w", err)
+		}
+
+		if !cont {
+			break
+		}
+	}
+
+	return nil
+}
+
+// NewByReader ... func
+// decodes the single document of r, what follows it is an error.
+func NewByReader(r io.Reader) (*JSONElement, error) {
+
+	dec := json.NewDecoder(r)
+
+	var obj interface{}
+
+	err := dec.Decode(&obj)
+	if err != nil {
+		return nil, fmt.Errorf("Decode: %w", err)
+	}
+
+	_, err = dec.Token()
+	if err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("invalid character after top-level value")
+		}
+
+		return nil, fmt.Errorf("Decode: %w", err)
+	}
+
+	return New(obj), nil
+}
+
+// StreamArray ... func
+// calls cb for every element of a top-level array, or for every value of
+// NDJSON (values one after another) when r does not start with "[".
+// Only one element is held in memory at a time, only whitespace may follow the array.
+func StreamArray(r io.Reader, cb func(*JSONElement) (bool, error)) error {
+
+	br := bufio.NewReader(r)
+
+	first, err := peekNonSpace(br)
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("StreamArray: %w", err)
+	}
+
+	dec := json.NewDecoder(br)
+
+	if first == '[' {
+		return streamArrayElements(dec, cb)
+	}
+
+	for i := 0; ; i++ {
+
+		var obj interface{}
+
+		err := dec.Decode(&obj)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("StreamArray: Decode(%d): %w", i, err)
+		}
+
+		cont, err := cb(New(obj))
+		if err != nil {
+			return fmt.Errorf("callback: %w", err)
+		}
+
+		if !cont {
+			return nil
+		}
+	}
+}
+
+// streamArrayElements ... func
+// the top-level array of dec, followed by nothing but whitespace.
+func streamArrayElements(dec *json.Decoder, cb func(*JSONElement) (bool, error)) error {
+
+	// '[' was peeked
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("StreamArray: Token: %w", err)
+	}
+
+	for i := 0; dec.More(); i++ {
+
+		var obj interface{}
+
+		err := dec.Decode(&obj)
+		if err != nil {
+			return fmt.Errorf("StreamArray: Decode(%d): %w", i, err)
+		}
+
+		cont, err := cb(New(obj))
+		if err != nil {
+			return fmt.Errorf("callback: %w", err)
+		}
+
+		if !cont {
+			return nil
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("StreamArray: Token: %w", err)
+	}
+
+	_, err := dec.Token()
+	if err != io.EOF {
+		if err == nil {
+			err = fmt.Errorf("invalid character after top-level value")
+		}
+
+		return fmt.Errorf("StreamArray: %w", err)
+	}
+
+	return nil
+}
+
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			br.ReadByte()
+		default:
+			return b[0], nil
+		}
+	}
+}
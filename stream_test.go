@@ -0,0 +1,138 @@
+package dynajson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEachArrayFromReader(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data := `{"skip": {"a": [1, 2, {"b": 3}]}, "data": {"items": [10, {"id": 20}, [30], 40]}}`
+
+	ids := []int{}
+	err := EachArrayFromReader(strings.NewReader(data), "data/items", func(i int, elm *JSONElement) (bool, error) {
+
+		switch {
+		case elm.IsMap():
+			ids = append(ids, elm.Select("id").AsInt())
+		case elm.IsArray():
+			ids = append(ids, elm.Select(0).AsInt())
+		default:
+			ids = append(ids, elm.AsInt())
+		}
+
+		return i < 2, nil
+	})
+
+	assert.Nil(err)
+	assert.Equal([]int{10, 20, 30}, ids)
+
+	sum := 0
+	err = EachArrayFromReader(strings.NewReader(`[[1], [2, [3, 4]]]`), "1/1", func(i int, elm *JSONElement) (bool, error) {
+		sum += elm.AsInt()
+		return true, nil
+	})
+
+	assert.Nil(err)
+	assert.Equal(7, sum)
+
+	err = EachArrayFromReader(strings.NewReader(data), "data/none", func(i int, elm *JSONElement) (bool, error) {
+		return true, nil
+	})
+	assert.NotNil(err)
+
+	err = EachArrayFromReader(strings.NewReader(data), "skip", func(i int, elm *JSONElement) (bool, error) {
+		return true, nil
+	})
+	assert.NotNil(err)
+
+	// escaped keys
+	count := 0
+	err = EachArrayFromReader(strings.NewReader(`{"x": {"a/b": [1], "a~b": [1, 2]}}`), "/x/a~1b", func(i int, elm *JSONElement) (bool, error) {
+		count++
+		return true, nil
+	})
+	assert.Nil(err)
+	assert.Equal(1, count)
+
+	err = EachArrayFromReader(strings.NewReader(`{"x": {"a/b": [1], "a~b": [1, 2]}}`), "/x/a~0b", func(i int, elm *JSONElement) (bool, error) {
+		count++
+		return true, nil
+	})
+	assert.Nil(err)
+	assert.Equal(3, count)
+}
+
+func TestNewByReader(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, err := NewByReader(strings.NewReader(` {"a": [1, 2]} `))
+	assert.Nil(err)
+	assert.Equal(2, elm.Select("a", 1).AsInt())
+
+	_, err = NewByReader(strings.NewReader(`{"a": 1} {"b": 2}`))
+	assert.NotNil(err)
+
+	_, err = NewByReader(strings.NewReader(`{"a": `))
+	assert.NotNil(err)
+}
+
+func TestStreamArray(t *testing.T) {
+
+	assert := assert.New(t)
+
+	collect := func(src string, limit int) ([]string, error) {
+
+		got := []string{}
+		err := StreamArray(strings.NewReader(src), func(elm *JSONElement) (bool, error) {
+			got = append(got, elm.String())
+			return len(got) < limit, nil
+		})
+
+		return got, err
+	}
+
+	got, err := collect("\n [{\"id\": 1}, 2, [3]]", 10)
+	assert.Nil(err)
+	assert.Equal([]string{`{"id": 1}`, "2", "[3]"}, got)
+
+	got, err = collect("{\"id\": 1}\n{\"id\": 2}\n\n{\"id\": 3}\n", 10)
+	assert.Nil(err)
+	assert.Equal([]string{`{"id": 1}`, `{"id": 2}`, `{"id": 3}`}, got)
+
+	got, err = collect("{\"id\": 1}\n{\"id\": 2}\n", 1)
+	assert.Nil(err)
+	assert.Equal([]string{`{"id": 1}`}, got)
+
+	got, err = collect("", 10)
+	assert.Nil(err)
+	assert.Empty(got)
+
+	_, err = collect("{\"id\": 1}\n{\"id\": ", 10)
+	assert.NotNil(err)
+
+	// only whitespace after the array
+	got, err = collect("[1, 2, 3] \n", 10)
+	assert.Nil(err)
+	assert.Len(got, 3)
+
+	_, err = collect("[1, 2, 3] junk", 10)
+	assert.NotNil(err)
+
+	_, err = collect("[1, 2, 3] [4]", 10)
+	assert.NotNil(err)
+
+	_, err = collect("[1, 2, 3", 10)
+	assert.NotNil(err)
+
+	err = StreamArray(strings.NewReader(`[1, 2]`), func(elm *JSONElement) (bool, error) {
+		return false, errors.New("stop")
+	})
+	assert.NotNil(err)
+}
@@ -0,0 +1,90 @@
+package dynajson
+
+// selectKeys ... func
+// the keys of Select, []string and []interface{} arguments are expanded.
+func selectKeys(keys []interface{}) []interface{} {
+
+	ret := []interface{}{}
+
+	for _, key := range keys {
+
+		switch v := key.(type) {
+		case []string:
+			for _, s := range v {
+				ret = append(ret, s)
+			}
+		case []interface{}:
+			ret = append(ret, v...)
+		default:
+			ret = append(ret, key)
+		}
+	}
+
+	return ret
+}
+
+// SelectE ... func
+// Select that stops at the first missing key, index out of range or value of
+// another kind and returns an *Error for it. Nothing goes through the
+// Warn/Fatal handlers. A null value at the last key is not an error.
+func (me *JSONElement) SelectE(keys ...interface{}) (*JSONElement, error) {
+
+	const op = "SelectE"
+
+	keys = selectKeys(keys)
+	if len(keys) == 0 {
+		return nil, me.newError(op, ErrorBadArgument, "no key")
+	}
+
+	cur := me
+
+	for _, key := range keys {
+
+		if cur.IsNil() {
+			return nil, cur.newError(op, ErrorNilObject, "key=[%v]", key)
+		}
+
+		switch typed := key.(type) {
+		case string:
+			obj, ok := cur.raw.(map[string]interface{})
+			if !ok {
+				return nil, cur.newError(op, ErrorNotMap, "key=[%s]: %s", typed, kindOf(cur.raw))
+			}
+
+			val, ok := obj[typed]
+			if !ok {
+				return nil, cur.child(typed, nil).newError(op, ErrorNotFound, "")
+			}
+
+			cur = cur.child(typed, val)
+
+		case int:
+			var arr []interface{}
+
+			switch v := cur.raw.(type) {
+			case []interface{}:
+				arr = v
+			case *[]interface{}:
+				arr = *v
+			default:
+				return nil, cur.newError(op, ErrorNotArray, "pos=[%d]: %s", typed, kindOf(cur.raw))
+			}
+
+			pos := typed
+			if pos < 0 {
+				pos += len(arr)
+			}
+
+			if pos < 0 || pos >= len(arr) {
+				return nil, cur.newError(op, ErrorOverflow, "pos=[%d]: length %d", typed, len(arr))
+			}
+
+			cur = cur.child(pos, arr[pos])
+
+		default:
+			return nil, cur.newError(op, ErrorBadArgument, "%T", key)
+		}
+	}
+
+	return cur, nil
+}
@@ -0,0 +1,65 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectE(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [10, {"c": null}]}, "s": "x"}`)
+	assert.Nil(err)
+
+	warned := 0
+	root.WarnHandler = func(elm *JSONElement, msg, where string, line int) {
+		warned++
+	}
+
+	elm, err := root.SelectE("a", "b", 0)
+	assert.Nil(err)
+	assert.Equal(10, elm.AsInt())
+	assert.Equal("/a/b/0", elm.PathString())
+
+	elm, err = root.SelectE([]interface{}{"a", "b"}, 1, "c")
+	assert.Nil(err)
+	assert.True(elm.IsNil())
+
+	var de *Error
+
+	_, err = root.SelectE("a", "x", "y")
+	assert.True(errors.Is(err, ErrNotFound))
+	assert.True(errors.As(err, &de))
+	assert.Equal("/a/x", de.Path)
+
+	_, err = root.SelectE("a", "b", 5)
+	assert.True(errors.Is(err, ErrOverflow))
+	assert.Equal("SelectE: /a/b: overflow: pos=[5]: length 2", err.Error())
+
+	elm, err = root.SelectE("a", "b", -2)
+	assert.Nil(err)
+	assert.Equal("/a/b/0", elm.PathString())
+
+	_, err = root.SelectE("a", "b", -3)
+	assert.True(errors.Is(err, ErrOverflow))
+
+	_, err = root.SelectE("s", "k")
+	assert.True(errors.Is(err, ErrNotMap))
+
+	_, err = root.SelectE("a", 0)
+	assert.True(errors.Is(err, ErrNotArray))
+
+	_, err = root.SelectE("a", "b", 1, "c", "d")
+	assert.True(errors.Is(err, ErrNilObject))
+
+	_, err = root.SelectE(1.5)
+	assert.True(errors.Is(err, ErrBadArgument))
+
+	_, err = root.SelectE()
+	assert.True(errors.Is(err, ErrBadArgument))
+
+	assert.Equal(0, warned)
+}
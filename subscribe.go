@@ -0,0 +1,165 @@
+package dynajson
+
+import (
+	"sort"
+	"sync"
+)
+
+type changeFeed struct {
+	mu        sync.Mutex
+	root      *JSONElement
+	listeners map[int]func(*JSONElement)
+	nextID    int
+	depth     int
+	batch     []interface{}
+}
+
+// Subscribe ... func
+// delivers every mutation made through me or elements derived from me afterwards
+// as an RFC 6902 JSON Patch (a Readonly array), one patch per call or per Transaction.
+// Paths are relative to the element Subscribe was first called on.
+// Bulk rewrites (Prune, ConvertKeys, Render ...) are reported as a replace of the element they ran on.
+// Returns a func removing the listener.
+func (me *JSONElement) Subscribe(listener func(patch *JSONElement)) func() {
+
+	if me.feed == nil {
+		me.feed = &changeFeed{
+			root:      me,
+			listeners: map[int]func(*JSONElement){},
+		}
+	}
+
+	feed := me.feed
+
+	feed.mu.Lock()
+	defer feed.mu.Unlock()
+
+	id := feed.nextID
+	feed.nextID++
+	feed.listeners[id] = listener
+
+	return func() {
+
+		feed.mu.Lock()
+		defer feed.mu.Unlock()
+
+		delete(feed.listeners, id)
+	}
+}
+
+// Transaction ... func
+// batches the patches of the mutations made in fn into one delivery.
+// There is no rollback, mutations made before fn fails are still delivered.
+func (me *JSONElement) Transaction(fn func() error) error {
+
+	end := me.batch()
+	err := fn()
+	end()
+
+	if err != nil {
+		return me.Errorf("Transaction: %w", err)
+	}
+
+	return nil
+}
+
+// batch ... func
+// holds back deliveries until the returned func is called, calls nest.
+func (me *JSONElement) batch() func() {
+
+	feed := me.feed
+	if feed == nil {
+		return func() {}
+	}
+
+	feed.mu.Lock()
+	feed.depth++
+	feed.mu.Unlock()
+
+	return func() {
+
+		feed.mu.Lock()
+		feed.depth--
+		feed.mu.Unlock()
+
+		feed.flush()
+	}
+}
+
+func (me *changeFeed) flush() {
+
+	me.mu.Lock()
+
+	if me.depth > 0 || len(me.batch) == 0 {
+		me.mu.Unlock()
+		return
+	}
+
+	ops := me.batch
+	me.batch = nil
+
+	ids := make([]int, 0, len(me.listeners))
+	for id := range me.listeners {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	listeners := make([]func(*JSONElement), len(ids))
+	for i, id := range ids {
+		listeners[i] = me.listeners[id]
+	}
+
+	me.mu.Unlock()
+
+	for _, listener := range listeners {
+
+		patch := New(&[]interface{}{})
+		*(patch.raw.(*[]interface{})) = copyRaw(ops).([]interface{})
+		patch.Readonly = true
+
+		listener(patch)
+	}
+}
+
+// notify ... func
+// records op ("add", "replace", "remove") on key of me, nil key means me itself.
+func (me *JSONElement) notify(op string, key interface{}, val interface{}) {
+
+	me.limits.update()
+
+	if me.feed == nil {
+		return
+	}
+
+	fullPath := me.FullPath()
+	if key != nil {
+		fullPath = append(fullPath, key)
+	}
+
+	rel := fullPath
+	if level := me.feed.root.level; level <= len(fullPath) {
+		rel = fullPath[level:]
+	}
+
+	entry := map[string]interface{}{
+		"op":   op,
+		"path": Path2Pointer(rel),
+	}
+
+	if op != "remove" {
+		entry["value"] = copyRaw(val)
+	}
+
+	me.feed.mu.Lock()
+	me.feed.batch = append(me.feed.batch, entry)
+	me.feed.mu.Unlock()
+
+	me.feed.flush()
+}
+
+// notifyReplaced ... func
+// reports a bulk rewrite of me as a replace of the whole element.
+func (me *JSONElement) notifyReplaced() {
+
+	me.notify("replace", nil, me.raw)
+}
@@ -0,0 +1,114 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"name": "a", "tags": ["x", "y"], "n": 1}`)
+	assert.Nil(err)
+
+	patches := []string{}
+	cancel := root.Subscribe(func(patch *JSONElement) {
+		assert.True(patch.Readonly)
+		patch.KeyLess = LexicalLess
+		patches = append(patches, patch.String())
+	})
+
+	assert.Nil(root.Put("name", "b"))
+	assert.Nil(root.Put("age", 3))
+	assert.Nil(root.DeleteByKey("n"))
+
+	list, err := root.PutEmptyArray("list")
+	assert.Nil(err)
+	assert.Nil(list.Append(1, 2))
+	assert.Nil(list.DeleteByPos(0))
+
+	_, err = root.Increment("age", 1)
+	assert.Nil(err)
+
+	assert.Equal([]string{
+		`[{"op": "replace", "path": "/name", "value": "b"}]`,
+		`[{"op": "add", "path": "/age", "value": 3}]`,
+		`[{"op": "remove", "path": "/n"}]`,
+		`[{"op": "add", "path": "/list", "value": []}]`,
+		`[{"op": "add", "path": "/list/0", "value": 1}, {"op": "add", "path": "/list/1", "value": 2}]`,
+		`[{"op": "remove", "path": "/list/0"}]`,
+		`[{"op": "replace", "path": "/age", "value": 4}]`,
+	}, patches)
+
+	patches = patches[:0]
+	err = root.Transaction(func() error {
+
+		assert.Nil(root.Put("a~b", true))
+		assert.Nil(root.Select("tags").setChild(1, "z"))
+
+		return errors.New("stop")
+	})
+	assert.NotNil(err)
+
+	assert.Equal([]string{
+		`[{"op": "add", "path": "/a~0b", "value": true}, {"op": "replace", "path": "/tags/1", "value": "z"}]`,
+	}, patches)
+
+	patches = patches[:0]
+	_, err = root.Select("tags").Prune(PruneOptions{RemoveZeroScalars: true})
+	assert.Nil(err)
+
+	count, _, err := root.ReplaceValues(func(elm *JSONElement) bool {
+		return elm.Raw() == "z" || elm.Raw() == "x"
+	}, func(elm *JSONElement) interface{} {
+		return "_"
+	})
+	assert.Nil(err)
+	assert.Equal(2, count)
+	assert.Equal(1, len(patches))
+
+	cancel()
+	assert.Nil(root.Put("name", "c"))
+	assert.Equal(1, len(patches))
+
+	other := NewAsMap()
+	assert.Nil(other.Transaction(func() error { return other.Put("k", 1) }))
+}
+
+func TestSubscribeArrayEdits(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [3, 1, 2]}`)
+	assert.Nil(err)
+
+	patches := []string{}
+	root.Subscribe(func(patch *JSONElement) {
+		patch.KeyLess = LexicalLess
+		patches = append(patches, patch.String())
+	})
+
+	// parsed arrays become editable without a patch of their own
+	arr := root.Select("a")
+	assert.Nil(arr.SortArray(func(a, b *JSONElement) bool { return a.AsInt() < b.AsInt() }))
+	assert.Nil(arr.Reverse())
+
+	assert.Equal([]string{
+		`[{"op": "replace", "path": "/a", "value": [1, 2, 3]}]`,
+		`[{"op": "replace", "path": "/a", "value": [3, 2, 1]}]`,
+	}, patches)
+
+	other, _ := NewByString(`[1, 2, 3]`)
+
+	patches = patches[:0]
+	other.Subscribe(func(patch *JSONElement) {
+		patch.KeyLess = LexicalLess
+		patches = append(patches, patch.String())
+	})
+
+	assert.Nil(other.DeleteRange(0, 1))
+	assert.Equal([]string{`[{"op": "remove", "path": "/0"}]`}, patches)
+}
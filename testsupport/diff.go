@@ -0,0 +1,196 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cbh34680/dynajson"
+)
+
+// MaxDiffLines ... var
+// limits the lines FormatDiff prints, the rest is summarized.
+var MaxDiffLines = 50
+
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+	colorReset = "\x1b[0m"
+)
+
+type diffLine struct {
+	path     string
+	expected interface{}
+	actual   interface{}
+	hasExp   bool
+	hasAct   bool
+}
+
+func normalize(arg interface{}) interface{} {
+
+	switch v := arg.(type) {
+	case *[]interface{}:
+		return *v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+
+	return arg
+}
+
+func pathJoin(path string, key interface{}) string {
+
+	if i, ok := key.(int); ok {
+		return fmt.Sprintf("%s[%d]", path, i)
+	}
+
+	return fmt.Sprintf("%s/%v", path, key)
+}
+
+func collectDiff(path string, exp, act interface{}, lines []diffLine) []diffLine {
+
+	exp = normalize(exp)
+	act = normalize(act)
+
+	expMap, ok1 := exp.(map[string]interface{})
+	actMap, ok2 := act.(map[string]interface{})
+	if ok1 && ok2 {
+		keys := []string{}
+		for k := range expMap {
+			keys = append(keys, k)
+		}
+		for k := range actMap {
+			if _, ok := expMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			e, hasExp := expMap[k]
+			a, hasAct := actMap[k]
+
+			if hasExp && hasAct {
+				lines = collectDiff(pathJoin(path, k), e, a, lines)
+				continue
+			}
+
+			lines = append(lines, diffLine{pathJoin(path, k), e, a, hasExp, hasAct})
+		}
+
+		return lines
+	}
+
+	expArr, ok1 := exp.([]interface{})
+	actArr, ok2 := act.([]interface{})
+	if ok1 && ok2 {
+		n := len(expArr)
+		if len(actArr) > n {
+			n = len(actArr)
+		}
+
+		for i := 0; i < n; i++ {
+			hasExp := i < len(expArr)
+			hasAct := i < len(actArr)
+
+			if hasExp && hasAct {
+				lines = collectDiff(pathJoin(path, i), expArr[i], actArr[i], lines)
+				continue
+			}
+
+			var e, a interface{}
+			if hasExp {
+				e = expArr[i]
+			}
+			if hasAct {
+				a = actArr[i]
+			}
+
+			lines = append(lines, diffLine{pathJoin(path, i), e, a, hasExp, hasAct})
+		}
+
+		return lines
+	}
+
+	if !reflect.DeepEqual(exp, act) {
+		lines = append(lines, diffLine{path, exp, act, true, true})
+	}
+
+	return lines
+}
+
+func compact(arg interface{}) string {
+
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return fmt.Sprintf("%v", arg)
+	}
+
+	str := string(data)
+	if len(str) > 80 {
+		str = str[:77] + "..."
+	}
+
+	return str
+}
+
+// IsTerminal ... func
+func IsTerminal(f *os.File) bool {
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// FormatDiff ... func
+// returns "" when equal, otherwise one path-annotated "-"/"+" pair per mismatching node.
+func FormatDiff(expected, actual *dynajson.JSONElement, color bool) string {
+
+	lines := collectDiff("", expected.Raw(), actual.Raw(), []diffLine{})
+	if len(lines) == 0 {
+		return ""
+	}
+
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+
+	b := strings.Builder{}
+
+	for i, v := range lines {
+
+		if i >= MaxDiffLines {
+			b.WriteString(fmt.Sprintf("... %d more difference(s)\n", len(lines)-i))
+			break
+		}
+
+		path := v.path
+		if path == "" {
+			path = "/"
+		}
+
+		b.WriteString(paint(colorCyan, "@ "+path) + "\n")
+
+		if v.hasExp {
+			b.WriteString(paint(colorRed, "- "+compact(v.expected)) + "\n")
+		}
+
+		if v.hasAct {
+			b.WriteString(paint(colorGreen, "+ "+compact(v.actual)) + "\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package testsupport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cbh34680/dynajson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDiff(t *testing.T) {
+
+	assert := assert.New(t)
+
+	expected, _ := dynajson.NewByString(`{"a": 1, "b": {"c": [1, 2, 3]}, "d": "x"}`)
+	actual, _ := dynajson.NewByString(`{"a": 1, "b": {"c": [1, 5]}, "e": true}`)
+
+	diff := FormatDiff(expected, actual, false)
+	assert.Equal(strings.Join([]string{
+		"@ /b/c[1]",
+		"- 2",
+		"+ 5",
+		"@ /b/c[2]",
+		"- 3",
+		"@ /d",
+		`- "x"`,
+		"@ /e",
+		"+ true",
+		"",
+	}, "\n"), diff)
+
+	assert.Equal("", FormatDiff(expected, expected, false))
+	assert.True(strings.Contains(FormatDiff(expected, actual, true), colorRed))
+
+	prev := MaxDiffLines
+	MaxDiffLines = 1
+	defer func() { MaxDiffLines = prev }()
+
+	assert.True(strings.HasSuffix(FormatDiff(expected, actual, false), "... 3 more difference(s)\n"))
+}
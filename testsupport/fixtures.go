@@ -0,0 +1,30 @@
+package testsupport
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/cbh34680/dynajson"
+)
+
+var refreshFixtures = flag.Bool("refresh-fixtures", false, "fetch remote documents again and overwrite recorded fixtures")
+
+// UseRecordedFixtures ... func
+// makes NewByPath record remote documents under dir and replay them, until the test ends.
+// `go test -refresh-fixtures` fetches them again.
+func UseRecordedFixtures(t testing.TB, dir string) *dynajson.RecordingFetcher {
+
+	t.Helper()
+
+	recorder := &dynajson.RecordingFetcher{
+		Dir:     dir,
+		Refresh: *refreshFixtures,
+	}
+
+	prev := dynajson.SetDefaultFetcher(recorder)
+	t.Cleanup(func() {
+		dynajson.SetDefaultFetcher(prev)
+	})
+
+	return recorder
+}
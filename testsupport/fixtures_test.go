@@ -0,0 +1,36 @@
+package testsupport
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cbh34680/dynajson"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUseRecordedFixtures(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "testsupport")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	url := "https://example.com/fixture.json"
+
+	t.Run("replay", func(t *testing.T) {
+
+		recorder := UseRecordedFixtures(t, dir)
+		assert.Nil(ioutil.WriteFile(recorder.FixturePath(url), []byte(`{"ok": true}`), 0644))
+
+		root, err := dynajson.NewByPath(url)
+		assert.Nil(err)
+		assert.True(root.Select("ok").AsBool())
+	})
+
+	_, ok := dynajson.GetDefaultFetcher().(*dynajson.RecordingFetcher)
+	assert.False(ok)
+	assert.Equal(dir, filepath.Dir((&dynajson.RecordingFetcher{Dir: dir}).FixturePath(url)))
+}
@@ -0,0 +1,100 @@
+// Package testsupport ... golden-file helpers for tests of dynajson documents
+package testsupport
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cbh34680/dynajson"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the actual output")
+
+// Canonical ... func
+// serializes with sorted keys and two-space indent, terminated by "\n".
+func Canonical(elm *dynajson.JSONElement) ([]byte, error) {
+
+	buf := &bytes.Buffer{}
+
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	err := enc.Encode(elm.Raw())
+	if err != nil {
+		return nil, fmt.Errorf("Encode: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// LoadGolden ... func
+func LoadGolden(t testing.TB, argPath string) *dynajson.JSONElement {
+
+	t.Helper()
+
+	root, err := dynajson.NewByPath(argPath)
+	if err != nil {
+		t.Fatalf("LoadGolden: %v", err)
+	}
+
+	return root
+}
+
+// AssertMatchesGolden ... func
+// compares the canonical form of elm with the file, `go test -update` rewrites the file.
+func AssertMatchesGolden(t testing.TB, elm *dynajson.JSONElement, argPath string) bool {
+
+	t.Helper()
+
+	actual, err := Canonical(elm)
+	if err != nil {
+		t.Errorf("AssertMatchesGolden: %s: %v", argPath, err)
+		return false
+	}
+
+	if *update {
+
+		err := os.MkdirAll(filepath.Dir(argPath), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(argPath, actual, 0644)
+		}
+
+		if err != nil {
+			t.Errorf("AssertMatchesGolden: update: %s: %v", argPath, err)
+			return false
+		}
+
+		return true
+	}
+
+	golden, err := dynajson.NewByPath(argPath)
+	if err != nil {
+		t.Errorf("AssertMatchesGolden: %s: %v (run with -update to create)", argPath, err)
+		return false
+	}
+
+	expected, err := Canonical(golden)
+	if err != nil {
+		t.Errorf("AssertMatchesGolden: %s: %v", argPath, err)
+		return false
+	}
+
+	if !bytes.Equal(expected, actual) {
+		diff := FormatDiff(golden, elm, IsTerminal(os.Stdout))
+		if diff == "" {
+			diff = fmt.Sprintf("--- expected\n%s+++ actual\n%s", expected, actual)
+		}
+
+		t.Errorf("AssertMatchesGolden: %s: mismatch\n%s", argPath, diff)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,44 @@
+package testsupport
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/cbh34680/dynajson"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (me *fakeT) Helper() {}
+
+func (me *fakeT) Errorf(format string, args ...interface{}) {
+	me.failed = true
+}
+
+func TestGolden(t *testing.T) {
+
+	assert := assert.New(t)
+
+	goldenPath := filepath.Join("testdata", "golden.json")
+
+	root := dynajson.NewAsMap()
+	root.Put("str", "<&>")
+	root.Put("arr", 1, "a")
+	sub, _ := root.PutEmptyMap("map")
+	sub.Put("x", true)
+
+	assert.True(AssertMatchesGolden(t, root, goldenPath))
+
+	loaded := LoadGolden(t, goldenPath)
+	assert.Equal("<&>", loaded.Select("str").AsString())
+
+	root.Put("str", "changed")
+
+	fake := &fakeT{TB: t}
+	assert.False(AssertMatchesGolden(fake, root, goldenPath))
+	assert.True(fake.failed)
+}
@@ -0,0 +1,162 @@
+package dynajson
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func parseTimeLayouts(str string, layouts []string, loc *time.Location) (time.Time, bool) {
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, layout := range layouts {
+
+		t, err := time.ParseInLocation(layout, str, loc)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NormalizeTimes ... func
+// rewrites string leaves parseable by one of layouts into target format in loc
+// (nil keeps the parsed zone, layouts without zone are read as UTC).
+// With paths (patterns, see MatchPath) only the matching leaves are considered.
+func (me *JSONElement) NormalizeTimes(layouts []string, target string, loc *time.Location, paths ...string) (int, error) {
+
+	if me.IsNil() {
+		return 0, me.fail("NormalizeTimes", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return 0, me.fail("NormalizeTimes", ErrorReadonly, "")
+	}
+
+	me.unshareAll()
+
+	count := 0
+
+	var normalize func(raw interface{}, rel []interface{})
+	normalize = func(raw interface{}, rel []interface{}) {
+
+		entries, err := walkEntries(rel, raw)
+		if err != nil {
+			me.Warn("NormalizeTimes: %v", err)
+			return
+		}
+
+		for _, v := range entries {
+
+			subRel := appendParents(rel, v.key)
+
+			str, ok := v.val.(string)
+			if !ok {
+				normalize(v.val, subRel)
+				continue
+			}
+
+			if len(paths) > 0 && !matchAnyPath(paths, subRel) {
+				continue
+			}
+
+			t, ok := parseTimeLayouts(str, layouts, loc)
+			if !ok {
+				continue
+			}
+
+			if loc != nil {
+				t = t.In(loc)
+			}
+
+			if conv := t.Format(target); conv != str {
+				setContainerRaw(raw, v.key, conv)
+				count++
+			}
+		}
+	}
+
+	normalize(me.raw, []interface{}{})
+
+	if count > 0 {
+		me.notifyReplaced()
+	}
+
+	return count, nil
+}
+
+// AsTime ... func
+// parses a string value with the first matching of layouts (RFC 3339 when none),
+// layouts without zone are read as UTC.
+func (me *JSONElement) AsTime(layouts ...string) (time.Time, error) {
+
+	str, err := me.GetString()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("AsTime: %w", err)
+	}
+
+	if len(layouts) == 0 {
+		layouts = []string{time.RFC3339Nano}
+	}
+
+	t, ok := parseTimeLayouts(str, layouts, nil)
+	if !ok {
+		return time.Time{}, fmt.Errorf("AsTime: %s: Bad Time: %q", me.PathString(), str)
+	}
+
+	return t, nil
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^(-)?P(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseISODuration ... func
+// ISO 8601 durations without years and months (their length varies), e.g. "PT1H30M".
+func parseISODuration(str string) (time.Duration, bool) {
+
+	m := isoDurationRegexp.FindStringSubmatch(str)
+	if m == nil || str == "P" || str == "-P" || strings.HasSuffix(str, "T") {
+		return 0, false
+	}
+
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+	var d float64
+	for i, unit := range units {
+		if m[i+2] != "" {
+			f, _ := strconv.ParseFloat(m[i+2], 64)
+			d += f * float64(unit)
+		}
+	}
+
+	if m[1] != "" {
+		d = -d
+	}
+
+	return time.Duration(d), true
+}
+
+// AsDuration ... func
+// parses a string value as a Go duration ("1h30m") or an ISO 8601 one ("PT1H30M").
+func (me *JSONElement) AsDuration() (time.Duration, error) {
+
+	str, err := me.GetString()
+	if err != nil {
+		return 0, fmt.Errorf("AsDuration: %w", err)
+	}
+
+	if d, err := time.ParseDuration(str); err == nil {
+		return d, nil
+	}
+
+	if d, ok := parseISODuration(str); ok {
+		return d, nil
+	}
+
+	return 0, fmt.Errorf("AsDuration: %s: Bad Duration: %q", me.PathString(), str)
+}
@@ -0,0 +1,103 @@
+package dynajson
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeTimes(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data := `{
+		"created": "2020-12-14T09:30:00+09:00",
+		"updated": "14/12/2020 01:02:03",
+		"log": [{"at": "Mon, 14 Dec 2020 10:00:00 GMT"}, {"at": "yesterday"}],
+		"name": "2020"
+	}`
+
+	layouts := []string{time.RFC3339, "02/01/2006 15:04:05", time.RFC1123}
+
+	root, err := NewByString(data)
+	assert.Nil(err)
+
+	count, err := root.NormalizeTimes(layouts, time.RFC3339, time.UTC)
+	assert.Nil(err)
+	assert.Equal(3, count)
+	assert.Equal("2020-12-14T00:30:00Z", root.Select("created").AsString())
+	assert.Equal("2020-12-14T01:02:03Z", root.Select("updated").AsString())
+	assert.Equal("2020-12-14T10:00:00Z", root.Select("log", 0, "at").AsString())
+	assert.Equal("yesterday", root.Select("log", 1, "at").AsString())
+	assert.Equal("2020", root.Select("name").AsString())
+
+	root, err = NewByString(data)
+	assert.Nil(err)
+
+	count, err = root.NormalizeTimes(layouts, "2006-01-02", nil, "log/*/at")
+	assert.Nil(err)
+	assert.Equal(1, count)
+	assert.Equal("2020-12-14", root.Select("log", 0, "at").AsString())
+	assert.Equal("2020-12-14T09:30:00+09:00", root.Select("created").AsString())
+}
+
+func TestAsTime(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"ts": "2020-01-02T03:04:05.5+09:00", "day": "2020-01-02", "bad": "x", "n": 1}`)
+	assert.Nil(err)
+
+	ts, err := root.Select("ts").AsTime()
+	assert.Nil(err)
+	assert.Equal(time.Date(2020, 1, 1, 18, 4, 5, 500000000, time.UTC), ts.UTC())
+
+	day, err := root.Select("day").AsTime(time.RFC3339, "2006-01-02")
+	assert.Nil(err)
+	assert.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), day)
+
+	_, err = root.Select("day").AsTime()
+	assert.NotNil(err)
+
+	_, err = root.Select("bad").AsTime()
+	assert.Equal(`AsTime: /bad: Bad Time: "x"`, err.Error())
+
+	_, err = root.Select("n").AsTime()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+
+	_, err = root.Select("none").AsTime()
+	assert.True(errors.Is(err, ErrNotFound))
+}
+
+func TestAsDuration(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`["1h30m", "PT1H30M", "P1DT0.5S", "-PT2M", "P2W", "250ms", "P", "PT", "1x", 5]`)
+	assert.Nil(err)
+
+	expect := []time.Duration{
+		90 * time.Minute,
+		90 * time.Minute,
+		24*time.Hour + 500*time.Millisecond,
+		-2 * time.Minute,
+		14 * 24 * time.Hour,
+		250 * time.Millisecond,
+	}
+
+	for i, v := range expect {
+		d, err := root.Select(i).AsDuration()
+		assert.Nil(err, i)
+		assert.Equal(v, d, i)
+	}
+
+	for i := len(expect); i < root.Count()-1; i++ {
+		_, err := root.Select(i).AsDuration()
+		assert.NotNil(err, i)
+	}
+
+	_, err = root.Select(root.Count() - 1).AsDuration()
+	assert.True(errors.Is(err, ErrTypeMismatch))
+}
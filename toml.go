@@ -0,0 +1,938 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// MediaTypeTOML ... const
+const MediaTypeTOML = "application/toml"
+
+func init() {
+	RegisterDecoder(MediaTypeTOML, decodeTOML, ".toml")
+}
+
+func decodeTOML(data []byte) (interface{}, error) {
+	return tomlDecode(data, nil)
+}
+
+// tomlParser ... struct
+// TOML 1.0, date and time values are kept as the strings they were written as.
+type tomlParser struct {
+	src   string
+	pos   int
+	line  int
+	order *keyOrderState
+	root  map[string]interface{}
+	cur   map[string]interface{}
+	// fixed are inline tables and static arrays, which can not be extended
+	fixed map[uintptr]bool
+}
+
+func tomlDecode(data []byte, order *keyOrderState) (interface{}, error) {
+
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("TOML: Invalid UTF-8")
+	}
+
+	p := &tomlParser{
+		src:   strings.TrimPrefix(string(data), "\ufeff"),
+		line:  1,
+		order: order,
+		root:  map[string]interface{}{},
+		fixed: map[uintptr]bool{},
+	}
+	p.cur = p.root
+
+	err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("TOML: line %d: %w", p.line, err)
+	}
+
+	return p.root, nil
+}
+
+func (me *tomlParser) eof() bool {
+	return me.pos >= len(me.src)
+}
+
+func (me *tomlParser) peek() byte {
+
+	if me.eof() {
+		return 0
+	}
+
+	return me.src[me.pos]
+}
+
+func (me *tomlParser) hasPrefix(s string) bool {
+	return strings.HasPrefix(me.src[me.pos:], s)
+}
+
+func (me *tomlParser) skipSpace() {
+
+	for !me.eof() && (me.peek() == ' ' || me.peek() == '\t') {
+		me.pos++
+	}
+}
+
+func (me *tomlParser) skipComment() {
+
+	if me.peek() != '#' {
+		return
+	}
+
+	for !me.eof() && me.peek() != '\n' {
+		me.pos++
+	}
+}
+
+// skipBlank ... func
+// spaces, comments and newlines (between statements and inside arrays).
+func (me *tomlParser) skipBlank() {
+
+	for {
+		me.skipSpace()
+		me.skipComment()
+
+		switch {
+		case me.hasPrefix("\n"):
+			me.pos++
+			me.line++
+		case me.hasPrefix("\r\n"):
+			me.pos += 2
+			me.line++
+		default:
+			return
+		}
+	}
+}
+
+func (me *tomlParser) endOfLine() error {
+
+	me.skipSpace()
+	me.skipComment()
+
+	switch {
+	case me.eof():
+		return nil
+	case me.hasPrefix("\n"), me.hasPrefix("\r\n"):
+		return nil
+	}
+
+	return fmt.Errorf("Unexpected %q", me.peek())
+}
+
+func (me *tomlParser) set(obj map[string]interface{}, key string, val interface{}) {
+
+	if me.order != nil {
+		me.order.add(obj, key)
+	}
+
+	obj[key] = val
+}
+
+func (me *tomlParser) newTable() map[string]interface{} {
+
+	obj := map[string]interface{}{}
+
+	if me.order != nil {
+		me.order.record(obj, []string{})
+	}
+
+	return obj
+}
+
+func (me *tomlParser) parse() error {
+
+	if me.order != nil {
+		me.order.record(me.root, []string{})
+	}
+
+	for {
+		me.skipBlank()
+
+		if me.eof() {
+			return nil
+		}
+
+		var err error
+
+		switch {
+		case me.hasPrefix("[["):
+			me.pos += 2
+			err = me.header(true)
+		case me.hasPrefix("["):
+			me.pos++
+			err = me.header(false)
+		default:
+			err = me.keyValue(me.cur)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = me.endOfLine()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (me *tomlParser) header(arrayTable bool) error {
+
+	keys, err := me.key()
+	if err != nil {
+		return err
+	}
+
+	closing := "]"
+	if arrayTable {
+		closing = "]]"
+	}
+
+	me.skipSpace()
+	if !me.hasPrefix(closing) {
+		return fmt.Errorf("Missing %s", closing)
+	}
+	me.pos += len(closing)
+
+	parent, err := me.descend(me.root, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	last := keys[len(keys)-1]
+
+	if arrayTable {
+		tbl := me.newTable()
+
+		switch typed := parent[last].(type) {
+		case nil:
+			arr := []interface{}{tbl}
+			me.set(parent, last, &arr)
+		case *[]interface{}:
+			if me.fixed[rawAddr(typed)] {
+				return fmt.Errorf("%s: Static Array", last)
+			}
+			*typed = append(*typed, tbl)
+		default:
+			return fmt.Errorf("%s: Not Array Of Tables", last)
+		}
+
+		me.cur = tbl
+
+		return nil
+	}
+
+	switch typed := parent[last].(type) {
+	case nil:
+		tbl := me.newTable()
+		me.set(parent, last, tbl)
+		me.cur = tbl
+	case map[string]interface{}:
+		if me.fixed[rawAddr(typed)] {
+			return fmt.Errorf("%s: Inline Table", last)
+		}
+		me.cur = typed
+	default:
+		return fmt.Errorf("%s: Already Defined", last)
+	}
+
+	return nil
+}
+
+// descend ... func
+// walks (creating) the tables of keys, the last element of an array of tables is entered.
+func (me *tomlParser) descend(obj map[string]interface{}, keys []string) (map[string]interface{}, error) {
+
+	for _, k := range keys {
+
+		switch typed := obj[k].(type) {
+		case nil:
+			tbl := me.newTable()
+			me.set(obj, k, tbl)
+			obj = tbl
+		case map[string]interface{}:
+			if me.fixed[rawAddr(typed)] {
+				return nil, fmt.Errorf("%s: Inline Table", k)
+			}
+			obj = typed
+		case *[]interface{}:
+			if me.fixed[rawAddr(typed)] || len(*typed) == 0 {
+				return nil, fmt.Errorf("%s: Static Array", k)
+			}
+			tbl, ok := (*typed)[len(*typed)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: Not Table", k)
+			}
+			obj = tbl
+		default:
+			return nil, fmt.Errorf("%s: Not Table", k)
+		}
+	}
+
+	return obj, nil
+}
+
+func (me *tomlParser) keyValue(obj map[string]interface{}) error {
+
+	keys, err := me.key()
+	if err != nil {
+		return err
+	}
+
+	me.skipSpace()
+	if me.peek() != '=' {
+		return fmt.Errorf("Missing =")
+	}
+	me.pos++
+	me.skipSpace()
+
+	val, err := me.value()
+	if err != nil {
+		return err
+	}
+
+	parent, err := me.descend(obj, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+
+	last := keys[len(keys)-1]
+
+	if _, ok := parent[last]; ok {
+		return fmt.Errorf("%s: Duplicate Key", last)
+	}
+
+	me.set(parent, last, val)
+
+	return nil
+}
+
+func isBareKeyChar(c byte) bool {
+	return c == '_' || c == '-' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (me *tomlParser) key() ([]string, error) {
+
+	keys := []string{}
+
+	for {
+		me.skipSpace()
+
+		var k string
+		var err error
+
+		switch me.peek() {
+		case '"':
+			k, err = me.basicString()
+		case '\'':
+			k, err = me.literalString()
+		default:
+			start := me.pos
+			for !me.eof() && isBareKeyChar(me.peek()) {
+				me.pos++
+			}
+			if start == me.pos {
+				return nil, fmt.Errorf("Bad Key")
+			}
+			k = me.src[start:me.pos]
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, k)
+
+		me.skipSpace()
+		if me.peek() != '.' {
+			return keys, nil
+		}
+		me.pos++
+	}
+}
+
+func (me *tomlParser) value() (interface{}, error) {
+
+	switch {
+	case me.hasPrefix(`"""`):
+		return me.multiLineString(`"""`)
+	case me.hasPrefix(`'''`):
+		return me.multiLineString(`'''`)
+	case me.peek() == '"':
+		return me.basicString()
+	case me.peek() == '\'':
+		return me.literalString()
+	case me.peek() == '[':
+		return me.array()
+	case me.peek() == '{':
+		return me.inlineTable()
+	case me.hasPrefix("true"):
+		me.pos += 4
+		return true, nil
+	case me.hasPrefix("false"):
+		me.pos += 5
+		return false, nil
+	}
+
+	return me.scalar()
+}
+
+func (me *tomlParser) escape() (string, error) {
+
+	// at the char after the backslash
+	c := me.peek()
+	me.pos++
+
+	switch c {
+	case 'b':
+		return "\b", nil
+	case 't':
+		return "\t", nil
+	case 'n':
+		return "\n", nil
+	case 'f':
+		return "\f", nil
+	case 'r':
+		return "\r", nil
+	case 'e':
+		return "\x1b", nil
+	case '"':
+		return `"`, nil
+	case '\\':
+		return `\`, nil
+	case 'u', 'U':
+		n := 4
+		if c == 'U' {
+			n = 8
+		}
+		if me.pos+n > len(me.src) {
+			return "", fmt.Errorf("Bad Escape")
+		}
+		code, err := strconv.ParseUint(me.src[me.pos:me.pos+n], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return "", fmt.Errorf("Bad Escape: \\%c%s", c, me.src[me.pos:me.pos+n])
+		}
+		me.pos += n
+		return string(rune(code)), nil
+	}
+
+	return "", fmt.Errorf("Bad Escape: \\%c", c)
+}
+
+func (me *tomlParser) basicString() (string, error) {
+
+	me.pos++
+
+	sb := strings.Builder{}
+
+	for {
+		if me.eof() || me.peek() == '\n' {
+			return "", fmt.Errorf("Unterminated String")
+		}
+
+		c := me.peek()
+		me.pos++
+
+		switch c {
+		case '"':
+			return sb.String(), nil
+		case '\\':
+			s, err := me.escape()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+func (me *tomlParser) literalString() (string, error) {
+
+	me.pos++
+
+	end := strings.IndexAny(me.src[me.pos:], "'\n")
+	if end < 0 || me.src[me.pos+end] != '\'' {
+		return "", fmt.Errorf("Unterminated String")
+	}
+
+	s := me.src[me.pos : me.pos+end]
+	me.pos += end + 1
+
+	return s, nil
+}
+
+func (me *tomlParser) multiLineString(quote string) (string, error) {
+
+	me.pos += 3
+
+	// a newline right after the opening quotes is trimmed
+	if me.hasPrefix("\r\n") {
+		me.pos += 2
+		me.line++
+	} else if me.hasPrefix("\n") {
+		me.pos++
+		me.line++
+	}
+
+	sb := strings.Builder{}
+
+	for {
+		if me.eof() {
+			return "", fmt.Errorf("Unterminated String")
+		}
+
+		if me.hasPrefix(quote) {
+			// up to two quotes may precede the closing ones
+			n := 3
+			for n < 5 && me.pos+n < len(me.src) && me.src[me.pos+n] == quote[0] {
+				n++
+			}
+			sb.WriteString(me.src[me.pos : me.pos+n-3])
+			me.pos += n
+			return sb.String(), nil
+		}
+
+		c := me.peek()
+		me.pos++
+
+		if c == '\n' {
+			me.line++
+		}
+
+		if c == '\\' && quote == `"""` {
+
+			// line ending backslash trims the following whitespace
+			rest := me.src[me.pos:]
+			trimmed := strings.TrimLeft(rest, " \t")
+			if strings.HasPrefix(trimmed, "\n") || strings.HasPrefix(trimmed, "\r\n") {
+				all := strings.TrimLeft(rest, " \t\r\n")
+				me.line += strings.Count(rest[:len(rest)-len(all)], "\n")
+				me.pos += len(rest) - len(all)
+				continue
+			}
+
+			s, err := me.escape()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+			continue
+		}
+
+		sb.WriteByte(c)
+	}
+}
+
+func (me *tomlParser) array() (interface{}, error) {
+
+	me.pos++
+
+	arr := []interface{}{}
+
+	for {
+		me.skipBlank()
+
+		if me.peek() == ']' {
+			me.pos++
+			break
+		}
+
+		val, err := me.value()
+		if err != nil {
+			return nil, err
+		}
+
+		arr = append(arr, val)
+
+		me.skipBlank()
+
+		switch me.peek() {
+		case ',':
+			me.pos++
+		case ']':
+		default:
+			return nil, fmt.Errorf("Bad Array")
+		}
+	}
+
+	me.fixed[rawAddr(&arr)] = true
+
+	return &arr, nil
+}
+
+func (me *tomlParser) inlineTable() (interface{}, error) {
+
+	me.pos++
+
+	obj := me.newTable()
+
+	me.skipSpace()
+	if me.peek() == '}' {
+		me.pos++
+		me.fixed[rawAddr(obj)] = true
+		return obj, nil
+	}
+
+	for {
+		err := me.keyValue(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		me.skipSpace()
+
+		switch me.peek() {
+		case ',':
+			me.pos++
+		case '}':
+			me.pos++
+			me.fixed[rawAddr(obj)] = true
+			return obj, nil
+		default:
+			return nil, fmt.Errorf("Bad Inline Table")
+		}
+	}
+}
+
+var (
+	tomlDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	tomlDateTime = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}:\d{2}(\.\d+)?([Zz]|[+-]\d{2}:\d{2})?)?|\d{2}:\d{2}:\d{2}(\.\d+)?)$`)
+	tomlInt      = regexp.MustCompile(`^[+-]?(0|[1-9](_?\d)*)$`)
+	tomlFloat    = regexp.MustCompile(`^[+-]?(0|[1-9](_?\d)*)((\.\d(_?\d)*)([eE][+-]?\d(_?\d)*)?|[eE][+-]?\d(_?\d)*)$`)
+)
+
+func (me *tomlParser) scalar() (interface{}, error) {
+
+	start := me.pos
+	for !me.eof() && strings.IndexByte(" \t\r\n,]}#", me.peek()) < 0 {
+		me.pos++
+	}
+
+	tok := me.src[start:me.pos]
+
+	// "1979-05-27 07:32:00" has a space in it
+	if tomlDate.MatchString(tok) && me.peek() == ' ' && len(me.src) > me.pos+3 && me.src[me.pos+3] == ':' {
+		me.pos++
+		for !me.eof() && strings.IndexByte(" \t\r\n,]}#", me.peek()) < 0 {
+			me.pos++
+		}
+		tok = me.src[start:me.pos]
+	}
+
+	switch tok {
+	case "":
+		return nil, fmt.Errorf("Missing Value")
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	if tomlDateTime.MatchString(tok) {
+		return tok, nil
+	}
+
+	if len(tok) > 2 && tok[0] == '0' && strings.IndexByte("xob", tok[1]) >= 0 {
+		base := map[byte]int{'x': 16, 'o': 8, 'b': 2}[tok[1]]
+		digits := tok[2:]
+		if strings.HasPrefix(digits, "_") || strings.HasSuffix(digits, "_") || strings.Contains(digits, "__") {
+			return nil, fmt.Errorf("Bad Number: %s", tok)
+		}
+		i, err := strconv.ParseInt(strings.Replace(digits, "_", "", -1), base, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Bad Number: %s", tok)
+		}
+		return int(i), nil
+	}
+
+	if tomlInt.MatchString(tok) {
+		i, err := strconv.ParseInt(strings.Replace(tok, "_", "", -1), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Bad Number: %s", tok)
+		}
+		return int(i), nil
+	}
+
+	if tomlFloat.MatchString(tok) {
+		f, err := strconv.ParseFloat(strings.Replace(tok, "_", "", -1), 64)
+		if err != nil {
+			return nil, fmt.Errorf("Bad Number: %s", tok)
+		}
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("Bad Value: %s", tok)
+}
+
+// NewByTOML ... func
+// loads a TOML document, map keys keep the order of the source.
+// Arrays are editable, dates and times become strings.
+func NewByTOML(data []byte) (*JSONElement, error) {
+
+	order := newKeyOrderState()
+
+	obj, err := tomlDecode(data, order)
+	if err != nil {
+		return nil, fmt.Errorf("NewByTOML: %w", err)
+	}
+
+	elm := New(obj)
+	elm.order = order
+
+	return elm, nil
+}
+
+// tomlWriter ... struct
+type tomlWriter struct {
+	buf  *bytes.Buffer
+	elm  *JSONElement
+	less func(string, string) bool
+}
+
+func tomlKey(k string) string {
+
+	if k == "" {
+		return `""`
+	}
+
+	for i := 0; i < len(k); i++ {
+		if !isBareKeyChar(k[i]) {
+			return tomlString(k)
+		}
+	}
+
+	return k
+}
+
+func tomlString(s string) string {
+
+	sb := strings.Builder{}
+	sb.WriteByte('"')
+
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+func tomlPath(path []string) string {
+
+	parts := make([]string, len(path))
+	for i, v := range path {
+		parts[i] = tomlKey(v)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// isTableArray ... func
+// non empty array whose elements are all maps, written as [[name]].
+func isTableArray(raw interface{}) bool {
+
+	arr := asSlice(raw)
+	if len(arr) == 0 {
+		return false
+	}
+
+	for _, v := range arr {
+		if _, ok := v.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (me *tomlWriter) inline(path []string, raw interface{}) (string, error) {
+
+	raw, err := resolveSpill(raw)
+	if err != nil {
+		return "", err
+	}
+
+	switch typed := raw.(type) {
+	case nil:
+		return "", fmt.Errorf("%s: TOML Has No null", tomlPath(path))
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case string:
+		return tomlString(typed), nil
+	case int:
+		return strconv.Itoa(typed), nil
+	case json.Number:
+		return typed.String(), nil
+	case float64:
+		switch {
+		case math.IsNaN(typed):
+			return "nan", nil
+		case math.IsInf(typed, 1):
+			return "inf", nil
+		case math.IsInf(typed, -1):
+			return "-inf", nil
+		case typed == math.Trunc(typed) && math.Abs(typed) < 1e15:
+			return strconv.FormatFloat(typed, 'f', -1, 64), nil
+		}
+		s := strconv.FormatFloat(typed, 'g', -1, 64)
+		if !strings.ContainsAny(s, ".e") {
+			s += ".0"
+		}
+		return s, nil
+
+	case []interface{}, *[]interface{}:
+		parts := []string{}
+		for i, v := range asSlice(typed) {
+			s, err := me.inline(append(path, strconv.Itoa(i)), v)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		return "[" + strings.Join(parts, ", ") + "]", nil
+
+	case map[string]interface{}:
+		parts := []string{}
+		for _, k := range me.elm.order.keys(typed, me.less) {
+			s, err := me.inline(append(path, k), typed[k])
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, tomlKey(k)+" = "+s)
+		}
+		if len(parts) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(parts, ", ") + " }", nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", tomlPath(path), err)
+	}
+
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return "", fmt.Errorf("%s: %w", tomlPath(path), err)
+	}
+
+	return me.inline(path, obj)
+}
+
+// table ... func
+// key/values first, then sub tables and arrays of tables.
+func (me *tomlWriter) table(path []string, obj map[string]interface{}, header string) error {
+
+	keys := me.elm.order.keys(obj, me.less)
+	nested := []string{}
+
+	if header != "" {
+		if me.buf.Len() > 0 {
+			me.buf.WriteByte('\n')
+		}
+		me.buf.WriteString(header + "\n")
+	}
+
+	for _, k := range keys {
+
+		v, err := resolveSpill(obj[k])
+		if err != nil {
+			return fmt.Errorf("%s: %w", tomlPath(append(path, k)), err)
+		}
+
+		if _, ok := v.(map[string]interface{}); ok || isTableArray(v) {
+			nested = append(nested, k)
+			continue
+		}
+
+		s, err := me.inline(append(path, k), v)
+		if err != nil {
+			return err
+		}
+
+		me.buf.WriteString(tomlKey(k) + " = " + s + "\n")
+	}
+
+	for _, k := range nested {
+
+		sub := append(append([]string{}, path...), k)
+		v, err := resolveSpill(obj[k])
+		if err != nil {
+			return fmt.Errorf("%s: %w", tomlPath(sub), err)
+		}
+
+		if typed, ok := v.(map[string]interface{}); ok {
+			err := me.table(sub, typed, "["+tomlPath(sub)+"]")
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, elm := range asSlice(v) {
+			err := me.table(sub, elm.(map[string]interface{}), "[["+tomlPath(sub)+"]]")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ToTOML ... func
+// writes me (a map) as a TOML document, map keys in insertion order for
+// ordered elements, otherwise by KeyLess (lexical when nil). null is an error.
+func (me *JSONElement) ToTOML() ([]byte, error) {
+
+	obj, ok := me.Raw().(map[string]interface{})
+	if !ok {
+		return nil, me.fail("ToTOML", ErrorNotMap, "%T", me.Raw())
+	}
+
+	less := me.KeyLess
+	if less == nil {
+		less = LexicalLess
+	}
+
+	w := &tomlWriter{buf: &bytes.Buffer{}, elm: me, less: less}
+
+	err := w.table([]string{}, obj, "")
+	if err != nil {
+		return nil, me.Errorf("ToTOML: %w", err)
+	}
+
+	return w.buf.Bytes(), nil
+}
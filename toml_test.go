@@ -0,0 +1,159 @@
+package dynajson
+
+import (
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByTOML(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := `# config
+title = "TOML \"Example\""
+site.name = 'C:\path'
+
+[owner]
+name = "Tom"
+dob = 1979-05-27T07:32:00-08:00
+day = 1979-05-27 07:32:00
+
+[database]
+enabled = true
+ports = [ 8000, 8001,
+  8002, # last
+]
+data = [ ["delta", "phi"], [3.14] ]
+temp_targets = { cpu = 79.5, case = 72.0 }
+hex = 0xDEAD_BEEF
+big = 1_000
+exp = 5e+22
+inf = -inf
+
+[[products]]
+name = "Hammer"
+sku = 738594937
+
+[[products]]
+
+[[products]]
+name = "Nail"
+color = "gray"
+
+[products.size]
+len = 2
+
+[servers."alpha.example"]
+ip = "10.0.0.1"
+desc = """
+multi \
+   line"""
+raw = '''
+it's "raw"'''
+`
+
+	elm, err := NewByTOML([]byte(src))
+	assert.Nil(err)
+
+	assert.Equal([]string{"title", "site", "owner", "database", "products", "servers"}, elm.Keys())
+	assert.Equal(`TOML "Example"`, elm.Select("title").AsString())
+	assert.Equal(`C:\path`, elm.Select("site", "name").AsString())
+	assert.Equal("1979-05-27T07:32:00-08:00", elm.Select("owner", "dob").AsString())
+	assert.Equal("1979-05-27 07:32:00", elm.Select("owner", "day").AsString())
+	assert.True(elm.Select("database", "enabled").AsBool())
+	assert.Equal(3, elm.Select("database", "ports").Count())
+	assert.Equal(8002, elm.Select("database", "ports", 2).AsInt())
+	assert.Equal("phi", elm.Select("database", "data", 0, 1).AsString())
+	assert.Equal(79.5, elm.Select("database", "temp_targets", "cpu").AsFloat())
+	assert.Equal([]string{"cpu", "case"}, elm.Select("database", "temp_targets").Keys())
+	assert.Equal(0xDEADBEEF, elm.Select("database", "hex").AsInt())
+	assert.Equal(1000, elm.Select("database", "big").AsInt())
+	assert.Equal(5e+22, elm.Select("database", "exp").AsFloat())
+	assert.True(math.IsInf(elm.Select("database", "inf").AsFloat(), -1))
+	assert.Equal(3, elm.Select("products").Count())
+	assert.Equal(0, elm.Select("products", 1).Count())
+	assert.Equal(2, elm.Select("products", 2, "size", "len").AsInt())
+	assert.Equal("10.0.0.1", elm.Select("servers", "alpha.example", "ip").AsString())
+	assert.Equal("multi line", elm.Select("servers", "alpha.example", "desc").AsString())
+	assert.Equal(`it's "raw"`, elm.Select("servers", "alpha.example", "raw").AsString())
+
+	for _, bad := range []string{
+		"a = 1\na = 2",
+		"a = ",
+		"a = \"x",
+		"[a\nb = 1",
+		"a = {x = 1}\n[a]",
+		"a = [1]\n[[a]]",
+		"a = 1 b = 2",
+		"a = 0x",
+		"a = 1__0",
+	} {
+		_, err := NewByTOML([]byte(bad))
+		assert.NotNil(err, bad)
+	}
+}
+
+func TestToTOML(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, _ := NewByString(`{"title": "x\ny", "port": 8080, "ratio": 0.5, "tags": ["a", "b"], "mixed": [1, {"k": true}],
+		"db": {"host": "h", "opts": {}}, "items": [{"id": 1}, {"id": 2, "sub": {"z": 1}}], "my key": 1}`)
+
+	data, err := elm.ToTOML()
+	assert.Nil(err)
+	assert.Equal(`mixed = [1, { k = true }]
+"my key" = 1
+port = 8080
+ratio = 0.5
+tags = ["a", "b"]
+title = "x\ny"
+
+[db]
+host = "h"
+
+[db.opts]
+
+[[items]]
+id = 1
+
+[[items]]
+id = 2
+
+[items.sub]
+z = 1
+`, string(data))
+
+	back, err := NewByTOML(data)
+	assert.Nil(err)
+	assert.True(rawEqual(elm.Raw(), back.Raw()))
+
+	src := "z = 1\na = 2\n\n[m]\ny = 1\nb = 2\n"
+	ordered, _ := NewByTOML([]byte(src))
+	data, err = ordered.ToTOML()
+	assert.Nil(err)
+	assert.Equal(src, string(data))
+
+	withNull, _ := NewByString(`{"a": null}`)
+	_, err = withNull.ToTOML()
+	assert.NotNil(err)
+
+	_, err = New(&[]interface{}{}).ToTOML()
+	assert.NotNil(err)
+}
+
+func TestNewByPathTOML(t *testing.T) {
+
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "conf.toml")
+	assert.Nil(ioutil.WriteFile(path, []byte("[server]\nport = 8080\n"), 0644))
+
+	elm, err := NewByPath(path)
+	assert.Nil(err)
+	assert.Equal(8080, elm.Select("server", "port").AsInt())
+}
@@ -0,0 +1,111 @@
+package dynajson
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// ValueEncoding ... type
+type ValueEncoding int
+
+const (
+	// EncodingRaw ... the string as is
+	EncodingRaw ValueEncoding = iota
+	// EncodingBase64 ... standard base64 with padding
+	EncodingBase64
+	// EncodingBase64URL ... URL-safe base64 without padding
+	EncodingBase64URL
+	// EncodingHex ... lower case hex
+	EncodingHex
+)
+
+func encodeString(str string, enc ValueEncoding) (string, bool) {
+
+	switch enc {
+	case EncodingRaw:
+		return str, true
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString([]byte(str)), true
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString([]byte(str)), true
+	case EncodingHex:
+		return hex.EncodeToString([]byte(str)), true
+	}
+
+	return "", false
+}
+
+func decodeString(str string, enc ValueEncoding) (string, error) {
+
+	var data []byte
+	var err error
+
+	switch enc {
+	case EncodingRaw:
+		return str, nil
+	case EncodingBase64:
+		data, err = base64.StdEncoding.DecodeString(str)
+	case EncodingBase64URL:
+		data, err = base64.RawURLEncoding.DecodeString(str)
+	case EncodingHex:
+		data, err = hex.DecodeString(str)
+	default:
+		return "", errBadEncoding
+	}
+
+	return string(data), err
+}
+
+var errBadEncoding = errors.New("Bad Encoding")
+
+func (me *JSONElement) transcodeValue(name, path string, fn func(string) (string, error)) error {
+
+	keys := me.SlashPath2Keys(path)
+
+	parent, key, err := me.selectParent(keys)
+	if err != nil {
+		return me.Errorf("%s: %s: %w", name, path, err)
+	}
+
+	str, ok := parent.Select(key).Raw().(string)
+	if !ok {
+		return me.Errorf("%s: %s: Not String: %T", name, path, parent.Select(key).Raw())
+	}
+
+	str, err = fn(str)
+	if err != nil {
+		return me.Errorf("%s: %s: %w", name, path, err)
+	}
+
+	err = parent.setChild(key, str)
+	if err != nil {
+		return me.Errorf("%s: %s: %w", name, path, err)
+	}
+
+	return nil
+}
+
+// EncodeValue ... func
+// rewrites the string leaf at path ("/" separated) in its encoded form.
+func (me *JSONElement) EncodeValue(path string, enc ValueEncoding) error {
+
+	return me.transcodeValue("EncodeValue", path, func(str string) (string, error) {
+
+		ret, ok := encodeString(str, enc)
+		if !ok {
+			return "", errBadEncoding
+		}
+
+		return ret, nil
+	})
+}
+
+// DecodeValue ... func
+// rewrites the encoded string leaf at path ("/" separated) with the decoded bytes.
+func (me *JSONElement) DecodeValue(path string, enc ValueEncoding) error {
+
+	return me.transcodeValue("DecodeValue", path, func(str string) (string, error) {
+		return decodeString(str, enc)
+	})
+}
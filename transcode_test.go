@@ -0,0 +1,32 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranscodeValue(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"hook": {"payload": "eyJpZCI6IDF9", "list": ["68656c6c6f"]}, "n": 1}`)
+	assert.Nil(err)
+
+	assert.Nil(root.DecodeValue("hook/payload", EncodingBase64))
+	assert.Equal(`{"id": 1}`, root.Select("hook", "payload").AsString())
+
+	assert.Nil(root.DecodeValue("hook/list/0", EncodingHex))
+	assert.Equal("hello", root.Select("hook", "list", 0).AsString())
+
+	assert.Nil(root.EncodeValue("hook/list/0", EncodingBase64URL))
+	assert.Equal("aGVsbG8", root.Select("hook", "list", 0).AsString())
+
+	assert.Nil(root.EncodeValue("hook/payload", EncodingBase64))
+	assert.Equal("eyJpZCI6IDF9", root.Select("hook", "payload").AsString())
+
+	assert.NotNil(root.DecodeValue("hook/list/0", EncodingHex))
+	assert.NotNil(root.DecodeValue("n", EncodingHex))
+	assert.NotNil(root.DecodeValue("none/x", EncodingHex))
+	assert.NotNil(root.EncodeValue("hook/payload", ValueEncoding(99)))
+}
@@ -0,0 +1,105 @@
+package dynajson
+
+import "fmt"
+
+// Action ... type
+// what Transform does with a node.
+type Action int
+
+const (
+	// ActionKeep ... leave the node and descend into it
+	ActionKeep Action = iota
+	// ActionReplace ... replace the node with the returned value, not descended into
+	ActionReplace
+	// ActionDelete ... remove the node from its map or array
+	ActionDelete
+	// ActionSkipSubtree ... leave the node and do not descend into it
+	ActionSkipSubtree
+)
+
+// Transform ... func
+// visits every node below me (pre-order) and applies the Action fn returns, path
+// is relative to me. An error stops the walk, the changes made so far are kept.
+func (me *JSONElement) Transform(fn func(path []interface{}, elm *JSONElement) (Action, interface{}, error)) error {
+
+	if me.IsNil() {
+		return me.fail("Transform", ErrorNilObject, "")
+	}
+
+	if me.isReadonly() {
+		return me.fail("Transform", ErrorReadonly, "")
+	}
+
+	defer me.batch()()
+
+	var visit func(elm *JSONElement, rel []interface{}) error
+	visit = func(elm *JSONElement, rel []interface{}) error {
+
+		entries, err := walkEntries(rel, elm.raw)
+		if err != nil {
+			return err
+		}
+
+		removed := 0
+
+		for _, v := range entries {
+
+			key := v.key
+			if pos, ok := key.(int); ok {
+				key = pos - removed
+			}
+
+			sub := elm.child(key, v.val)
+			subRel := appendParents(rel, key)
+
+			action, val, err := fn(subRel, sub)
+			if err != nil {
+				return fmt.Errorf("%s: %w", Path2Pointer(subRel), err)
+			}
+
+			switch action {
+			case ActionKeep:
+				if err := visit(sub, subRel); err != nil {
+					return err
+				}
+
+			case ActionReplace:
+				newRaw := elm2Raw(val)
+				elm.unshare()
+				old, _ := containerRaw(elm.raw, key)
+				elm.order.replace(old, newRaw)
+				setContainerRaw(elm.raw, key, newRaw)
+				elm.notify("replace", key, newRaw)
+
+			case ActionDelete:
+				elm.unshare()
+				switch typed := key.(type) {
+				case string:
+					obj := elm.raw.(map[string]interface{})
+					elm.order.remove(obj, typed)
+					delete(obj, typed)
+				case int:
+					elm.makeEditable()
+					refArr := elm.raw.(*[]interface{})
+					elm.order.forget((*refArr)[typed])
+					*refArr = remove(*refArr, typed)
+					removed++
+				}
+				elm.notify("remove", key, nil)
+
+			case ActionSkipSubtree:
+
+			default:
+				return fmt.Errorf("%s: Bad Action: %d", Path2Pointer(subRel), action)
+			}
+		}
+
+		return nil
+	}
+
+	if err := visit(me, []interface{}{}); err != nil {
+		return me.Errorf("Transform: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package dynajson
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransform(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{
+		"user": {"name": "a", "password": "x", "created": "2020/01/02"},
+		"list": [1, null, 2, null, null, 3],
+		"raw": {"password": "keep"},
+		"tmp": true
+	}`)
+	assert.Nil(err)
+	root.KeyLess = LexicalLess
+
+	paths := []string{}
+
+	err = root.Transform(func(path []interface{}, elm *JSONElement) (Action, interface{}, error) {
+
+		paths = append(paths, Path2Pointer(path))
+
+		switch {
+		case path[0] == "raw":
+			return ActionSkipSubtree, nil, nil
+		case path[len(path)-1] == "password":
+			return ActionReplace, "***", nil
+		case path[len(path)-1] == "created":
+			return ActionReplace, strings.Replace(elm.AsString(), "/", "-", -1), nil
+		case path[0] == "tmp", elm.IsNil():
+			return ActionDelete, nil, nil
+		}
+
+		return ActionKeep, nil, nil
+	})
+	assert.Nil(err)
+
+	assert.Equal(`{"list": [1, 2, 3], "raw": {"password": "keep"}, "user": {"created": "2020-01-02", "name": "a", "password": "***"}}`, root.String())
+
+	// array positions are the current ones
+	assert.Contains(paths, "/list/2")
+	assert.NotContains(paths, "/list/5")
+	assert.NotContains(paths, "/raw/password")
+
+	// errors stop the walk
+	stop := errors.New("stop")
+	err = root.Transform(func(path []interface{}, elm *JSONElement) (Action, interface{}, error) {
+		if path[0] == "list" {
+			return ActionKeep, nil, stop
+		}
+		return ActionKeep, nil, nil
+	})
+	assert.True(errors.Is(err, stop))
+
+	err = root.Transform(func(path []interface{}, elm *JSONElement) (Action, interface{}, error) {
+		return Action(9), nil, nil
+	})
+	assert.NotNil(err)
+
+	root.Readonly = true
+	assert.NotNil(root.Transform(func(path []interface{}, elm *JSONElement) (Action, interface{}, error) {
+		return ActionDelete, nil, nil
+	}))
+}
+
+func TestTransformSnapshot(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": {"b": [1, 2]}}`)
+	assert.Nil(err)
+
+	snap := root.Snapshot()
+
+	err = root.Transform(func(path []interface{}, elm *JSONElement) (Action, interface{}, error) {
+		if elm.Raw() == 1.0 {
+			return ActionDelete, nil, nil
+		}
+		return ActionKeep, nil, nil
+	})
+	assert.Nil(err)
+
+	assert.Equal(`{"a": {"b": [2]}}`, root.String())
+	assert.Equal(`{"a": {"b": [1, 2]}}`, snap.String())
+}
@@ -0,0 +1,497 @@
+package dynajson
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// structField ... struct
+// a field of a struct as encoding/json sees it.
+type structField struct {
+	name   string
+	index  []int
+	quoted bool
+}
+
+func parseJSONTag(f reflect.StructField) (string, bool, bool) {
+
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(tag, ",")
+
+	quoted := false
+	for _, v := range parts[1:] {
+		if v == "string" {
+			quoted = true
+		}
+	}
+
+	return parts[0], quoted, false
+}
+
+// structFields ... func
+// exported fields by their json name, fields of embedded structs are promoted
+// unless a shallower field has the same name.
+func structFields(t reflect.Type) []structField {
+
+	fields := []structField{}
+	seen := map[string]bool{}
+
+	type level struct {
+		t     reflect.Type
+		index []int
+	}
+
+	current := []level{{t: t}}
+
+	for len(current) > 0 {
+
+		next := []level{}
+		found := map[string]bool{}
+
+		for _, lv := range current {
+			for i := 0; i < lv.t.NumField(); i++ {
+
+				f := lv.t.Field(i)
+
+				name, quoted, skip := parseJSONTag(f)
+				if skip {
+					continue
+				}
+
+				index := append(append([]int{}, lv.index...), i)
+
+				ft := f.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+
+				if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+					next = append(next, level{t: ft, index: index})
+					continue
+				}
+
+				if f.PkgPath != "" {
+					continue
+				}
+
+				if name == "" {
+					name = f.Name
+				}
+
+				if seen[name] || found[name] {
+					continue
+				}
+
+				found[name] = true
+				fields = append(fields, structField{name: name, index: index, quoted: quoted})
+			}
+		}
+
+		for k := range found {
+			seen[k] = true
+		}
+
+		current = next
+	}
+
+	return fields
+}
+
+// lookupField ... func
+// exact name first, then case-insensitive like encoding/json.
+func lookupField(fields []structField, key string) (structField, bool) {
+
+	for _, f := range fields {
+		if f.name == key {
+			return f, true
+		}
+	}
+
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+
+	return structField{}, false
+}
+
+// fieldByIndex ... func
+// allocates nil embedded pointers on the way.
+func fieldByIndex(rv reflect.Value, index []int) reflect.Value {
+
+	for i, x := range index {
+
+		if i > 0 && rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+
+		rv = rv.Field(x)
+	}
+
+	return rv
+}
+
+type unmarshalError struct {
+	path []interface{}
+	raw  interface{}
+	typ  reflect.Type
+}
+
+func (me *unmarshalError) Error() string {
+	return fmt.Sprintf("%s: cannot unmarshal %s into %s", Path2Pointer(me.path), kindOf(me.raw), me.typ)
+}
+
+// plainCopy ... func
+// what encoding/json would store in an interface{}: arrays as []interface{}.
+func plainCopy(raw interface{}) (interface{}, error) {
+
+	raw, err := resolveSpill(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		obj := make(map[string]interface{}, len(typed))
+		for k, v := range typed {
+			if obj[k], err = plainCopy(v); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	case []interface{}, *[]interface{}:
+		src := asSlice(typed)
+		arr := make([]interface{}, len(src))
+		for i, v := range src {
+			if arr[i], err = plainCopy(v); err != nil {
+				return nil, err
+			}
+		}
+		return arr, nil
+	case int:
+		return float64(typed), nil
+	}
+
+	return raw, nil
+}
+
+func (me *JSONElement) decodeInto(path []interface{}, raw interface{}, rv reflect.Value, quoted bool) error {
+
+	raw, err := resolveSpill(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	switch raw.(type) {
+	case nil, bool, string, int, float64, json.Number, map[string]interface{}, []interface{}, *[]interface{}:
+	default:
+		// whatever was Put as another Go type (int64, a struct, ...)
+		if raw, err = plainRaw(raw); err != nil {
+			return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+		}
+	}
+
+	mismatch := func() error {
+		return &unmarshalError{path: path, raw: raw, typ: rv.Type()}
+	}
+
+	// custom decoders (time.Time, ...) get their JSON text
+	if rv.Kind() != reflect.Ptr && rv.CanAddr() {
+
+		pv := rv.Addr()
+
+		if pv.Type().Implements(jsonUnmarshalerType) {
+
+			data, err := New(raw).Marshal(DumpOptions{})
+			if err != nil {
+				return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+			}
+
+			err = pv.Interface().(json.Unmarshaler).UnmarshalJSON(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+			}
+
+			return nil
+		}
+
+		if s, ok := raw.(string); ok && pv.Type().Implements(textUnmarshalerType) {
+
+			err := pv.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			if err != nil {
+				return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+			}
+
+			return nil
+		}
+	}
+
+	if raw == nil {
+		switch rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+		return nil
+	}
+
+	// ",string" fields hold their value as a JSON string
+	if quoted {
+		s, ok := raw.(string)
+		if !ok {
+			return mismatch()
+		}
+
+		var inner interface{}
+		if err := json.Unmarshal([]byte(s), &inner); err != nil {
+			if rv.Kind() != reflect.String {
+				return mismatch()
+			}
+			inner = s
+		}
+
+		raw = inner
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return me.decodeInto(path, raw, rv.Elem(), false)
+
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return mismatch()
+		}
+		cp, err := plainCopy(raw)
+		if err != nil {
+			return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+		}
+		if cp != nil {
+			rv.Set(reflect.ValueOf(cp))
+		} else {
+			rv.Set(reflect.Zero(rv.Type()))
+		}
+
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return mismatch()
+		}
+		rv.SetBool(b)
+
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return mismatch()
+		}
+		rv.SetString(s)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		switch typed := raw.(type) {
+		case json.Number:
+			v, err := typed.Int64()
+			if err != nil {
+				return mismatch()
+			}
+			i = v
+		default:
+			f, ok := jpNumber(raw)
+			if !ok || f != math.Trunc(f) {
+				return mismatch()
+			}
+			i = int64(f)
+		}
+		if rv.OverflowInt(i) {
+			return mismatch()
+		}
+		rv.SetInt(i)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		switch typed := raw.(type) {
+		case json.Number:
+			v, err := strconv.ParseUint(string(typed), 10, 64)
+			if err != nil {
+				return mismatch()
+			}
+			u = v
+		default:
+			f, ok := jpNumber(raw)
+			if !ok || f != math.Trunc(f) || f < 0 {
+				return mismatch()
+			}
+			u = uint64(f)
+		}
+		if rv.OverflowUint(u) {
+			return mismatch()
+		}
+		rv.SetUint(u)
+
+	case reflect.Float32, reflect.Float64:
+		f, ok := jpNumber(raw)
+		if !ok || rv.OverflowFloat(f) {
+			return mismatch()
+		}
+		rv.SetFloat(f)
+
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			s, ok := raw.(string)
+			if !ok {
+				return mismatch()
+			}
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+			}
+			rv.SetBytes(b)
+			return nil
+		}
+
+		arr := asSlice(raw)
+		if arr == nil {
+			return mismatch()
+		}
+
+		sv := reflect.MakeSlice(rv.Type(), len(arr), len(arr))
+		for i, v := range arr {
+			err := me.decodeInto(appendParents(path, i), v, sv.Index(i), false)
+			if err != nil {
+				return err
+			}
+		}
+		rv.Set(sv)
+
+	case reflect.Array:
+		arr := asSlice(raw)
+		if arr == nil {
+			return mismatch()
+		}
+
+		for i := 0; i < rv.Len(); i++ {
+			if i >= len(arr) {
+				rv.Index(i).Set(reflect.Zero(rv.Type().Elem()))
+				continue
+			}
+			err := me.decodeInto(appendParents(path, i), arr[i], rv.Index(i), false)
+			if err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMapWithSize(rv.Type(), len(obj)))
+		}
+
+		kt := rv.Type().Key()
+
+		for k, v := range obj {
+
+			kv := reflect.New(kt).Elem()
+
+			switch {
+			case reflect.PtrTo(kt).Implements(textUnmarshalerType):
+				err := kv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(k))
+				if err != nil {
+					return fmt.Errorf("%s: %w", Path2Pointer(appendParents(path, k)), err)
+				}
+			case kt.Kind() == reflect.String:
+				kv.SetString(k)
+			case kt.Kind() >= reflect.Int && kt.Kind() <= reflect.Int64:
+				i, err := strconv.ParseInt(k, 10, 64)
+				if err != nil || kv.OverflowInt(i) {
+					return &unmarshalError{path: appendParents(path, k), raw: k, typ: kt}
+				}
+				kv.SetInt(i)
+			case kt.Kind() >= reflect.Uint && kt.Kind() <= reflect.Uintptr:
+				u, err := strconv.ParseUint(k, 10, 64)
+				if err != nil || kv.OverflowUint(u) {
+					return &unmarshalError{path: appendParents(path, k), raw: k, typ: kt}
+				}
+				kv.SetUint(u)
+			default:
+				return mismatch()
+			}
+
+			ev := reflect.New(rv.Type().Elem()).Elem()
+
+			err := me.decodeInto(appendParents(path, k), v, ev, false)
+			if err != nil {
+				return err
+			}
+
+			rv.SetMapIndex(kv, ev)
+		}
+
+	case reflect.Struct:
+		obj, ok := raw.(map[string]interface{})
+		if !ok {
+			return mismatch()
+		}
+
+		fields := structFields(rv.Type())
+
+		for _, k := range mapKeys(obj, LexicalLess) {
+
+			f, ok := lookupField(fields, k)
+			if !ok {
+				continue
+			}
+
+			err := me.decodeInto(appendParents(path, k), obj[k], fieldByIndex(rv, f.index), f.quoted)
+			if err != nil {
+				return err
+			}
+		}
+
+	default:
+		return mismatch()
+	}
+
+	return nil
+}
+
+// Unmarshal ... func
+// decodes me into v (a non-nil pointer) the way json.Unmarshal would decode
+// String(), honoring json tags, without going through the text. Unknown keys are ignored.
+func (me *JSONElement) Unmarshal(v interface{}) error {
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return me.Errorf("Unmarshal: Not Pointer: %T", v)
+	}
+
+	err := me.decodeInto(me.FullPath(), me.raw, rv.Elem(), false)
+	if err != nil {
+		return me.Errorf("Unmarshal: %w", err)
+	}
+
+	return nil
+}
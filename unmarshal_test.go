@@ -0,0 +1,93 @@
+package dynajson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type unmarshalBase struct {
+	ID      int64  `json:"id"`
+	Comment string `json:"-"`
+}
+
+type unmarshalTarget struct {
+	unmarshalBase
+	Name    string            `json:"name"`
+	Tags    []string          `json:"tags"`
+	Ratio   *float64          `json:"ratio"`
+	Created time.Time         `json:"created"`
+	Count   int               `json:"count,string"`
+	Extra   map[string]int    `json:"extra"`
+	ByID    map[int]string    `json:"by_id"`
+	Any     interface{}       `json:"any"`
+	Pair    [2]uint8          `json:"pair"`
+	Data    []byte            `json:"data"`
+	Nested  *unmarshalTarget  `json:"nested"`
+	Labels  map[string]string `json:"labels"`
+	Caps    string
+	hidden  string
+}
+
+func TestUnmarshal(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"doc": {"id": 9, "Comment": "c", "name": "n", "tags": ["a", "b"], "ratio": 0.5,
+		"created": "2020-01-02T03:04:05Z", "count": "12", "extra": {"x": 1}, "by_id": {"7": "seven"},
+		"any": [1, {"k": true}], "pair": [1, 2, 3], "data": "AQI=", "nested": {"name": "child"},
+		"labels": null, "caps": "ci", "hidden": "h", "unknown": 1}}`)
+	assert.Nil(err)
+
+	var v unmarshalTarget
+	v.Labels = map[string]string{"old": "x"}
+
+	err = root.Select("doc").Unmarshal(&v)
+	assert.Nil(err)
+
+	assert.Equal(int64(9), v.ID)
+	assert.Equal("", v.Comment)
+	assert.Equal("n", v.Name)
+	assert.Equal([]string{"a", "b"}, v.Tags)
+	assert.Equal(0.5, *v.Ratio)
+	assert.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), v.Created)
+	assert.Equal(12, v.Count)
+	assert.Equal(map[string]int{"x": 1}, v.Extra)
+	assert.Equal(map[int]string{7: "seven"}, v.ByID)
+	assert.Equal([]interface{}{float64(1), map[string]interface{}{"k": true}}, v.Any)
+	assert.Equal([2]uint8{1, 2}, v.Pair)
+	assert.Equal([]byte{1, 2}, v.Data)
+	assert.Equal("child", v.Nested.Name)
+	assert.Nil(v.Labels)
+	assert.Equal("ci", v.Caps)
+	assert.Equal("", v.hidden)
+
+	var names []string
+	assert.Nil(root.Select("doc", "tags").Unmarshal(&names))
+	assert.Equal([]string{"a", "b"}, names)
+
+	var bad struct {
+		Tags []int `json:"tags"`
+	}
+	err = root.Select("doc").Unmarshal(&bad)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "/doc/tags/0: cannot unmarshal string into int")
+
+	var small struct {
+		ID int8 `json:"id"`
+	}
+	root.Select("doc").Put("id", 300)
+	assert.NotNil(root.Select("doc").Unmarshal(&small))
+
+	assert.NotNil(root.Unmarshal(v))
+	assert.NotNil(root.Unmarshal(nil))
+
+	put := NewAsMap()
+	put.Put("big", int64(9007199254740993))
+	var big struct {
+		Big int64 `json:"big"`
+	}
+	assert.Nil(put.Unmarshal(&big))
+	assert.Equal(int64(9007199254740993), big.Big)
+}
@@ -0,0 +1,288 @@
+package dynajson
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UTF8Policy ... type
+// how invalid UTF-8 bytes and lone surrogate escapes (\ud800) are handled.
+type UTF8Policy int
+
+const (
+	// UTF8Replace ... replace with U+FFFD (what encoding/json does)
+	UTF8Replace UTF8Policy = iota
+	// UTF8Reject ... fail
+	UTF8Reject
+	// UTF8PassThrough ... keep the bytes as they are (lone surrogates become WTF-8)
+	UTF8PassThrough
+)
+
+// UTF8Issue ... struct
+// Offset is the byte offset in the parsed data (-1 on output), Path the node (nil on parse).
+type UTF8Issue struct {
+	Offset int
+	Path   []interface{}
+	Reason string
+}
+
+func (me UTF8Issue) String() string {
+
+	if me.Offset >= 0 {
+		return fmt.Sprintf("offset %d: %s", me.Offset, me.Reason)
+	}
+
+	return fmt.Sprintf("%s: %s", FullPath2Str(me.Path, "/"), me.Reason)
+}
+
+// private use runes standing in for raw bytes / lone surrogates during UTF8PassThrough decoding,
+// real runes from U+10F000 up are kept behind markLiteral so they are not taken for markers.
+const (
+	markSurrogate = 0x10F000
+	markLiteral   = 0x10F800
+	markByte      = 0x10FF00
+)
+
+func isSurrogate(r rune, lo, hi rune) bool {
+	return lo <= r && r <= hi
+}
+
+func parseEscapeU(data []byte, i int) (rune, bool) {
+
+	if i+6 > len(data) || data[i] != '\\' || data[i+1] != 'u' {
+		return 0, false
+	}
+
+	v, err := strconv.ParseUint(string(data[i+2:i+6]), 16, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	return rune(v), true
+}
+
+// scanUTF8 ... func
+// finds invalid bytes and lone surrogate escapes, and with mark rewrites them to marker runes.
+func scanUTF8(data []byte, mark bool) ([]byte, []UTF8Issue) {
+
+	issues := []UTF8Issue{}
+	out := &bytes.Buffer{}
+
+	inString := false
+
+	for i := 0; i < len(data); {
+
+		c := data[i]
+
+		if c >= 0x80 {
+			r, size := utf8.DecodeRune(data[i:])
+			if r == utf8.RuneError && size == 1 {
+				issues = append(issues, UTF8Issue{Offset: i, Reason: fmt.Sprintf("Invalid Byte: 0x%02x", c)})
+				if mark {
+					out.WriteRune(markByte + rune(c))
+				}
+			} else if mark {
+				if r >= markSurrogate {
+					out.WriteRune(markLiteral)
+				}
+				out.Write(data[i : i+size])
+			}
+
+			i += size
+			continue
+		}
+
+		if inString && c == '\\' {
+			if r, ok := parseEscapeU(data, i); ok && isSurrogate(r, 0xD800, 0xDFFF) {
+
+				if isSurrogate(r, 0xD800, 0xDBFF) {
+					if r2, ok := parseEscapeU(data, i+6); ok && isSurrogate(r2, 0xDC00, 0xDFFF) {
+						if mark {
+							if utf16.DecodeRune(r, r2) >= markSurrogate {
+								out.WriteRune(markLiteral)
+							}
+							out.Write(data[i : i+12])
+						}
+						i += 12
+						continue
+					}
+				}
+
+				issues = append(issues, UTF8Issue{Offset: i, Reason: fmt.Sprintf("Lone Surrogate: %s", data[i:i+6])})
+				if mark {
+					r1, r2 := utf16.EncodeRune(markSurrogate + r - 0xD800)
+					fmt.Fprintf(out, `\u%04x\u%04x`, r1, r2)
+				}
+
+				i += 6
+				continue
+			}
+
+			if mark && i+1 < len(data) {
+				out.Write(data[i : i+2])
+			}
+
+			i += 2
+			continue
+		}
+
+		if c == '"' {
+			inString = !inString
+		}
+
+		if mark {
+			out.WriteByte(c)
+		}
+
+		i++
+	}
+
+	return out.Bytes(), issues
+}
+
+func hasMarkRune(arg string) bool {
+	return strings.IndexFunc(arg, func(r rune) bool { return r >= markSurrogate }) >= 0
+}
+
+func unmarkString(arg string) string {
+
+	if !hasMarkRune(arg) {
+		return arg
+	}
+
+	b := strings.Builder{}
+	literal := false
+
+	for _, r := range arg {
+
+		switch {
+		case literal:
+			b.WriteRune(r)
+			literal = false
+		case r == markLiteral:
+			literal = true
+		case r >= markByte+0x80:
+			b.WriteByte(byte(r - markByte))
+		case r >= markSurrogate && r < markSurrogate+0x800:
+			s := 0xD800 + r - markSurrogate
+			b.WriteByte(byte(0xE0 | (s >> 12)))
+			b.WriteByte(byte(0x80 | ((s >> 6) & 0x3F)))
+			b.WriteByte(byte(0x80 | (s & 0x3F)))
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func markString(arg string) string {
+
+	b := strings.Builder{}
+
+	for i := 0; i < len(arg); {
+
+		r, size := utf8.DecodeRuneInString(arg[i:])
+		if r == utf8.RuneError && size == 1 {
+			b.WriteRune(markByte + rune(arg[i]))
+		} else {
+			if r >= markSurrogate {
+				b.WriteRune(markLiteral)
+			}
+			b.WriteString(arg[i : i+size])
+		}
+
+		i += size
+	}
+
+	return b.String()
+}
+
+func unmarkRaw(arg interface{}) interface{} {
+
+	switch v := arg.(type) {
+	case string:
+		return unmarkString(v)
+	case []interface{}:
+		for i, sub := range v {
+			v[i] = unmarkRaw(sub)
+		}
+	case map[string]interface{}:
+		for k, sub := range v {
+			delete(v, k)
+			v[unmarkString(k)] = unmarkRaw(sub)
+		}
+	}
+
+	return arg
+}
+
+func sanitizeUTF8(path []interface{}, arg interface{}, policy UTF8Policy, issues []UTF8Issue) (interface{}, []UTF8Issue) {
+
+	fix := func(str string, where []interface{}, what string) string {
+
+		if utf8.ValidString(str) {
+			if policy == UTF8PassThrough && hasMarkRune(str) {
+				return markString(str)
+			}
+			return str
+		}
+
+		issues = append(issues, UTF8Issue{Offset: -1, Path: where, Reason: "Invalid UTF-8 " + what})
+
+		if policy == UTF8PassThrough {
+			// Dump would turn the bytes into U+FFFD, they are restored after Dump
+			return markString(str)
+		}
+
+		return strings.ToValidUTF8(str, "\uFFFD")
+	}
+
+	switch v := arg.(type) {
+	case string:
+		return fix(v, path, "Value"), issues
+	case *[]interface{}:
+		sub, subIssues := sanitizeUTF8(path, *v, policy, issues)
+		arr := sub.([]interface{})
+		return &arr, subIssues
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, sub := range v {
+			arr[i], issues = sanitizeUTF8(appendParents(path, i), sub, policy, issues)
+		}
+		return arr, issues
+	case map[string]interface{}:
+		obj := map[string]interface{}{}
+		for k, sub := range v {
+			subPath := appendParents(path, k)
+			key := fix(k, subPath, "Key")
+			obj[key], issues = sanitizeUTF8(subPath, sub, policy, issues)
+		}
+		return obj, issues
+	}
+
+	return arg, issues
+}
+
+// StringUTF8 ... func
+// String() with invalid UTF-8 handled per policy, the tree itself is not modified.
+func (me *JSONElement) StringUTF8(policy UTF8Policy) (string, []UTF8Issue, error) {
+
+	obj, issues := sanitizeUTF8([]interface{}{}, me.Raw(), policy, []UTF8Issue{})
+
+	if policy == UTF8Reject && len(issues) > 0 {
+		return "", issues, fmt.Errorf("StringUTF8: %s", issues[0])
+	}
+
+	str := New(obj).String()
+
+	if policy == UTF8PassThrough {
+		str = unmarkString(str)
+	}
+
+	return str, issues, nil
+}
@@ -0,0 +1,68 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUTF8Policy(t *testing.T) {
+
+	assert := assert.New(t)
+
+	data := []byte("{\"a\": \"x\xffy\", \"b\": \"\\ud800z\", \"c\": \"\\ud83d\\ude00\", \"d\xfe\": 1}")
+
+	issues := []UTF8Issue{}
+	root, err := NewByBytesWithOptions(data, ParseOptions{
+		OnUTF8Issue: func(v UTF8Issue) {
+			issues = append(issues, v)
+		},
+	})
+	assert.Nil(err)
+	assert.Equal(3, len(issues))
+	assert.Equal(8, issues[0].Offset)
+	assert.Equal("Lone Surrogate: \\ud800", issues[1].Reason)
+	assert.Equal("x\uFFFDy", root.Select("a").AsString())
+	assert.Equal("\uFFFDz", root.Select("b").AsString())
+	assert.Equal("😀", root.Select("c").AsString())
+
+	_, err = NewByBytesWithOptions(data, ParseOptions{UTF8: UTF8Reject})
+	assert.NotNil(err)
+
+	root, err = NewByBytesWithOptions(data, ParseOptions{UTF8: UTF8PassThrough})
+	assert.Nil(err)
+	assert.Equal("x\xffy", root.Select("a").AsString())
+	assert.Equal("\xed\xa0\x80z", root.Select("b").AsString())
+	assert.Equal("😀", root.Select("c").AsString())
+	assert.Equal(1, root.Select("d\xfe").AsInt())
+
+	str, outIssues, err := root.Select("a").StringUTF8(UTF8Replace)
+	assert.Nil(err)
+	assert.Equal(1, len(outIssues))
+	assert.Equal("\"x\uFFFDy\"", str)
+
+	_, outIssues, err = root.StringUTF8(UTF8Reject)
+	assert.NotNil(err)
+	assert.Equal(3, len(outIssues))
+
+	str, _, err = root.Select("a").StringUTF8(UTF8PassThrough)
+	assert.Nil(err)
+	assert.Equal("\"x\xffy\"", str)
+	assert.Equal("x\xffy", root.Select("a").AsString())
+
+	// real runes in the range of the markers are kept
+	data = []byte("{\"a\": \"\xff\U0010F000\U0010F800\U0010FF85\", \"b\": \"\\udbfc\\udc00\\udbff\\udfff\", \"\U0010FFAA\": 1}")
+	root, err = NewByBytesWithOptions(data, ParseOptions{UTF8: UTF8PassThrough, PreserveOrder: true})
+	assert.Nil(err)
+	assert.Equal("\xff\U0010F000\U0010F800\U0010FF85", root.Select("a").AsString())
+	assert.Equal("\U0010F000\U0010FFFF", root.Select("b").AsString())
+	assert.Equal(1, root.Select("\U0010FFAA").AsInt())
+	assert.Equal([]string{"a", "b", "\U0010FFAA"}, root.Keys())
+
+	str, outIssues, err = root.StringUTF8(UTF8PassThrough)
+	assert.Nil(err)
+	assert.Equal(1, len(outIssues))
+	assert.Contains(str, "\"\xff\U0010F000\U0010F800\U0010FF85\"")
+	assert.Contains(str, "\"\U0010F000\U0010FFFF\"")
+	assert.Contains(str, "\"\U0010FFAA\"")
+}
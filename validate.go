@@ -0,0 +1,711 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError ... struct
+// Path is the JSON Pointer of the failing value, SchemaPath the one of the keyword.
+type ValidationError struct {
+	Path       string
+	SchemaPath string
+	Keyword    string
+	Message    string
+}
+
+func (me ValidationError) Error() string {
+
+	path := me.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return fmt.Sprintf("%s: %s: %s", path, me.Keyword, me.Message)
+}
+
+// validator ... struct
+// JSON Schema draft-07 / 2020-12 (and OpenAPI 3.0 schema objects) on raw values.
+type validator struct {
+	ctx     context.Context
+	root    interface{}
+	errs    []ValidationError
+	regexps map[string]*regexp.Regexp
+	anchors map[string]interface{}
+}
+
+// maxValidateDepth ... const
+// guards against $ref cycles that do not consume the instance.
+const maxValidateDepth = 512
+
+func (me *validator) fail(path []interface{}, schemaPath string, keyword, format string, a ...interface{}) {
+
+	me.errs = append(me.errs, ValidationError{
+		Path:       Path2Pointer(path),
+		SchemaPath: schemaPath + "/" + keyword,
+		Keyword:    keyword,
+		Message:    fmt.Sprintf(format, a...),
+	})
+}
+
+func (me *validator) regexp(pattern string) (*regexp.Regexp, error) {
+
+	if re, ok := me.regexps[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Bad pattern: %s: %w", pattern, err)
+	}
+
+	me.regexps[pattern] = re
+
+	return re, nil
+}
+
+func (me *validator) collectAnchors(raw interface{}) {
+
+	switch typed := raw.(type) {
+	case map[string]interface{}:
+		if a, ok := typed["$anchor"].(string); ok {
+			me.anchors[a] = typed
+		}
+		if id, ok := typed["$id"].(string); ok && strings.HasPrefix(id, "#") && len(id) > 1 {
+			me.anchors[id[1:]] = typed
+		}
+		for _, v := range typed {
+			me.collectAnchors(v)
+		}
+	case []interface{}, *[]interface{}:
+		for _, v := range asSlice(typed) {
+			me.collectAnchors(v)
+		}
+	}
+}
+
+// resolveRef ... func
+// local references only: "#", "#/json/pointer" and "#anchor".
+func (me *validator) resolveRef(ref string) (interface{}, error) {
+
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("Unsupported $ref: %s", ref)
+	}
+
+	if len(ref) > 1 && ref[1] != '/' {
+		if v, ok := me.anchors[ref[1:]]; ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("$ref Not Found: %s", ref)
+	}
+
+	tokens, err := ParsePointer(ref)
+	if err != nil {
+		return nil, fmt.Errorf("Bad $ref: %s: %w", ref, err)
+	}
+
+	raw := me.root
+
+	for _, token := range tokens {
+
+		var key interface{} = token
+		if asSlice(raw) != nil {
+			pos, err := strconv.Atoi(token)
+			if err != nil {
+				return nil, fmt.Errorf("$ref Not Found: %s", ref)
+			}
+			key = pos
+		}
+
+		v, ok := containerRaw(raw, key)
+		if !ok {
+			return nil, fmt.Errorf("$ref Not Found: %s", ref)
+		}
+
+		var err error
+		if raw, err = resolveSpill(v); err != nil {
+			return nil, fmt.Errorf("$ref: %s: %w", ref, err)
+		}
+	}
+
+	return raw, nil
+}
+
+func typeMatches(name string, raw interface{}) bool {
+
+	switch name {
+	case "null":
+		return raw == nil
+	case "boolean":
+		_, ok := raw.(bool)
+		return ok
+	case "string":
+		_, ok := raw.(string)
+		return ok
+	case "number":
+		_, ok := jpNumber(raw)
+		return ok
+	case "integer":
+		f, ok := jpNumber(raw)
+		return ok && f == math.Trunc(f) && !math.IsInf(f, 0)
+	case "array":
+		return asSlice(raw) != nil
+	case "object":
+		_, ok := raw.(map[string]interface{})
+		return ok
+	}
+
+	return false
+}
+
+func stringList(raw interface{}) []string {
+
+	ret := []string{}
+
+	if s, ok := raw.(string); ok {
+		return append(ret, s)
+	}
+
+	for _, v := range asSlice(raw) {
+		if s, ok := v.(string); ok {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
+func rawText(raw interface{}) string {
+
+	data, err := New(raw).MarshalJSON()
+	if err != nil {
+		return fmt.Sprintf("%v", raw)
+	}
+
+	return string(data)
+}
+
+// valid ... func
+// validates in a scratch validator, for anyOf / oneOf / not / if / contains.
+func (me *validator) valid(path []interface{}, schemaPath string, raw, schema interface{}, depth int) (bool, error) {
+
+	sub := &validator{ctx: me.ctx, root: me.root, regexps: me.regexps, anchors: me.anchors}
+
+	err := sub.validate(path, schemaPath, raw, schema, depth)
+
+	return len(sub.errs) == 0, err
+}
+
+func (me *validator) validate(path []interface{}, schemaPath string, raw, schema interface{}, depth int) error {
+
+	if depth > maxValidateDepth {
+		return fmt.Errorf("%s: Too Deep (cyclic $ref?)", schemaPath)
+	}
+
+	if err := me.ctx.Err(); err != nil {
+		return err
+	}
+
+	raw, err := resolveSpill(raw)
+	if err != nil {
+		return fmt.Errorf("%s: %w", Path2Pointer(path), err)
+	}
+
+	schema, err = resolveSpill(schema)
+	if err != nil {
+		return fmt.Errorf("%s: %w", schemaPath, err)
+	}
+
+	switch typed := schema.(type) {
+	case bool:
+		if !typed {
+			me.fail(path, schemaPath, "false", "no value is allowed")
+		}
+		return nil
+	case map[string]interface{}:
+	default:
+		return fmt.Errorf("%s: Schema Not Object: %T", schemaPath, schema)
+	}
+
+	sch := schema.(map[string]interface{})
+
+	if ref, ok := sch["$ref"].(string); ok {
+
+		target, err := me.resolveRef(ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", schemaPath, err)
+		}
+
+		err = me.validate(path, ref, raw, target, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	// OpenAPI 3.0
+	if nullable, _ := sch["nullable"].(bool); nullable && raw == nil {
+		return nil
+	}
+
+	if t, ok := sch["type"]; ok {
+
+		names := stringList(t)
+		matched := false
+		for _, v := range names {
+			if typeMatches(v, raw) {
+				matched = true
+			}
+		}
+
+		if !matched {
+			me.fail(path, schemaPath, "type", "%s is not %s", kindOf(raw), strings.Join(names, " or "))
+		}
+	}
+
+	if enum, ok := sch["enum"]; ok {
+
+		found := false
+		for _, v := range asSlice(enum) {
+			if rawEqual(raw, v) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			me.fail(path, schemaPath, "enum", "%s is not one of %s", rawText(raw), rawText(enum))
+		}
+	}
+
+	if c, ok := sch["const"]; ok && !rawEqual(raw, c) {
+		me.fail(path, schemaPath, "const", "%s is not %s", rawText(raw), rawText(c))
+	}
+
+	if err := me.validateNumber(path, schemaPath, raw, sch); err != nil {
+		return err
+	}
+
+	if err := me.validateString(path, schemaPath, raw, sch); err != nil {
+		return err
+	}
+
+	if err := me.validateArray(path, schemaPath, raw, sch, depth); err != nil {
+		return err
+	}
+
+	if err := me.validateObject(path, schemaPath, raw, sch, depth); err != nil {
+		return err
+	}
+
+	return me.validateCombinators(path, schemaPath, raw, sch, depth)
+}
+
+func (me *validator) validateNumber(path []interface{}, schemaPath string, raw interface{}, sch map[string]interface{}) error {
+
+	f, ok := jpNumber(raw)
+	if !ok {
+		return nil
+	}
+
+	// draft-04 / OpenAPI 3.0 use booleans for the exclusive ones
+	exMin, _ := sch["exclusiveMinimum"].(bool)
+	exMax, _ := sch["exclusiveMaximum"].(bool)
+
+	if min, ok := jpNumber(sch["minimum"]); ok {
+		if f < min || exMin && f == min {
+			me.fail(path, schemaPath, "minimum", "%v is less than %v", f, min)
+		}
+	}
+
+	if max, ok := jpNumber(sch["maximum"]); ok {
+		if f > max || exMax && f == max {
+			me.fail(path, schemaPath, "maximum", "%v is greater than %v", f, max)
+		}
+	}
+
+	if min, ok := jpNumber(sch["exclusiveMinimum"]); ok && f <= min {
+		me.fail(path, schemaPath, "exclusiveMinimum", "%v is not greater than %v", f, min)
+	}
+
+	if max, ok := jpNumber(sch["exclusiveMaximum"]); ok && f >= max {
+		me.fail(path, schemaPath, "exclusiveMaximum", "%v is not less than %v", f, max)
+	}
+
+	if m, ok := jpNumber(sch["multipleOf"]); ok && m > 0 {
+		q := f / m
+		if math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+			me.fail(path, schemaPath, "multipleOf", "%v is not a multiple of %v", f, m)
+		}
+	}
+
+	return nil
+}
+
+func (me *validator) validateString(path []interface{}, schemaPath string, raw interface{}, sch map[string]interface{}) error {
+
+	s, ok := raw.(string)
+	if !ok {
+		return nil
+	}
+
+	n := utf8.RuneCountInString(s)
+
+	if min, ok := jpNumber(sch["minLength"]); ok && float64(n) < min {
+		me.fail(path, schemaPath, "minLength", "length %d is less than %v", n, min)
+	}
+
+	if max, ok := jpNumber(sch["maxLength"]); ok && float64(n) > max {
+		me.fail(path, schemaPath, "maxLength", "length %d is greater than %v", n, max)
+	}
+
+	if pattern, ok := sch["pattern"].(string); ok {
+
+		re, err := me.regexp(pattern)
+		if err != nil {
+			return fmt.Errorf("%s/pattern: %w", schemaPath, err)
+		}
+
+		if !re.MatchString(s) {
+			me.fail(path, schemaPath, "pattern", "%q does not match %s", s, pattern)
+		}
+	}
+
+	return nil
+}
+
+func (me *validator) validateArray(path []interface{}, schemaPath string, raw interface{}, sch map[string]interface{}, depth int) error {
+
+	arr := asSlice(raw)
+	if arr == nil {
+		return nil
+	}
+
+	if min, ok := jpNumber(sch["minItems"]); ok && float64(len(arr)) < min {
+		me.fail(path, schemaPath, "minItems", "%d items are less than %v", len(arr), min)
+	}
+
+	if max, ok := jpNumber(sch["maxItems"]); ok && float64(len(arr)) > max {
+		me.fail(path, schemaPath, "maxItems", "%d items are more than %v", len(arr), max)
+	}
+
+	if unique, _ := sch["uniqueItems"].(bool); unique {
+	outer:
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if rawEqual(arr[i], arr[j]) {
+					me.fail(path, schemaPath, "uniqueItems", "items %d and %d are equal", i, j)
+					break outer
+				}
+			}
+		}
+	}
+
+	// tuple: 2020-12 prefixItems or draft-07 items array
+	prefix, prefixKey := asSlice(sch["prefixItems"]), "prefixItems"
+	rest, restKey := sch["items"], "items"
+
+	if prefix == nil {
+		if tuple := asSlice(sch["items"]); tuple != nil {
+			prefix, prefixKey = tuple, "items"
+			rest, restKey = sch["additionalItems"], "additionalItems"
+		}
+	}
+
+	for i, v := range arr {
+
+		var sub interface{}
+		var subPath string
+
+		switch {
+		case i < len(prefix):
+			sub, subPath = prefix[i], fmt.Sprintf("%s/%s/%d", schemaPath, prefixKey, i)
+		case rest != nil:
+			sub, subPath = rest, schemaPath+"/"+restKey
+		default:
+			continue
+		}
+
+		err := me.validate(appendParents(path, i), subPath, v, sub, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	if contains, ok := sch["contains"]; ok {
+
+		count := 0
+		for i, v := range arr {
+			ok, err := me.valid(appendParents(path, i), schemaPath+"/contains", v, contains, depth+1)
+			if err != nil {
+				return err
+			}
+			if ok {
+				count++
+			}
+		}
+
+		min := 1.0
+		if v, ok := jpNumber(sch["minContains"]); ok {
+			min = v
+		}
+
+		if float64(count) < min {
+			me.fail(path, schemaPath, "contains", "%d matching items are less than %v", count, min)
+		}
+
+		if max, ok := jpNumber(sch["maxContains"]); ok && float64(count) > max {
+			me.fail(path, schemaPath, "maxContains", "%d matching items are more than %v", count, max)
+		}
+	}
+
+	return nil
+}
+
+func (me *validator) validateObject(path []interface{}, schemaPath string, raw interface{}, sch map[string]interface{}, depth int) error {
+
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	keys := mapKeys(obj, LexicalLess)
+
+	if min, ok := jpNumber(sch["minProperties"]); ok && float64(len(obj)) < min {
+		me.fail(path, schemaPath, "minProperties", "%d properties are less than %v", len(obj), min)
+	}
+
+	if max, ok := jpNumber(sch["maxProperties"]); ok && float64(len(obj)) > max {
+		me.fail(path, schemaPath, "maxProperties", "%d properties are more than %v", len(obj), max)
+	}
+
+	for _, k := range stringList(sch["required"]) {
+		if _, ok := obj[k]; !ok {
+			me.fail(path, schemaPath, "required", "%q is missing", k)
+		}
+	}
+
+	if deps, ok := sch["dependentRequired"].(map[string]interface{}); ok {
+		for _, k := range mapKeys(deps, LexicalLess) {
+			if _, ok := obj[k]; !ok {
+				continue
+			}
+			for _, d := range stringList(deps[k]) {
+				if _, ok := obj[d]; !ok {
+					me.fail(path, schemaPath, "dependentRequired", "%q is required by %q", d, k)
+				}
+			}
+		}
+	}
+
+	props, _ := sch["properties"].(map[string]interface{})
+	patterns, _ := sch["patternProperties"].(map[string]interface{})
+	patternKeys := mapKeys(patterns, LexicalLess)
+
+	for _, k := range keys {
+
+		sub := appendParents(path, k)
+		matched := false
+
+		if p, ok := props[k]; ok {
+			matched = true
+			err := me.validate(sub, schemaPath+"/properties/"+pointerEscaper.Replace(k), obj[k], p, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, pattern := range patternKeys {
+
+			re, err := me.regexp(pattern)
+			if err != nil {
+				return fmt.Errorf("%s/patternProperties: %w", schemaPath, err)
+			}
+
+			if re.MatchString(k) {
+				matched = true
+				err := me.validate(sub, schemaPath+"/patternProperties/"+pointerEscaper.Replace(pattern), obj[k], patterns[pattern], depth+1)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if additional, ok := sch["additionalProperties"]; ok && !matched {
+
+			if b, ok := additional.(bool); ok {
+				if !b {
+					me.fail(sub, schemaPath, "additionalProperties", "%q is not allowed", k)
+				}
+				continue
+			}
+
+			err := me.validate(sub, schemaPath+"/additionalProperties", obj[k], additional, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+
+		if names, ok := sch["propertyNames"]; ok {
+			ok, err := me.valid(sub, schemaPath+"/propertyNames", k, names, depth+1)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				me.fail(sub, schemaPath, "propertyNames", "%q is not a valid name", k)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (me *validator) validateCombinators(path []interface{}, schemaPath string, raw interface{}, sch map[string]interface{}, depth int) error {
+
+	for i, sub := range asSlice(sch["allOf"]) {
+		err := me.validate(path, fmt.Sprintf("%s/allOf/%d", schemaPath, i), raw, sub, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	count := func(keyword string) (int, int, error) {
+
+		subs := asSlice(sch[keyword])
+		n := 0
+
+		for i, sub := range subs {
+			ok, err := me.valid(path, fmt.Sprintf("%s/%s/%d", schemaPath, keyword, i), raw, sub, depth+1)
+			if err != nil {
+				return 0, 0, err
+			}
+			if ok {
+				n++
+			}
+		}
+
+		return n, len(subs), nil
+	}
+
+	if _, ok := sch["anyOf"]; ok {
+		n, _, err := count("anyOf")
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			me.fail(path, schemaPath, "anyOf", "no subschema matched")
+		}
+	}
+
+	if _, ok := sch["oneOf"]; ok {
+		n, _, err := count("oneOf")
+		if err != nil {
+			return err
+		}
+		if n != 1 {
+			me.fail(path, schemaPath, "oneOf", "%d subschemas matched instead of one", n)
+		}
+	}
+
+	if not, ok := sch["not"]; ok {
+		ok, err := me.valid(path, schemaPath+"/not", raw, not, depth+1)
+		if err != nil {
+			return err
+		}
+		if ok {
+			me.fail(path, schemaPath, "not", "subschema matched")
+		}
+	}
+
+	if cond, ok := sch["if"]; ok {
+
+		ok, err := me.valid(path, schemaPath+"/if", raw, cond, depth+1)
+		if err != nil {
+			return err
+		}
+
+		branch, key := sch["else"], "else"
+		if ok {
+			branch, key = sch["then"], "then"
+		}
+
+		if branch != nil {
+			err := me.validate(path, schemaPath+"/"+key, raw, branch, depth+1)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate ... func
+// checks me against a JSON Schema (draft-07 / 2020-12, OpenAPI 3.0 schema objects):
+// type, enum, const, numeric and string bounds, pattern, items, prefixItems, contains,
+// properties, required, additionalProperties, allOf, anyOf, oneOf, not, if/then/else
+// and local $ref ("#/..." or "#anchor"). format is not checked.
+// The error is for a broken schema, a valid document returns no ValidationError.
+func (me *JSONElement) Validate(schema *JSONElement) ([]ValidationError, error) {
+
+	errs, err := me.validateContext(context.Background(), schema)
+	if err != nil {
+		return nil, me.Errorf("Validate: %w", err)
+	}
+
+	return errs, nil
+}
+
+// ValidateContext ... func
+// same as Validate, ctx is checked at every value validated.
+func (me *JSONElement) ValidateContext(ctx context.Context, schema *JSONElement) ([]ValidationError, error) {
+
+	errs, err := me.validateContext(ctx, schema)
+	if err != nil {
+		return nil, me.Errorf("ValidateContext: %w", err)
+	}
+
+	return errs, nil
+}
+
+func (me *JSONElement) validateContext(ctx context.Context, schema *JSONElement) ([]ValidationError, error) {
+
+	if schema == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+
+	// "#/..." refers to the whole document, schema may be a part of it (OpenAPI components)
+	top := schema
+	for top.parent != nil {
+		top = top.parent
+	}
+
+	root, err := resolveSpill(top.Raw())
+	if err != nil {
+		return nil, err
+	}
+
+	v := &validator{
+		ctx:     ctx,
+		root:    root,
+		regexps: map[string]*regexp.Regexp{},
+		anchors: map[string]interface{}{},
+	}
+	v.collectAnchors(root)
+
+	err = v.validate(me.FullPath(), "#"+Path2Pointer(schema.FullPath()), me.Raw(), schema.Raw(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		return v.errs[i].Path < v.errs[j].Path
+	})
+
+	return v.errs, nil
+}
@@ -0,0 +1,138 @@
+package dynajson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validateMessages(errs []ValidationError) []string {
+
+	ret := []string{}
+	for _, v := range errs {
+		ret = append(ret, v.Error())
+	}
+
+	return ret
+}
+
+func TestValidate(t *testing.T) {
+
+	assert := assert.New(t)
+
+	schema, _ := NewByString(`{
+		"$defs": {"tag": {"type": "string", "pattern": "^[a-z]+$", "maxLength": 5}},
+		"type": "object",
+		"required": ["id", "name"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "integer", "minimum": 1},
+			"name": {"type": "string", "minLength": 1},
+			"kind": {"enum": ["a", "b"]},
+			"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}, "uniqueItems": true, "maxItems": 3},
+			"size": {"type": ["number", "null"], "exclusiveMaximum": 10, "multipleOf": 0.5},
+			"pair": {"prefixItems": [{"type": "string"}, {"type": "number"}], "items": false},
+			"choice": {"oneOf": [{"type": "string"}, {"type": "integer"}, {"type": "number"}]},
+			"shape": {
+				"if": {"properties": {"kind": {"const": "circle"}}},
+				"then": {"required": ["radius"]},
+				"else": {"required": ["width"]}
+			}
+		}
+	}`)
+
+	valid, _ := NewByString(`{"id": 1, "name": "x", "kind": "a", "tags": ["ab", "cd"], "size": null,
+		"pair": ["p", 1], "choice": "s", "shape": {"kind": "circle", "radius": 1}}`)
+
+	errs, err := valid.Validate(schema)
+	assert.Nil(err)
+	assert.Empty(errs)
+
+	invalid, _ := NewByString(`{"id": 0.5, "kind": "c", "tags": ["ab", "ab", "X1"], "size": 10,
+		"pair": ["p", 1, 2], "choice": 1, "shape": {"kind": "square"}, "extra": true}`)
+
+	errs, err = invalid.Validate(schema)
+	assert.Nil(err)
+	assert.Equal([]string{
+		"/: required: \"name\" is missing",
+		"/choice: oneOf: 2 subschemas matched instead of one",
+		"/extra: additionalProperties: \"extra\" is not allowed",
+		"/id: type: number is not integer",
+		"/id: minimum: 0.5 is less than 1",
+		"/kind: enum: \"c\" is not one of [\"a\", \"b\"]",
+		"/pair/2: false: no value is allowed",
+		"/shape: required: \"width\" is missing",
+		"/size: exclusiveMaximum: 10 is not less than 10",
+		"/tags: uniqueItems: items 0 and 1 are equal",
+		"/tags/2: pattern: \"X1\" does not match ^[a-z]+$",
+	}, validateMessages(errs))
+
+	assert.Equal("#/$defs/tag/pattern", errs[len(errs)-1].SchemaPath)
+	assert.Equal("#/properties/id/minimum", errs[4].SchemaPath)
+
+	// a subtree against a part of an OpenAPI document
+	doc, _ := NewByString(`{"components": {"schemas": {
+		"Pet": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string"}, "owner": {"$ref": "#/components/schemas/Owner"}}},
+		"Owner": {"type": "object", "properties": {"age": {"type": "integer", "nullable": true}}}
+	}}}`)
+
+	body, _ := NewByString(`{"req": {"name": "rex", "owner": {"age": "old"}}}`)
+
+	errs, err = body.Select("req").Validate(doc.Select("components", "schemas", "Pet"))
+	assert.Nil(err)
+	assert.Equal([]string{"/req/owner/age: type: string is not integer"}, validateMessages(errs))
+
+	body.Select("req", "owner").Put("age", nil)
+	errs, _ = body.Select("req").Validate(doc.Select("components", "schemas", "Pet"))
+	assert.Empty(errs)
+}
+
+func TestValidateKeywords(t *testing.T) {
+
+	assert := assert.New(t)
+
+	check := func(schema, value string) int {
+
+		s, err := NewByString(schema)
+		assert.Nil(err, schema)
+
+		v, err := NewByString(value)
+		assert.Nil(err, value)
+
+		errs, err := v.Validate(s)
+		assert.Nil(err, schema)
+
+		return len(errs)
+	}
+
+	assert.Equal(0, check(`true`, `1`))
+	assert.Equal(1, check(`false`, `1`))
+	assert.Equal(0, check(`{"anyOf": [{"type": "string"}, {"minimum": 2}]}`, `3`))
+	assert.Equal(1, check(`{"anyOf": [{"type": "string"}, {"minimum": 2}]}`, `1`))
+	assert.Equal(1, check(`{"not": {"type": "string"}}`, `"s"`))
+	assert.Equal(1, check(`{"allOf": [{"minimum": 1}, {"maximum": 2}]}`, `3`))
+	assert.Equal(0, check(`{"contains": {"const": 2}}`, `[1, 2]`))
+	assert.Equal(1, check(`{"contains": {"const": 2}, "maxContains": 1}`, `[2, 2]`))
+	assert.Equal(1, check(`{"items": [{"type": "string"}], "additionalItems": false}`, `["a", 1]`))
+	assert.Equal(1, check(`{"patternProperties": {"^x-": {"type": "string"}}}`, `{"x-a": 1, "b": 1}`))
+	assert.Equal(1, check(`{"propertyNames": {"maxLength": 2}}`, `{"abc": 1}`))
+	assert.Equal(1, check(`{"dependentRequired": {"a": ["b"]}}`, `{"a": 1}`))
+	assert.Equal(2, check(`{"minProperties": 2, "required": ["z"]}`, `{"a": 1}`))
+	assert.Equal(1, check(`{"maxLength": 2}`, `"日本語"`))
+	assert.Equal(1, check(`{"minimum": 1, "exclusiveMinimum": true}`, `1`))
+	assert.Equal(0, check(`{"multipleOf": 0.1}`, `0.3`))
+	assert.Equal(0, check(`{"$ref": "#node", "$defs": {"n": {"$anchor": "node", "type": "integer"}}}`, `1`))
+	assert.Equal(1, check(`{"type": "object", "properties": {"c": {"$ref": "#"}}}`, `{"c": {"c": 1}}`))
+
+	for _, bad := range []string{
+		`{"$ref": "#/nowhere"}`,
+		`{"$ref": "http://example.com/s.json"}`,
+		`{"pattern": "("}`,
+		`{"$ref": "#/$defs/a", "$defs": {"a": {"$ref": "#/$defs/a"}}}`,
+		`1`,
+	} {
+		s, _ := NewByString(bad)
+		_, err := New("x").Validate(s)
+		assert.NotNil(err, bad)
+	}
+}
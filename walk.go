@@ -0,0 +1,293 @@
+package dynajson
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WalkOrder ... type
+type WalkOrder int
+
+const (
+	// WalkPreOrder ... depth-first, parent before children (same as Walk)
+	WalkPreOrder WalkOrder = iota
+	// WalkPostOrder ... depth-first, children before parent
+	WalkPostOrder
+	// WalkBreadthFirst ... level by level
+	WalkBreadthFirst
+)
+
+type walkEntry struct {
+	parents []interface{}
+	key     interface{}
+	val     interface{}
+}
+
+func appendParents(parents []interface{}, key interface{}) []interface{} {
+
+	ret := make([]interface{}, len(parents), len(parents)+1)
+	copy(ret, parents)
+
+	return append(ret, key)
+}
+
+func walkEntries(parents []interface{}, argVal interface{}) ([]walkEntry, error) {
+
+	argVal, err := resolveSpill(argVal)
+	if err != nil {
+		return nil, fmt.Errorf("resolveSpill: %w", err)
+	}
+
+	entries := []walkEntry{}
+
+	switch v := argVal.(type) {
+	case []interface{}:
+		for k, sub := range v {
+			entries = append(entries, walkEntry{parents, k, sub})
+		}
+	case *[]interface{}:
+		for k, sub := range *v {
+			entries = append(entries, walkEntry{parents, k, sub})
+		}
+	case map[string]interface{}:
+		for k, sub := range v {
+			entries = append(entries, walkEntry{parents, k, sub})
+		}
+	}
+
+	for i := range entries {
+
+		entries[i].val, err = resolveSpill(entries[i].val)
+		if err != nil {
+			return nil, fmt.Errorf("%v: resolveSpill: %w", entries[i].key, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// WalkOptions ... struct
+type WalkOptions struct {
+	Order WalkOrder
+	// SortKeys visits map members by KeyLess (lexical when nil) instead of at random.
+	SortKeys bool
+	// MaxDepth > 0 visits only the nodes up to that depth, 1 is the children of me.
+	MaxDepth int
+}
+
+type walker struct {
+	opts     WalkOptions
+	less     func(a, b string) bool
+	callback walkCallbackType
+}
+
+func (me *walker) entries(parents []interface{}, raw interface{}) ([]walkEntry, error) {
+
+	entries, err := walkEntries(parents, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if me.less != nil && len(entries) > 0 {
+
+		if _, ok := entries[0].key.(string); ok {
+			sort.Slice(entries, func(i, j int) bool {
+				return me.less(entries[i].key.(string), entries[j].key.(string))
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+func (me *walker) descend(parents []interface{}) bool {
+	return me.opts.MaxDepth <= 0 || len(parents)+1 < me.opts.MaxDepth
+}
+
+func (me *walker) visit(v walkEntry) (bool, error) {
+
+	cont, err := me.callback(v.parents, v.key, v.val)
+	if err != nil {
+		return false, fmt.Errorf("%v: callback: %w", v.key, err)
+	}
+
+	return cont, nil
+}
+
+func (me *walker) depthFirst(parents []interface{}, raw interface{}) (bool, error) {
+
+	entries, err := me.entries(parents, raw)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range entries {
+
+		if me.opts.Order == WalkPreOrder {
+			if cont, err := me.visit(v); err != nil || !cont {
+				return false, err
+			}
+		}
+
+		if me.descend(v.parents) {
+
+			cont, err := me.depthFirst(appendParents(v.parents, v.key), v.val)
+			if err != nil {
+				return false, fmt.Errorf("%v: walk: %w", v.key, err)
+			}
+
+			if !cont {
+				return false, nil
+			}
+		}
+
+		if me.opts.Order == WalkPostOrder {
+			if cont, err := me.visit(v); err != nil || !cont {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (me *walker) breadthFirst(raw interface{}) error {
+
+	queue, err := me.entries([]interface{}{}, raw)
+	if err != nil {
+		return err
+	}
+
+	for len(queue) > 0 {
+
+		v := queue[0]
+		queue = queue[1:]
+
+		if cont, err := me.visit(v); err != nil || !cont {
+			return err
+		}
+
+		if me.descend(v.parents) {
+
+			entries, err := me.entries(appendParents(v.parents, v.key), v.val)
+			if err != nil {
+				return fmt.Errorf("%v: walk: %w", v.key, err)
+			}
+
+			queue = append(queue, entries...)
+		}
+	}
+
+	return nil
+}
+
+func (me *JSONElement) walkWith(opts WalkOptions, callback walkCallbackType) error {
+
+	w := &walker{opts: opts, callback: callback}
+
+	if opts.SortKeys {
+		w.less = me.KeyLess
+		if w.less == nil {
+			w.less = LexicalLess
+		}
+	}
+
+	if opts.Order == WalkBreadthFirst {
+		return w.breadthFirst(me.raw)
+	}
+
+	_, err := w.depthFirst([]interface{}{}, me.raw)
+
+	return err
+}
+
+func validWalkOrder(order WalkOrder) bool {
+	return order == WalkPreOrder || order == WalkPostOrder || order == WalkBreadthFirst
+}
+
+// WalkOrdered ... func
+// same as Walk with a selectable traversal order, returning false from callback stops the walk.
+func (me *JSONElement) WalkOrdered(order WalkOrder, callback walkCallbackType) error {
+
+	if !validWalkOrder(order) {
+		return me.Errorf("WalkOrdered: Bad Order: %d", order)
+	}
+
+	return me.walkWith(WalkOptions{Order: order}, callback)
+}
+
+// Match ... struct
+// Path is relative to the element FindAll was called on.
+type Match struct {
+	Path    []interface{}
+	Element *JSONElement
+}
+
+// FindAll ... func
+// the elements under me (me included) pred accepts, depth-first with map keys in
+// KeyLess (default lexical) order. The elements can be edited in place.
+func (me *JSONElement) FindAll(pred func(path []interface{}, elm *JSONElement) bool) []*Match {
+
+	matches := []*Match{}
+
+	var find func(path []interface{}, elm *JSONElement)
+	find = func(path []interface{}, elm *JSONElement) {
+
+		if pred(path, elm) {
+			matches = append(matches, &Match{Path: path, Element: elm})
+		}
+
+		for _, v := range jpChildren(elm) {
+			find(appendParents(path, v.key), v)
+		}
+	}
+
+	find([]interface{}{}, me)
+
+	return matches
+}
+
+// WalkPointer ... func
+// same as Walk, passing the RFC 6901 pointer (relative to me) and the element of
+// every node below me. Map members are visited in KeyLess (default lexical) order.
+func (me *JSONElement) WalkPointer(callback func(pointer string, elm *JSONElement) (bool, error)) error {
+
+	var visit func(prefix string, elm *JSONElement) (bool, error)
+	visit = func(prefix string, elm *JSONElement) (bool, error) {
+
+		for _, v := range jpChildren(elm) {
+
+			ptr := prefix + "/" + pointerEscaper.Replace(fmt.Sprintf("%v", v.key))
+
+			cont, err := callback(ptr, v)
+			if err != nil {
+				return false, fmt.Errorf("%s: callback: %w", ptr, err)
+			}
+
+			if !cont {
+				return false, nil
+			}
+
+			if cont, err = visit(ptr, v); err != nil || !cont {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+
+	_, err := visit("", me)
+
+	return err
+}
+
+// WalkWith ... func
+// Walk with a traversal order, deterministic map order and a depth bound.
+func (me *JSONElement) WalkWith(opts WalkOptions, callback walkCallbackType) error {
+
+	if !validWalkOrder(opts.Order) {
+		return me.Errorf("WalkWith: Bad Order: %d", opts.Order)
+	}
+
+	return me.walkWith(opts, callback)
+}
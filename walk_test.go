@@ -0,0 +1,197 @@
+package dynajson
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkOrdered(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`[[[1]], 2, [3]]`)
+	assert.Nil(err)
+
+	collect := func(order WalkOrder, limit int) []string {
+
+		keys := []string{}
+		err := root.WalkOrdered(order, func(parents []interface{}, key, val interface{}) (bool, error) {
+			keys = append(keys, FullPath2Str(append(parents, key), "/"))
+			return len(keys) < limit, nil
+		})
+		assert.Nil(err)
+
+		return keys
+	}
+
+	assert.Equal([]string{"/0", "/0/0", "/0/0/0", "/1", "/2", "/2/0"}, collect(WalkPreOrder, 100))
+	assert.Equal([]string{"/0/0/0", "/0/0", "/0", "/1", "/2/0", "/2"}, collect(WalkPostOrder, 100))
+	assert.Equal([]string{"/0", "/1", "/2", "/0/0", "/2/0", "/0/0/0"}, collect(WalkBreadthFirst, 100))
+	assert.Equal([]string{"/0", "/1", "/2"}, collect(WalkBreadthFirst, 3))
+
+	assert.NotNil(root.WalkOrdered(WalkOrder(99), nil))
+}
+
+func TestFindAll(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"users": [{"name": "a", "password": "x"}, {"name": "b", "auth": {"password": "y"}}], "password": null}`)
+	assert.Nil(err)
+
+	matches := root.FindAll(func(path []interface{}, elm *JSONElement) bool {
+		return len(path) > 0 && path[len(path)-1] == "password"
+	})
+
+	assert.Equal(3, len(matches))
+	assert.Equal([]interface{}{"password"}, matches[0].Path)
+	assert.Equal([]interface{}{"users", 0, "password"}, matches[1].Path)
+	assert.Equal([]interface{}{"users", 1, "auth", "password"}, matches[2].Path)
+
+	// act on the matches afterwards
+	for _, m := range matches {
+		assert.Nil(m.Element.Parent().Put("password", "***"))
+	}
+	assert.Equal("***", root.Select("users", 1, "auth", "password").AsString())
+	assert.Equal("***", root.Select("password").AsString())
+
+	// by value, paths relative to the receiver
+	users := root.Select("users")
+	named := users.FindAll(func(path []interface{}, elm *JSONElement) bool {
+		return elm.Raw() == "b"
+	})
+	assert.Equal(1, len(named))
+	assert.Equal([]interface{}{1, "name"}, named[0].Path)
+	assert.Equal("/users/1/name", named[0].Element.PathString())
+
+	all := root.FindAll(func([]interface{}, *JSONElement) bool { return true })
+	assert.Equal(root, all[0].Element)
+	assert.Equal(10, len(all))
+}
+
+func TestWalkParentsStable(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [[[1, 2], [3, 4]], [[5]]]}`)
+	assert.Nil(err)
+
+	kept := map[float64][]interface{}{}
+
+	err = root.Walk(func(parents []interface{}, key, val interface{}) (bool, error) {
+		if f, ok := val.(float64); ok {
+			kept[f] = append(parents, key)
+		}
+		return true, nil
+	})
+	assert.Nil(err)
+
+	assert.Equal([]interface{}{"a", 0, 0, 0}, kept[1])
+	assert.Equal([]interface{}{"a", 0, 0, 1}, kept[2])
+	assert.Equal([]interface{}{"a", 0, 1, 0}, kept[3])
+	assert.Equal([]interface{}{"a", 0, 1, 1}, kept[4])
+	assert.Equal([]interface{}{"a", 1, 0, 0}, kept[5])
+}
+
+func TestWalkPointer(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"b": [true, {"c/d": 1}], "a~": null}`)
+	assert.Nil(err)
+
+	ptrs := []string{}
+
+	err = root.WalkPointer(func(ptr string, elm *JSONElement) (bool, error) {
+		ptrs = append(ptrs, ptr)
+		assert.Equal(ptr, elm.PathString())
+		return true, nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"/a~0", "/b", "/b/0", "/b/1", "/b/1/c~1d"}, ptrs)
+
+	// relative to the receiver, stops on false
+	ptrs = []string{}
+
+	err = root.Select("b").WalkPointer(func(ptr string, elm *JSONElement) (bool, error) {
+		ptrs = append(ptrs, ptr)
+		return ptr != "/1", nil
+	})
+	assert.Nil(err)
+	assert.Equal([]string{"/0", "/1"}, ptrs)
+
+	err = root.WalkPointer(func(ptr string, elm *JSONElement) (bool, error) {
+		return true, errors.New("x")
+	})
+	assert.Equal("/a~0: callback: x", err.Error())
+}
+
+func TestWalkWith(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"c": {"z": 1, "y": [2]}, "a": 3, "b": {"x": 4}}`)
+	assert.Nil(err)
+
+	collect := func(opts WalkOptions) []string {
+		ret := []string{}
+		err := root.WalkWith(opts, func(parents []interface{}, key, val interface{}) (bool, error) {
+			ret = append(ret, Path2Pointer(appendParents(parents, key)))
+			return true, nil
+		})
+		assert.Nil(err)
+		return ret
+	}
+
+	assert.Equal([]string{"/a", "/b", "/b/x", "/c", "/c/y", "/c/y/0", "/c/z"},
+		collect(WalkOptions{SortKeys: true}))
+
+	assert.Equal([]string{"/a", "/b/x", "/b", "/c/y/0", "/c/y", "/c/z", "/c"},
+		collect(WalkOptions{Order: WalkPostOrder, SortKeys: true}))
+
+	assert.Equal([]string{"/a", "/b", "/c", "/b/x", "/c/y", "/c/z", "/c/y/0"},
+		collect(WalkOptions{Order: WalkBreadthFirst, SortKeys: true}))
+
+	assert.Equal([]string{"/a", "/b", "/b/x", "/c", "/c/y", "/c/z"},
+		collect(WalkOptions{SortKeys: true, MaxDepth: 2}))
+
+	assert.Equal([]string{"/a", "/b", "/c"},
+		collect(WalkOptions{Order: WalkBreadthFirst, SortKeys: true, MaxDepth: 1}))
+
+	// KeyLess decides the order
+	root.KeyLess = func(a, b string) bool { return a > b }
+	assert.Equal([]string{"/c", "/b", "/a"}, collect(WalkOptions{SortKeys: true, MaxDepth: 1}))
+	root.KeyLess = nil
+
+	count := 0
+	err = root.WalkWith(WalkOptions{SortKeys: true}, func(parents []interface{}, key, val interface{}) (bool, error) {
+		count++
+		return count < 3, nil
+	})
+	assert.Nil(err)
+	assert.Equal(3, count)
+
+	assert.NotNil(root.WalkWith(WalkOptions{Order: WalkOrder(9)}, func(parents []interface{}, key, val interface{}) (bool, error) {
+		return true, nil
+	}))
+	// members of a spilled map are sorted too
+	dir, err := ioutil.TempDir("", "dynajson")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	jsonPath := filepath.Join(dir, "walk.json")
+	assert.Nil(ioutil.WriteFile(jsonPath, []byte(`{"big": {"z": "0123456789", "y": 1, "x": 2, "w": 3}}`), 0644))
+
+	root, err = NewByPathSpill(jsonPath, 20)
+	assert.Nil(err)
+	_, ok := root.Raw().(map[string]interface{})["big"].(*spillRef)
+	assert.True(ok)
+
+	assert.Equal([]string{"/big", "/big/w", "/big/x", "/big/y", "/big/z"}, collect(WalkOptions{SortKeys: true}))
+	assert.Equal([]string{"/big/w", "/big/x", "/big/y", "/big/z", "/big"}, collect(WalkOptions{Order: WalkPostOrder, SortKeys: true}))
+}
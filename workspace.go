@@ -0,0 +1,258 @@
+package dynajson
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Workspace ... struct
+// holds named roots (usually paths or URLs) and resolves "other-doc#/path"
+// references between them.
+type Workspace struct {
+	// Fetcher loads documents not added yet, nil means GetDefaultFetcher().
+	// Set AutoLoad to false to resolve only added documents.
+	Fetcher  Fetcher
+	AutoLoad bool
+
+	mu   sync.RWMutex
+	docs map[string]*JSONElement
+}
+
+// WorkspaceMatch ... struct
+type WorkspaceMatch struct {
+	Name    string
+	Path    []interface{}
+	Element *JSONElement
+}
+
+// NewWorkspace ... func
+func NewWorkspace() *Workspace {
+
+	return &Workspace{
+		AutoLoad: true,
+		docs:     map[string]*JSONElement{},
+	}
+}
+
+// Add ... func
+// registers root as name, replacing a document of the same name.
+func (me *Workspace) Add(name string, root *JSONElement) {
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	me.docs[name] = root
+}
+
+// Get ... func
+// returns nil when name is not in the workspace.
+func (me *Workspace) Get(name string) *JSONElement {
+
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+
+	return me.docs[name]
+}
+
+// Names ... func
+func (me *Workspace) Names() []string {
+
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+
+	names := make([]string, 0, len(me.docs))
+	for k := range me.docs {
+		names = append(names, k)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Load ... func
+// fetches argPath and registers it under argPath, an already added document is returned as is.
+func (me *Workspace) Load(ctx context.Context, argPath string) (*JSONElement, error) {
+
+	if root := me.Get(argPath); root != nil {
+		return root, nil
+	}
+
+	root, err := loadByPath(ctx, argPath, loadConfig{fetcher: me.Fetcher})
+	if err != nil {
+		return nil, fmt.Errorf("Workspace.Load: %w", err)
+	}
+
+	me.mu.Lock()
+	defer me.mu.Unlock()
+
+	if prev, ok := me.docs[argPath]; ok {
+		return prev, nil
+	}
+
+	me.docs[argPath] = root
+
+	return root, nil
+}
+
+// resolveName ... func
+// docRef relative to from, as a $ref in the document from would be.
+func (me *Workspace) resolveName(from, docRef string) string {
+
+	if docRef == "" {
+		return from
+	}
+
+	if me.Get(docRef) != nil || from == "" {
+		return docRef
+	}
+
+	if strings.Contains(from, "://") {
+
+		base, err := url.Parse(from)
+		if err != nil {
+			return docRef
+		}
+
+		ref, err := url.Parse(docRef)
+		if err != nil {
+			return docRef
+		}
+
+		return base.ResolveReference(ref).String()
+	}
+
+	if strings.Contains(docRef, "://") || path.IsAbs(docRef) {
+		return docRef
+	}
+
+	return path.Join(path.Dir(from), docRef)
+}
+
+// Resolve ... func
+// ref is "doc#/json/pointer", "doc" or "#/json/pointer", relative to the document from.
+// Returns the document name the ref points into along with the element.
+func (me *Workspace) Resolve(ctx context.Context, from string, ref string) (string, *JSONElement, error) {
+
+	docRef, fragment := ref, ""
+	if i := strings.Index(ref, "#"); i >= 0 {
+		docRef, fragment = ref[:i], ref[i+1:]
+	}
+
+	name := me.resolveName(from, docRef)
+
+	root := me.Get(name)
+	if root == nil {
+
+		if !me.AutoLoad {
+			return "", nil, fmt.Errorf("Workspace.Resolve: %s: %w", name, os.ErrNotExist)
+		}
+
+		var err error
+
+		root, err = me.Load(ctx, name)
+		if err != nil {
+			return "", nil, fmt.Errorf("Workspace.Resolve: %s: %w", ref, err)
+		}
+	}
+
+	tokens, err := ParsePointer(fragment)
+	if err != nil {
+		return "", nil, fmt.Errorf("Workspace.Resolve: %s: %w", ref, err)
+	}
+
+	elm := root.selectTokens(tokens)
+	if elm.IsNil() {
+		return "", nil, fmt.Errorf("Workspace.Resolve: %s: No Such Path", ref)
+	}
+
+	return name, elm, nil
+}
+
+// Each ... func
+// calls callback for every document in name order, returning false stops.
+func (me *Workspace) Each(callback func(string, *JSONElement) (bool, error)) error {
+
+	for _, name := range me.Names() {
+
+		cont, err := callback(name, me.Get(name))
+		if err != nil {
+			return fmt.Errorf("Workspace.Each: %s: %w", name, err)
+		}
+
+		if !cont {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Query ... func
+// returns the elements of every document whose path matches pattern (see MatchPath),
+// ordered by document name and then JSON Pointer.
+func (me *Workspace) Query(pattern string) ([]WorkspaceMatch, error) {
+
+	matches := []WorkspaceMatch{}
+
+	err := me.Each(func(name string, root *JSONElement) (bool, error) {
+
+		found := []WorkspaceMatch{}
+
+		err := root.Walk(func(parents []interface{}, key interface{}, val interface{}) (bool, error) {
+
+			keys := appendParents(parents, key)
+
+			if MatchPath(pattern, keys) {
+				found = append(found, WorkspaceMatch{
+					Name:    name,
+					Path:    keys,
+					Element: root.Select(keys),
+				})
+			}
+
+			return true, nil
+		})
+		if err != nil {
+			return false, err
+		}
+
+		sort.Slice(found, func(i, j int) bool {
+			return Path2Pointer(found[i].Path) < Path2Pointer(found[j].Path)
+		})
+
+		matches = append(matches, found...)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Workspace.Query: %s: %w", pattern, err)
+	}
+
+	return matches, nil
+}
+
+// Refs ... func
+// lists every "$ref" string in the workspace as name + JSON Pointer of the $ref key.
+func (me *Workspace) Refs() ([]WorkspaceMatch, error) {
+
+	all, err := me.Query("**/$ref")
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []WorkspaceMatch{}
+	for _, v := range all {
+		if _, ok := v.Element.Raw().(string); ok {
+			refs = append(refs, v)
+		}
+	}
+
+	return refs, nil
+}
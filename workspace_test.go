@@ -0,0 +1,65 @@
+package dynajson
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkspace(t *testing.T) {
+
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	ws := NewWorkspace()
+	ws.Fetcher = MapFetcher{
+		"specs/common/errors.json":         []byte(`{"Error": {"type": "object", "properties": {"code": {"type": "integer"}}}}`),
+		"https://example.com/lib/pet.json": []byte(`{"Pet": {"$ref": "#/Animal"}, "Animal": {"type": "object"}}`),
+	}
+
+	api, err := NewByString(`{"paths": {"/pets": {"$ref": "https://example.com/lib/pet.json#/Pet"}, "/err": {"$ref": "common/errors.json#/Error"}}}`)
+	assert.Nil(err)
+	ws.Add("specs/api.json", api)
+
+	name, elm, err := ws.Resolve(ctx, "specs/api.json", "common/errors.json#/Error/properties/code")
+	assert.Nil(err)
+	assert.Equal("specs/common/errors.json", name)
+	assert.Equal("integer", elm.Select("type").AsString())
+
+	name, elm, err = ws.Resolve(ctx, "specs/api.json", "#/paths/~1pets")
+	assert.Nil(err)
+	assert.Equal("specs/api.json", name)
+	assert.Equal("https://example.com/lib/pet.json#/Pet", elm.Select("$ref").AsString())
+
+	_, _, err = ws.Resolve(ctx, "https://example.com/lib/pet.json", "#/Missing")
+	assert.NotNil(err)
+
+	name, _, err = ws.Resolve(ctx, "specs/api.json", "https://example.com/lib/pet.json#/Pet")
+	assert.Nil(err)
+	name, elm, err = ws.Resolve(ctx, name, "#/Animal")
+	assert.Nil(err)
+	assert.Equal("object", elm.Select("type").AsString())
+
+	assert.Equal([]string{"https://example.com/lib/pet.json", "specs/api.json", "specs/common/errors.json"}, ws.Names())
+
+	_, _, err = ws.Resolve(ctx, "specs/api.json", "#/nothing")
+	assert.NotNil(err)
+
+	refs, err := ws.Refs()
+	assert.Nil(err)
+	assert.Equal(3, len(refs))
+	assert.Equal("https://example.com/lib/pet.json", refs[0].Name)
+	assert.Equal([]interface{}{"paths", "/err", "$ref"}, refs[1].Path)
+	assert.Equal("/paths/~1pets/$ref", Path2Pointer(refs[2].Path))
+
+	types, err := ws.Query("**/type")
+	assert.Nil(err)
+	assert.Equal(3, len(types))
+
+	ws.AutoLoad = false
+	_, _, err = ws.Resolve(ctx, "specs/api.json", "other.json#/x")
+	assert.True(errors.Is(err, os.ErrNotExist))
+}
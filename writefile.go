@@ -0,0 +1,78 @@
+package dynajson
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic ... func
+// writes data to a temporary file in the same directory and renames it over
+// filePath, so readers see either the old or the new content.
+func writeFileAtomic(filePath string, data []byte, perm os.FileMode) error {
+
+	dir := filepath.Dir(filePath)
+
+	tmp, err := ioutil.TempFile(dir, "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return fmt.Errorf("TempFile: %s: %w", dir, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("Write: %s: %w", tmp.Name(), err)
+	}
+
+	err = os.Rename(tmp.Name(), filePath)
+	if err != nil {
+		return fmt.Errorf("Rename: %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// WriteFile ... func
+// replaces argPath atomically with me formatted by opts.
+func (me *JSONElement) WriteFile(argPath string, perm os.FileMode, opts DumpOptions) error {
+
+	data, err := me.Marshal(opts)
+	if err != nil {
+		return me.Errorf("WriteFile: %w", err)
+	}
+
+	err = writeFileAtomic(argPath, data, perm)
+	if err != nil {
+		return me.Errorf("WriteFile: %w", err)
+	}
+
+	return nil
+}
+
+// WriteTo ... func
+// implements io.WriterTo, writes the same text as MarshalJSON.
+func (me *JSONElement) WriteTo(w io.Writer) (int64, error) {
+
+	data, err := me.Marshal(DumpOptions{})
+	if err != nil {
+		return 0, me.Errorf("WriteTo: %w", err)
+	}
+
+	n, err := w.Write(data)
+	if err != nil {
+		return int64(n), me.Errorf("WriteTo: %w", err)
+	}
+
+	return int64(n), nil
+}
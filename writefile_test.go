@@ -0,0 +1,65 @@
+package dynajson
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "dynajson")
+	assert.Nil(err)
+	defer os.RemoveAll(dir)
+
+	root, err := NewByString(`{"b": 1, "a": [true]}`)
+	assert.Nil(err)
+
+	filePath := filepath.Join(dir, "out.json")
+
+	err = root.WriteFile(filePath, 0600, DumpOptions{Indent: "  ", SortKeys: true, TrailingNewline: true})
+	assert.Nil(err)
+
+	data, err := ioutil.ReadFile(filePath)
+	assert.Nil(err)
+	assert.Equal("{\n  \"a\": [\n    true\n  ],\n  \"b\": 1\n}\n", string(data))
+
+	info, err := os.Stat(filePath)
+	assert.Nil(err)
+	assert.Equal(os.FileMode(0600), info.Mode().Perm())
+
+	// replaced, no temporary file is left behind
+	root.Put("b", 2)
+	assert.Nil(root.WriteFile(filePath, 0644, DumpOptions{SortKeys: true}))
+
+	data, err = ioutil.ReadFile(filePath)
+	assert.Nil(err)
+	assert.Equal(`{"a": [true], "b": 2}`, string(data))
+
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(err)
+	assert.Equal(1, len(files))
+
+	assert.NotNil(root.WriteFile(filepath.Join(dir, "no", "such.json"), 0644, DumpOptions{}))
+}
+
+func TestWriteTo(t *testing.T) {
+
+	assert := assert.New(t)
+
+	root, err := NewByString(`{"a": [1, "x"]}`)
+	assert.Nil(err)
+
+	buf := &bytes.Buffer{}
+
+	n, err := root.WriteTo(buf)
+	assert.Nil(err)
+	assert.Equal(int64(buf.Len()), n)
+	assert.Equal(`{"a": [1, "x"]}`, buf.String())
+}
@@ -0,0 +1,151 @@
+package dynajson
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// ArrayWriter ... struct
+// emits a JSON array incrementally, flushing every chunkSize elements.
+type ArrayWriter struct {
+	w         *bufio.Writer
+	chunkSize int
+	count     int
+	pending   int
+	closed    bool
+}
+
+// NewArrayWriter ... func
+func NewArrayWriter(w io.Writer, chunkSize int) *ArrayWriter {
+
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+
+	return &ArrayWriter{
+		w:         bufio.NewWriter(w),
+		chunkSize: chunkSize,
+	}
+}
+
+// Count ... func
+func (me *ArrayWriter) Count() int {
+	return me.count
+}
+
+func (me *ArrayWriter) writeOne(val interface{}) error {
+
+	buf := &bytes.Buffer{}
+
+	if me.count == 0 {
+		buf.WriteString("[")
+	} else {
+		buf.WriteString(", ")
+	}
+
+	raw := elm2Raw(val)
+	Dump(&raw, buf)
+
+	_, err := me.w.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("Write: %w", err)
+	}
+
+	me.count++
+	me.pending++
+
+	if me.pending >= me.chunkSize {
+		return me.Flush()
+	}
+
+	return nil
+}
+
+// Append ... func
+func (me *ArrayWriter) Append(val1 interface{}, vals ...interface{}) error {
+
+	if me.closed {
+		return fmt.Errorf("Append: Already Closed")
+	}
+
+	err := me.writeOne(val1)
+	if err != nil {
+		return fmt.Errorf("Append(%d): %w", me.count, err)
+	}
+
+	for _, v := range vals {
+
+		err := me.writeOne(v)
+		if err != nil {
+			return fmt.Errorf("Append(%d): %w", me.count, err)
+		}
+	}
+
+	return nil
+}
+
+// Flush ... func
+func (me *ArrayWriter) Flush() error {
+
+	err := me.w.Flush()
+	if err != nil {
+		return fmt.Errorf("Flush: %w", err)
+	}
+
+	me.pending = 0
+
+	return nil
+}
+
+// Close ... func
+// terminates the array, the underlying writer is not closed.
+func (me *ArrayWriter) Close() error {
+
+	if me.closed {
+		return nil
+	}
+
+	me.closed = true
+
+	tail := "]"
+	if me.count == 0 {
+		tail = "[]"
+	}
+
+	_, err := me.w.WriteString(tail)
+	if err != nil {
+		return fmt.Errorf("Close: Write: %w", err)
+	}
+
+	return me.Flush()
+}
+
+// WriteArrayChunks ... func
+func (me *JSONElement) WriteArrayChunks(w io.Writer, chunkSize int) error {
+
+	if me.IsNil() {
+		return me.fail("WriteArrayChunks", ErrorNilObject, "")
+	}
+
+	if !me.IsArray() {
+		return me.fail("WriteArrayChunks", ErrorNotArray, "%T", me.raw)
+	}
+
+	aw := NewArrayWriter(w, chunkSize)
+
+	err := me.EachArray(func(i int, elm *JSONElement) (bool, error) {
+		return true, aw.Append(elm.raw)
+	})
+	if err != nil {
+		return me.Errorf("WriteArrayChunks: %w", err)
+	}
+
+	err = aw.Close()
+	if err != nil {
+		return me.Errorf("WriteArrayChunks: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package dynajson
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayWriter(t *testing.T) {
+
+	assert := assert.New(t)
+
+	buf := &bytes.Buffer{}
+	aw := NewArrayWriter(buf, 2)
+
+	assert.Nil(aw.Append(1))
+	assert.Equal("", buf.String())
+
+	assert.Nil(aw.Append("a", NewAsMap()))
+	assert.Equal(`[1, "a"`, buf.String())
+
+	assert.Nil(aw.Close())
+	assert.Equal(`[1, "a", {}]`, buf.String())
+	assert.Equal(3, aw.Count())
+	assert.NotNil(aw.Append(2))
+
+	buf.Reset()
+	empty := NewArrayWriter(buf, 10)
+	assert.Nil(empty.Close())
+	assert.Equal(`[]`, buf.String())
+
+	root, err := NewByString(`{"arr": [1, "x", [2]]}`)
+	assert.Nil(err)
+
+	buf.Reset()
+	assert.Nil(root.Select("arr").WriteArrayChunks(buf, 1))
+	assert.Equal(`[1, "x", [2]]`, buf.String())
+
+	assert.NotNil(root.WriteArrayChunks(buf, 1))
+}
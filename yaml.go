@@ -0,0 +1,366 @@
+package dynajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MediaTypeYAML ... const
+const MediaTypeYAML = "application/yaml"
+
+func init() {
+	RegisterDecoder(MediaTypeYAML, decodeYAML, ".yaml", ".yml")
+}
+
+func decodeYAML(data []byte) (interface{}, error) {
+	return yamlDecode(data, nil)
+}
+
+// yamlAliasBudget ... const
+// the number of nodes aliases may expand to, or the size of the input when
+// that is larger (billion laughs).
+const yamlAliasBudget = 100000
+
+// yamlDecoder ... struct
+type yamlDecoder struct {
+	budget   int
+	aliasing int
+}
+
+func yamlDecode(data []byte, order *keyOrderState) (interface{}, error) {
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+
+	var doc yaml.Node
+
+	err := dec.Decode(&doc)
+	if err == io.EOF || (err == nil && doc.Kind == 0) {
+		// empty document
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("yaml.Decode: %w", err)
+	}
+
+	// a stream of documents is not silently cut to the first one
+	var next yaml.Node
+
+	if err := dec.Decode(&next); err != io.EOF {
+		if err == nil {
+			return nil, fmt.Errorf("line %d: Multiple Documents", next.Line)
+		}
+		return nil, fmt.Errorf("yaml.Decode: %w", err)
+	}
+
+	x := &yamlDecoder{budget: yamlAliasBudget}
+	if len(data) > x.budget {
+		x.budget = len(data)
+	}
+
+	return x.node2Raw(&doc, order)
+}
+
+// node2Raw ... func
+// mappings become maps (keys in source order when order is given), sequences arrays.
+func (me *yamlDecoder) node2Raw(node *yaml.Node, order *keyOrderState) (interface{}, error) {
+
+	if me.aliasing > 0 {
+		me.budget--
+		if me.budget < 0 {
+			return nil, fmt.Errorf("line %d: Excessive Aliasing", node.Line)
+		}
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil, nil
+		}
+		return me.node2Raw(node.Content[0], order)
+
+	case yaml.AliasNode:
+		me.aliasing++
+		defer func() { me.aliasing-- }()
+
+		return me.node2Raw(node.Alias, order)
+
+	case yaml.SequenceNode:
+		arr := make([]interface{}, len(node.Content))
+		for i, v := range node.Content {
+			sub, err := me.node2Raw(v, order)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = sub
+		}
+		return arr, nil
+
+	case yaml.MappingNode:
+		obj := map[string]interface{}{}
+		keys := []string{}
+
+		err := me.mapping(node, obj, &keys, order)
+		if err != nil {
+			return nil, err
+		}
+
+		if order != nil {
+			order.record(obj, keys)
+		}
+
+		return obj, nil
+	}
+
+	var val interface{}
+
+	err := node.Decode(&val)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", node.Line, err)
+	}
+
+	switch typed := val.(type) {
+	case time.Time:
+		return typed.Format(time.RFC3339Nano), nil
+	case int:
+		return float64(typed), nil
+	case int64:
+		return float64(typed), nil
+	case uint64:
+		return float64(typed), nil
+	case float64:
+		// .inf and .nan have no JSON form
+		if math.IsInf(typed, 0) || math.IsNaN(typed) {
+			return nil, fmt.Errorf("line %d: %s: Not JSON Number", node.Line, node.Value)
+		}
+	case []byte:
+		return string(typed), nil
+	}
+
+	return val, nil
+}
+
+// mapping ... func
+// explicit keys win over the ones merged by "<<".
+func (me *yamlDecoder) mapping(node *yaml.Node, obj map[string]interface{}, keys *[]string, order *keyOrderState) error {
+
+	merges := []*yaml.Node{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+
+		kn, vn := node.Content[i], node.Content[i+1]
+
+		if kn.Tag == "!!merge" {
+			merges = append(merges, vn)
+			continue
+		}
+
+		var key string
+
+		if kn.Kind == yaml.ScalarNode {
+			key = kn.Value
+		} else {
+			raw, err := me.node2Raw(kn, nil)
+			if err != nil {
+				return err
+			}
+			key = fmt.Sprintf("%v", raw)
+		}
+
+		val, err := me.node2Raw(vn, order)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := obj[key]; !ok {
+			*keys = append(*keys, key)
+		}
+		obj[key] = val
+	}
+
+	// merged mappings are expansions like aliases
+	me.aliasing++
+	defer func() { me.aliasing-- }()
+
+	for _, mn := range merges {
+
+		if mn.Kind == yaml.AliasNode {
+			mn = mn.Alias
+		}
+
+		srcs := []*yaml.Node{mn}
+		if mn.Kind == yaml.SequenceNode {
+			srcs = mn.Content
+		}
+
+		for _, src := range srcs {
+
+			if src.Kind == yaml.AliasNode {
+				src = src.Alias
+			}
+
+			if src.Kind != yaml.MappingNode {
+				return fmt.Errorf("line %d: merge of non-mapping", src.Line)
+			}
+
+			sub := map[string]interface{}{}
+			subKeys := []string{}
+
+			err := me.mapping(src, sub, &subKeys, order)
+			if err != nil {
+				return err
+			}
+
+			for _, k := range subKeys {
+				if _, ok := obj[k]; !ok {
+					*keys = append(*keys, k)
+					obj[k] = sub[k]
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewByYAML ... func
+// loads a YAML document (anchors and merge keys are resolved), map keys keep
+// the order of the source as with ParseOptions.PreserveOrder.
+func NewByYAML(data []byte) (*JSONElement, error) {
+
+	order := newKeyOrderState()
+
+	obj, err := yamlDecode(data, order)
+	if err != nil {
+		return nil, fmt.Errorf("NewByYAML: %w", err)
+	}
+
+	elm := New(obj)
+	elm.order = order
+
+	return elm, nil
+}
+
+func scalarNode(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+func floatNode(f float64) *yaml.Node {
+
+	switch {
+	case math.IsNaN(f):
+		return scalarNode("!!float", ".nan")
+	case math.IsInf(f, 1):
+		return scalarNode("!!float", ".inf")
+	case math.IsInf(f, -1):
+		return scalarNode("!!float", "-.inf")
+	case f == math.Trunc(f) && math.Abs(f) < 1e15:
+		return scalarNode("!!int", strconv.FormatFloat(f, 'f', -1, 64))
+	}
+
+	return scalarNode("!!float", strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+func (me *JSONElement) raw2YAMLNode(raw interface{}) (*yaml.Node, error) {
+
+	switch typed := raw.(type) {
+	case nil:
+		return scalarNode("!!null", "null"), nil
+	case bool:
+		return scalarNode("!!bool", strconv.FormatBool(typed)), nil
+	case string:
+		return scalarNode("!!str", typed), nil
+	case int:
+		return scalarNode("!!int", strconv.Itoa(typed)), nil
+	case float64:
+		return floatNode(typed), nil
+	case json.Number:
+		if _, err := typed.Int64(); err == nil {
+			return scalarNode("!!int", typed.String()), nil
+		}
+		return scalarNode("!!float", typed.String()), nil
+
+	case *spillRef:
+		obj, err := resolveSpill(typed)
+		if err != nil {
+			return nil, err
+		}
+		return me.raw2YAMLNode(obj)
+
+	case []interface{}, *[]interface{}:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, v := range asSlice(typed) {
+			sub, err := me.raw2YAMLNode(v)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, sub)
+		}
+		return node, nil
+
+	case map[string]interface{}:
+		less := me.KeyLess
+		if less == nil {
+			less = LexicalLess
+		}
+
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		for _, k := range me.order.keys(typed, less) {
+			sub, err := me.raw2YAMLNode(typed[k])
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, scalarNode("!!str", k), sub)
+		}
+		return node, nil
+	}
+
+	// whatever was Put (e.g. int64) goes through JSON
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj interface{}
+
+	err = json.Unmarshal(data, &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return me.raw2YAMLNode(obj)
+}
+
+// ToYAML ... func
+// writes me as a block style YAML document, map keys in insertion order for
+// ordered elements, otherwise by KeyLess (lexical when nil).
+func (me *JSONElement) ToYAML() ([]byte, error) {
+
+	node, err := me.raw2YAMLNode(me.raw)
+	if err != nil {
+		return nil, me.Errorf("ToYAML: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+
+	err = enc.Encode(node)
+	if err != nil {
+		return nil, me.Errorf("ToYAML: %w", err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, me.Errorf("ToYAML: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
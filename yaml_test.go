@@ -0,0 +1,125 @@
+package dynajson
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewByYAML(t *testing.T) {
+
+	assert := assert.New(t)
+
+	src := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels: &labels
+    app: web
+    tier: "1"
+spec:
+  containers:
+    - name: nginx
+      image: nginx:1.19
+      ports:
+        - containerPort: 80
+  extra:
+    <<: *labels
+    tier: front
+  ratio: 0.5
+  enabled: true
+  empty: ~
+  created: 2020-01-02T03:04:05Z
+`
+
+	elm, err := NewByYAML([]byte(src))
+	assert.Nil(err)
+
+	assert.Equal([]string{"apiVersion", "kind", "metadata", "spec"}, elm.Keys())
+	assert.Equal("nginx:1.19", elm.Select("spec", "containers", 0, "image").AsString())
+	assert.Equal(80, elm.Select("spec", "containers", 0, "ports", 0, "containerPort").AsInt())
+	assert.Equal("1", elm.Select("metadata", "labels", "tier").AsString())
+	assert.Equal("front", elm.Select("spec", "extra", "tier").AsString())
+	assert.Equal("web", elm.Select("spec", "extra", "app").AsString())
+	assert.Equal(0.5, elm.Select("spec", "ratio").AsFloat())
+	assert.True(elm.Select("spec", "enabled").AsBool())
+	assert.True(elm.Select("spec", "empty").IsNil())
+	assert.Equal("2020-01-02T03:04:05Z", elm.Select("spec", "created").AsString())
+
+	_, err = NewByYAML([]byte("a: [1, 2"))
+	assert.NotNil(err)
+
+	empty, err := NewByYAML([]byte(""))
+	assert.Nil(err)
+	assert.True(empty.IsNil())
+
+	// only one document
+	_, err = NewByYAML([]byte("a: 1\n---\nb: 2\n"))
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "Multiple Documents")
+
+	elm, err = NewByYAML([]byte("---\na: 1\n"))
+	assert.Nil(err)
+	assert.Equal(1, elm.Select("a").AsInt())
+
+	for _, bad := range []string{"a: .inf", "a: -.Inf", "a: .nan", "[1, .NaN]"} {
+		_, err = NewByYAML([]byte(bad))
+		assert.NotNil(err, bad)
+	}
+
+	// billion laughs
+	laughs := "a: &a [\"lol\", \"lol\", \"lol\", \"lol\", \"lol\", \"lol\", \"lol\", \"lol\", \"lol\"]\n"
+	prev := "a"
+	for _, name := range []string{"b", "c", "d", "e", "f", "g", "h", "i"} {
+		laughs += name + ": &" + name + " [" + strings.Repeat("*"+prev+", ", 8) + "*" + prev + "]\n"
+		prev = name
+	}
+
+	_, err = NewByYAML([]byte(laughs))
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "Excessive Aliasing")
+
+	// aliases within reason
+	elm, err = NewByYAML([]byte("a: &a {x: 1}\nb: [*a, *a]\nc:\n  <<: *a\n  y: 2\n"))
+	assert.Nil(err)
+	assert.Equal(`{"a": {"x": 1}, "b": [{"x": 1}, {"x": 1}], "c": {"y": 2, "x": 1}}`, elm.String())
+}
+
+func TestToYAML(t *testing.T) {
+
+	assert := assert.New(t)
+
+	elm, _ := NewByString(`{"b": {"y": [1, 2.5, "true"], "x": null}, "a": "text", "c": true}`)
+
+	data, err := elm.ToYAML()
+	assert.Nil(err)
+	assert.Equal("a: text\nb:\n  x: null\n  y:\n    - 1\n    - 2.5\n    - \"true\"\nc: true\n", string(data))
+
+	back, err := NewByYAML(data)
+	assert.Nil(err)
+	assert.True(rawEqual(elm.Raw(), back.Raw()))
+
+	src := "z: 1\na:\n  m: 2\n  b: 3\n"
+
+	ordered, _ := NewByYAML([]byte(src))
+	data, err = ordered.ToYAML()
+	assert.Nil(err)
+	assert.Equal(src, string(data))
+}
+
+func TestNewByPathYAML(t *testing.T) {
+
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "conf.yml")
+	assert.Nil(ioutil.WriteFile(path, []byte("name: app\nport: 8080\n"), 0644))
+
+	elm, err := NewByPath(path)
+	assert.Nil(err)
+	assert.Equal(8080, elm.Select("port").AsInt())
+	assert.Contains(AcceptHeader(), MediaTypeYAML)
+}